@@ -147,8 +147,20 @@ class JimmCharm(SystemdCharm):
             "bakery_public_key": self.config.get("public-key", ""),
             "bakery_private_key": self.config.get("private-key", ""),
             "audit_retention_period": self.config.get("audit-log-retention-period-in-days", ""),
+            "audit_sinks": self.config.get("audit-sinks", ""),
+            "health_check_cache_ttl": self.config.get("health-check-cache-ttl", ""),
+            "health_check_timeout": self.config.get("health-check-timeout", ""),
+            "jwks_algorithm": self.config.get("jwks-algorithm", ""),
             "jwt_expiry": self.config.get("jwt-expiry", "5m"),
             "macaroon_expiry_duration": self.config.get("macaroon-expiry-duration"),
+            "oauth_allowed_domains": self.config.get("oauth-allowed-domains", ""),
+            "oauth_groups_claim": self.config.get("oauth-groups-claim", ""),
+            "oauth_groups_pattern": self.config.get("oauth-groups-pattern", ""),
+            "oauth_groups_name_template": self.config.get("oauth-groups-name-template", ""),
+            "oauth_identity_claim": self.config.get("oauth-identity-claim", ""),
+            "oauth_identity_suffix": self.config.get("oauth-identity-suffix", ""),
+            "oauth_providers": self.config.get("oauth-providers", ""),
+            "oauth_require_email_verified": self.config.get("oauth-require-email-verified", False),
             "session_expiry_duration": self.config.get("session-expiry-duration"),
             "secure_session_cookies": self.config.get("secure-session-cookies"),
             "session_cookie_max_age": self.config.get("session-cookie-max-age"),
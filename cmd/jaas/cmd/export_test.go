@@ -46,3 +46,30 @@ func NewGrantCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginPro
 
 	return modelcmd.WrapBase(cmd)
 }
+
+func NewListServiceAccountsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listServiceAccountsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewRemoveServiceAccountCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &removeServiceAccountCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+func NewListServiceAccountAdministratorsCommandForTesting(store jujuclient.ClientStore, lp jujuapi.LoginProvider) cmd.Command {
+	cmd := &listServiceAccountAdministratorsCommand{
+		store:    store,
+		dialOpts: cmdtest.TestDialOpts(lp),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
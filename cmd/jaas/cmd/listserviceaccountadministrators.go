@@ -0,0 +1,109 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	listServiceAccountAdministratorsCommandDoc = `
+list-service-account-administrators lists the identities and groups that have administrator access over a service account.
+
+Only administrators of the service account may list its administrators.
+`
+	listServiceAccountAdministratorsCommandExamples = `
+    juju list-service-account-administrators <client-id>
+    juju list-service-account-administrators <client-id> --format json
+`
+)
+
+// NewListServiceAccountAdministratorsCommand returns a command to list the
+// administrators of a service account.
+func NewListServiceAccountAdministratorsCommand() cmd.Command {
+	cmd := &listServiceAccountAdministratorsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listServiceAccountAdministratorsCommand lists the administrators of a
+// service account.
+type listServiceAccountAdministratorsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	clientID string
+}
+
+// Info implements Command.Info.
+func (c *listServiceAccountAdministratorsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "list-service-account-administrators",
+		Purpose:  "List the administrators of a service account",
+		Args:     "<client-id>",
+		Doc:      listServiceAccountAdministratorsCommandDoc,
+		Examples: listServiceAccountAdministratorsCommandExamples,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listServiceAccountAdministratorsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listServiceAccountAdministratorsCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("client ID not specified")
+	}
+	c.clientID = args[0]
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listServiceAccountAdministratorsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	params := apiparams.ListServiceAccountAdministratorsRequest{
+		ClientID: c.clientID,
+	}
+	client := api.NewClient(apiCaller)
+	administrators, err := client.ListServiceAccountAdministrators(&params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, administrators)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type listServiceAccountAdministratorsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&listServiceAccountAdministratorsSuite{})
+
+func (s *listServiceAccountAdministratorsSuite) TestListServiceAccountAdministrators(c *gc.C) {
+	clientID := "abda51b2-d735-4794-a8bd-49c506baa4af"
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID)
+	c.Assert(err, gc.IsNil)
+	_, err = cmdtesting.RunCommand(c, cmd.NewGrantCommandForTesting(s.ClientStore(), bClient), clientID, "user-bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+
+	cmdContext, err := cmdtesting.RunCommand(c, cmd.NewListServiceAccountAdministratorsCommandForTesting(s.ClientStore(), bClient), clientID)
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdContext), gc.Equals, "- user-alice@canonical.com\n- user-bob@canonical.com\n")
+}
+
+func (s *listServiceAccountAdministratorsSuite) TestListServiceAccountAdministratorsUnauthorized(c *gc.C) {
+	clientID := "abda51b2-d735-4794-a8bd-49c506baa4af"
+	// bob does not administer the service account
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListServiceAccountAdministratorsCommandForTesting(s.ClientStore(), bClient), clientID)
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	listServiceAccountsCommandDoc = `
+list-service-accounts lists the service accounts you have administrator access over.
+`
+	listServiceAccountsCommandExamples = `
+    juju list-service-accounts
+    juju list-service-accounts --format yaml
+`
+)
+
+// NewListServiceAccountsCommand returns a command to list the service
+// accounts administered by the current user.
+func NewListServiceAccountsCommand() cmd.Command {
+	cmd := &listServiceAccountsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listServiceAccountsCommand lists the service accounts administered by
+// the current user.
+type listServiceAccountsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements Command.Info.
+func (c *listServiceAccountsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "list-service-accounts",
+		Purpose:  "List the service accounts you administer",
+		Doc:      listServiceAccountsCommandDoc,
+		Examples: listServiceAccountsCommandExamples,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listServiceAccountsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatServiceAccountsTabular,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listServiceAccountsCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *listServiceAccountsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	svcAccs, err := client.ListServiceAccounts()
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, svcAccs)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// formatServiceAccountsTabular writes a tabular list of service accounts.
+func formatServiceAccountsTabular(writer io.Writer, value interface{}) error {
+	svcAccs, ok := value.([]apiparams.ServiceAccount)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", svcAccs, value))
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Client ID")
+	for _, svcAcc := range svcAccs {
+		w.Println(svcAcc.ClientID)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type listServiceAccountsSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&listServiceAccountsSuite{})
+
+func (s *listServiceAccountsSuite) TestListServiceAccounts(c *gc.C) {
+	clientID := "abda51b2-d735-4794-a8bd-49c506baa4af"
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewAddServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID)
+	c.Assert(err, gc.IsNil)
+
+	cmdContext, err := cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClient), "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdContext), gc.Equals, "- client-id: abda51b2-d735-4794-a8bd-49c506baa4af@serviceaccount\n")
+
+	cmdContext, err = cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdContext), gc.Equals, "Client ID\nabda51b2-d735-4794-a8bd-49c506baa4af@serviceaccount\n")
+
+	// bob does not administer any service accounts.
+	bClientBob := s.SetupCLIAccess(c, "bob")
+	cmdContext, err = cmdtesting.RunCommand(c, cmd.NewListServiceAccountsCommandForTesting(s.ClientStore(), bClientBob), "--format", "yaml")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(cmdContext), gc.Equals, "[]\n")
+}
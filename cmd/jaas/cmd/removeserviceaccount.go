@@ -0,0 +1,122 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	removeServiceAccountCommandDoc = `
+remove-service-account removes a service account from JAAS.
+
+The service account's cloud credentials and all of its relations are
+removed. A service account that still owns models cannot be removed,
+its models must be destroyed first.
+`
+	removeServiceAccountCommandExamples = `
+    juju remove-service-account <client-id>
+    juju remove-service-account <client-id> -y
+`
+)
+
+// NewRemoveServiceAccountCommand returns a command to remove a service
+// account.
+func NewRemoveServiceAccountCommand() cmd.Command {
+	cmd := &removeServiceAccountCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeServiceAccountCommand removes a service account.
+type removeServiceAccountCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+	clientID string
+	force    bool
+}
+
+// Info implements Command.Info.
+func (c *removeServiceAccountCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:     "remove-service-account",
+		Purpose:  "Remove a service account",
+		Args:     "<client-id>",
+		Doc:      removeServiceAccountCommandDoc,
+		Examples: removeServiceAccountCommandExamples,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeServiceAccountCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "y", false, "remove service account without prompt")
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeServiceAccountCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("client ID not specified")
+	}
+	c.clientID = args[0]
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *removeServiceAccountCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	if !c.force {
+		reader := bufio.NewReader(ctxt.Stdin)
+		// Using Fprintf to avoid printing a new line.
+		_, err := fmt.Fprintf(ctxt.Stdout, "This will also delete the service account's cloud credentials and relations.\nConfirm you would like to remove service account %q (y/N): ", c.clientID)
+		if err != nil {
+			return err
+		}
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return errors.E(err, "Failed to read from input.")
+		}
+		text = strings.ReplaceAll(text, "\n", "")
+		if !(text == "y" || text == "Y") {
+			return nil
+		}
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	params := apiparams.RemoveServiceAccountRequest{
+		ClientID: c.clientID,
+	}
+	client := api.NewClient(apiCaller)
+	if err := client.RemoveServiceAccount(&params); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jaas/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+type removeServiceAccountSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&removeServiceAccountSuite{})
+
+func (s *removeServiceAccountSuite) TestRemoveServiceAccount(c *gc.C) {
+	ctx := context.Background()
+	clientID := "abda51b2-d735-4794-a8bd-49c506baa4af"
+	clientIDWithDomain := clientID + "@serviceaccount"
+
+	sa, err := dbmodel.NewIdentity(clientIDWithDomain)
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.GetIdentity(ctx, sa)
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewAddServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID)
+	c.Assert(err, gc.IsNil)
+
+	// bob does not administer the service account
+	bClientBob := s.SetupCLIAccess(c, "bob")
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveServiceAccountCommandForTesting(s.ClientStore(), bClientBob), clientID, "-y")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewRemoveServiceAccountCommandForTesting(s.ClientStore(), bClient), clientID, "-y")
+	c.Assert(err, gc.IsNil)
+
+	ok, err := s.JIMM.OpenFGAClient.CheckRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag("alice@canonical.com")),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(jimmnames.NewServiceAccountTag(clientIDWithDomain)),
+	}, false)
+	c.Assert(err, gc.IsNil)
+	c.Assert(ok, gc.Equals, false)
+
+	err = s.JIMM.Database.FetchIdentity(ctx, &dbmodel.Identity{Name: clientIDWithDomain})
+	c.Assert(err, gc.ErrorMatches, "record not found")
+}
@@ -30,6 +30,9 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 	serviceAccountCmd.Register(cmd.NewListServiceAccountCredentialsCommand())
 	serviceAccountCmd.Register(cmd.NewUpdateCredentialCommand())
 	serviceAccountCmd.Register(cmd.NewGrantCommand())
+	serviceAccountCmd.Register(cmd.NewListServiceAccountsCommand())
+	serviceAccountCmd.Register(cmd.NewRemoveServiceAccountCommand())
+	serviceAccountCmd.Register(cmd.NewListServiceAccountAdministratorsCommand())
 	return serviceAccountCmd
 }
 
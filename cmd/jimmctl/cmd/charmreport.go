@@ -0,0 +1,163 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var charmReportDoc = `
+	charm-report displays every application deployed on every controller,
+	grouped by charm, with the channel, revision and base of each one.
+
+	Applications running an older revision of their charm than the newest
+	deployed from charmhub anywhere in the fleet are flagged as outdated.
+	Charmhub revisions are only compared between applications deployed
+	from the same channel, base and architecture.
+	Applications deployed from any of the channels given with
+	--deprecated-channels are flagged as well. A deprecated channel may be
+	a complete channel, such as "14/edge", or a track, such as "14".
+
+	Example:
+		jimmctl charm-report
+		jimmctl charm-report --charm postgresql --format tabular
+		jimmctl charm-report --deprecated-channels 12,13,latest/edge
+`
+
+// NewCharmReportCommand returns a command used to report the charms
+// deployed across all controllers.
+func NewCharmReportCommand() cmd.Command {
+	cmd := &charmReportCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// charmReportCommand reports the charms deployed across all controllers.
+type charmReportCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	charm              string
+	deprecatedChannels string
+}
+
+func (c *charmReportCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "charm-report",
+		Purpose: "Report the charms deployed across all controllers.",
+		Doc:     charmReportDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *charmReportCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCharmReportTabular,
+	})
+	f.StringVar(&c.charm, "charm", "", "only report applications running the charm with this name")
+	f.StringVar(&c.deprecatedChannels, "deprecated-channels", "", "comma separated list of deprecated channels or tracks")
+}
+
+// Init implements the cmd.Command interface.
+func (c *charmReportCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *charmReportCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	req := apiparams.CharmReportRequest{
+		Charm: c.charm,
+	}
+	for _, channel := range strings.Split(c.deprecatedChannels, ",") {
+		if channel = strings.TrimSpace(channel); channel != "" {
+			req.DeprecatedChannels = append(req.DeprecatedChannels, channel)
+		}
+	}
+	report, err := client.CharmReport(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, report)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func formatCharmReportTabular(writer io.Writer, value interface{}) error {
+	report, ok := value.(apiparams.CharmReport)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", report, value))
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 50
+	table.Wrap = true
+
+	table.AddRow("Charm", "Latest", "Controller", "Model", "Application", "Channel", "Rev", "Base", "Notes")
+	for _, charm := range report.Charms {
+		for _, app := range charm.Applications {
+			var notes []string
+			if app.Outdated {
+				notes = append(notes, "outdated")
+			}
+			if app.DeprecatedChannel {
+				notes = append(notes, "deprecated channel")
+			}
+			table.AddRow(charm.Name, app.LatestRevision, app.Controller, app.ModelName, app.Application, app.Channel, app.Revision, app.Base, strings.Join(notes, ", "))
+		}
+	}
+	fmt.Fprint(writer, table)
+
+	if len(report.Errors) != 0 {
+		fmt.Fprintf(writer, "\n\n")
+		fmt.Fprintln(writer, "Errors")
+		models := make([]string, 0, len(report.Errors))
+		for model := range report.Errors {
+			models = append(models, model)
+		}
+		sort.Strings(models)
+		for _, model := range models {
+			fmt.Fprintf(writer, "%s: %s\n", model, report.Errors[model])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+type charmReportSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&charmReportSuite{})
+
+func (s *charmReportSuite) TestCharmReport(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewCharmReportCommandForTesting(s.ClientStore(), bClient), "--deprecated-channels", "14,latest/edge")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "charms: []\n")
+}
+
+func (s *charmReportSuite) TestCharmReportUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewCharmReportCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *charmReportSuite) TestCharmReportInvalidArguments(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewCharmReportCommandForTesting(s.ClientStore(), bClient), "extra")
+	c.Assert(err, gc.ErrorMatches, `unknown arguments`)
+}
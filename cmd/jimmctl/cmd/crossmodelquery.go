@@ -25,10 +25,28 @@ the collated query responses for each model.
 The query will run against the exact output of "juju status --format json",
 as such you can format your query against an output like this.
 
-The queries will expect a JQ query string.
-
-Example:
+By default queries are JQ query strings. The --type flag selects another
+query language:
+	jq       - a JQ query.
+	jmespath - a JMESPath expression.
+	filter   - a filter expression over the model's entities, such as
+	           application.charm == "postgresql" && unit.status != "active".
+	           Fields are referenced as <entity>.<field> where entity is one
+	           of model, application, unit or machine. The names of the
+	           matching entities are returned.
+
+The --controller, --cloud, --region, --owner, --model-name and --group
+flags restrict the query to the models matching all of the given values.
+
+With --page-size the models are queried in pages of the given size and
+the results of each page are written as soon as they are available.
+
+Examples:
 	jimmctl query-models '.applications | with_entries(select(.key=="nginx-ingress-integrator"))'
+	jimmctl query-models --page-size 50 '.model.name'
+	jimmctl query-models --type jmespath 'applications.*.charm'
+	jimmctl query-models --controller prod-1 --model-name 'db-*' '.applications | keys'
+	jimmctl query-models --type filter 'application.charm == "postgresql" && unit.status != "active"'
 `
 )
 
@@ -41,6 +59,10 @@ type crossModelQueryCommand struct {
 	query string
 	// queryType holds the type of query the user wishes to use.
 	queryType string
+	// pageSize holds the number of models to query in each request.
+	pageSize int
+	// selector holds the selector restricting the models queried.
+	selector apiparams.ModelSelector
 
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
@@ -63,7 +85,9 @@ func (c *crossModelQueryCommand) Init(args []string) error {
 		return errors.New("no query specified")
 	}
 	c.query = args[0]
-	c.queryType = "jq"
+	if c.pageSize < 0 {
+		return errors.New("page size must not be negative")
+	}
 	return nil
 }
 
@@ -74,6 +98,14 @@ func (c *crossModelQueryCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 	})
+	f.StringVar(&c.queryType, "type", "jq", "the query language, one of jq, jmespath or filter")
+	f.StringVar(&c.selector.Controller, "controller", "", "only query models hosted on this controller")
+	f.StringVar(&c.selector.Cloud, "cloud", "", "only query models hosted on this cloud")
+	f.StringVar(&c.selector.Region, "region", "", "only query models hosted in this cloud region")
+	f.StringVar(&c.selector.Owner, "owner", "", "only query models owned by this user")
+	f.StringVar(&c.selector.Name, "model-name", "", "only query models with names matching this glob pattern")
+	f.StringVar(&c.selector.Group, "group", "", "only query models administered by this group")
+	f.IntVar(&c.pageSize, "page-size", 0, "query the models in pages of this size, writing each page as it arrives")
 	c.file.StdinMarkers = stdinMarkers
 }
 
@@ -99,19 +131,32 @@ func (c *crossModelQueryCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	req := apiparams.CrossModelQueryRequest{
-		Type:  c.queryType,
-		Query: c.query,
+		Type:     c.queryType,
+		Query:    c.query,
+		Selector: c.selector,
 	}
 
 	client := api.NewClient(apiCaller)
-	resp, err := client.CrossModelQuery(&req)
-	if err != nil {
-		return errors.Mask(err)
+	if c.pageSize == 0 {
+		resp, err := client.CrossModelQuery(&req)
+		if err != nil {
+			return errors.Mask(err)
+		}
+		return errors.Mask(c.out.Write(ctxt, resp))
 	}
 
-	err = c.out.Write(ctxt, resp)
-	if err != nil {
-		return errors.Mask(err)
+	req.Limit = c.pageSize
+	for {
+		resp, err := client.CrossModelQuery(&req)
+		if err != nil {
+			return errors.Mask(err)
+		}
+		if err := c.out.Write(ctxt, resp); err != nil {
+			return errors.Mask(err)
+		}
+		req.Offset += c.pageSize
+		if req.Offset >= resp.Total {
+			return nil
+		}
 	}
-	return nil
 }
@@ -4,6 +4,8 @@ package cmd_test
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 
 	"github.com/juju/cmd/v3/cmdtesting"
 	jujuparams "github.com/juju/juju/rpc/params"
@@ -14,6 +16,7 @@ import (
 	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
 	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
 type crossModelQuerySuite struct {
@@ -63,3 +66,81 @@ func (s *crossModelQuerySuite) TestCrossModelQueryCommand(c *gc.C) {
 		c.Assert(len(testModel), gc.Equals, 8)
 	}
 }
+
+func (s *crossModelQuerySuite) TestCrossModelQueryCommandPaged(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	s.AddController(c, "controller-2", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-2", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewCrossModelQueryCommandForTesting(s.ClientStore(), bClient), "--page-size", "1", ".model.name")
+	c.Assert(err, gc.IsNil)
+
+	// Each page is written as a separate JSON document.
+	dec := json.NewDecoder(strings.NewReader(cmdtesting.Stdout(cmdCtx)))
+	var modelNames []string
+	for dec.More() {
+		var page apiparams.CrossModelQueryResponse
+		c.Assert(dec.Decode(&page), gc.IsNil)
+		c.Check(page.Total, gc.Equals, 2)
+		c.Check(page.Errors, gc.HasLen, 0)
+		c.Assert(page.Results, gc.HasLen, 1)
+		for _, v := range page.Results {
+			c.Assert(v, gc.HasLen, 1)
+			modelNames = append(modelNames, v[0].(string))
+		}
+	}
+	sort.Strings(modelNames)
+	c.Check(modelNames, gc.DeepEquals, []string{"model-1", "model-2"})
+}
+
+func (s *crossModelQuerySuite) TestCrossModelQueryCommandInvalidPageSize(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	_, err := cmdtesting.RunCommand(c, cmd.NewCrossModelQueryCommandForTesting(s.ClientStore(), bClient), "--page-size", "-1", ".")
+	c.Assert(err, gc.ErrorMatches, "page size must not be negative")
+}
+
+func (s *crossModelQuerySuite) TestCrossModelQueryCommandFilterType(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	s.AddController(c, "controller-2", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewCrossModelQueryCommandForTesting(s.ClientStore(), bClient), "--type", "filter", `model.name == "model-1"`)
+	c.Assert(err, gc.IsNil)
+
+	var resp apiparams.CrossModelQueryResponse
+	c.Assert(json.Unmarshal([]byte(cmdtesting.Stdout(cmdCtx)), &resp), gc.IsNil)
+	c.Check(resp.Errors, gc.HasLen, 0)
+	c.Assert(resp.Results, gc.HasLen, 1)
+	for _, v := range resp.Results {
+		c.Check(v, gc.DeepEquals, []any{map[string]any{"model": "model-1"}})
+	}
+}
+
+func (s *crossModelQuerySuite) TestCrossModelQueryCommandSelector(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	s.AddController(c, "controller-2", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/alice@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "db-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	s.AddModel(c, names.NewUserTag("alice@canonical.com"), "web-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	cmdCtx, err := cmdtesting.RunCommand(c, cmd.NewCrossModelQueryCommandForTesting(s.ClientStore(), bClient), "--model-name", "db-*", "--owner", "alice@canonical.com", ".model.name")
+	c.Assert(err, gc.IsNil)
+
+	var resp apiparams.CrossModelQueryResponse
+	c.Assert(json.Unmarshal([]byte(cmdtesting.Stdout(cmdCtx)), &resp), gc.IsNil)
+	c.Check(resp.Errors, gc.HasLen, 0)
+	c.Assert(resp.Results, gc.HasLen, 1)
+	for _, v := range resp.Results {
+		c.Check(v, gc.DeepEquals, []any{"db-1"})
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var drainControllerDoc = `
+	drain-controller migrates every model off a controller so that it can
+	be decommissioned. The controller is marked as deprecated and each model
+	is migrated to another controller hosting the same cloud region, chosen
+	in the same way as for a new model.
+
+	Migrations run in the background, use --status to follow the progress
+	of the drain and --pause, --resume or --abort to control it. Aborting a
+	drain does not stop migrations that are already in progress.
+
+	Use --dry-run to show where each model would be migrated to without
+	changing anything.
+
+	The drain and its progress are stored in JIMM's database and the drain
+	is run by the leader JIMM unit, so --status, --pause, --resume and
+	--abort work through any unit and a drain continues if a unit
+	restarts.
+
+	Example:
+		jimmctl drain-controller <name> --dry-run
+		jimmctl drain-controller <name> --concurrency 4
+		jimmctl drain-controller <name> --status
+		jimmctl drain-controller <name> --pause
+`
+
+// NewDrainControllerCommand returns a command used to migrate all models
+// off a controller.
+func NewDrainControllerCommand() cmd.Command {
+	cmd := &drainControllerCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// drainControllerCommand migrates all models off a controller.
+type drainControllerCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	controllerName string
+	concurrency    int
+	dryRun         bool
+	status         bool
+	pause          bool
+	resume         bool
+	abort          bool
+}
+
+func (c *drainControllerCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "drain-controller",
+		Args:    "<name>",
+		Purpose: "Migrate all models off a controller.",
+		Doc:     drainControllerDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *drainControllerCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.IntVar(&c.concurrency, "concurrency", 1, "maximum number of models to migrate at the same time")
+	f.BoolVar(&c.dryRun, "dry-run", false, "show where each model would be migrated to without migrating")
+	f.BoolVar(&c.status, "status", false, "show the progress of the drain")
+	f.BoolVar(&c.pause, "pause", false, "stop starting new migrations")
+	f.BoolVar(&c.resume, "resume", false, "resume a paused drain")
+	f.BoolVar(&c.abort, "abort", false, "abort the drain")
+}
+
+// Init implements the cmd.Command interface.
+func (c *drainControllerCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing controller name")
+	}
+	c.controllerName, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	n := 0
+	for _, set := range []bool{c.dryRun, c.status, c.pause, c.resume, c.abort} {
+		if set {
+			n++
+		}
+	}
+	if n > 1 {
+		return errors.E("only one of --dry-run, --status, --pause, --resume and --abort can be specified")
+	}
+	if c.concurrency < 1 {
+		return errors.E("concurrency must be at least 1")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *drainControllerCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+
+	var drain apiparams.ControllerDrain
+	switch {
+	case c.status:
+		drain, err = client.ControllerDrainStatus(&apiparams.ControllerDrainStatusRequest{
+			Controller: c.controllerName,
+		})
+	case c.pause:
+		drain, err = c.setState(client, "paused")
+	case c.resume:
+		drain, err = c.setState(client, "running")
+	case c.abort:
+		drain, err = c.setState(client, "aborted")
+	default:
+		drain, err = client.DrainController(&apiparams.DrainControllerRequest{
+			Controller:  c.controllerName,
+			Concurrency: c.concurrency,
+			DryRun:      c.dryRun,
+		})
+	}
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, drain)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func (c *drainControllerCommand) setState(client *api.Client, state string) (apiparams.ControllerDrain, error) {
+	return client.SetControllerDrainState(&apiparams.SetControllerDrainStateRequest{
+		Controller: c.controllerName,
+		State:      state,
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"github.com/juju/cmd/v3/cmdtesting"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+type drainControllerSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&drainControllerSuite{})
+
+func (s *drainControllerSuite) TestDrainControllerDryRun(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient), "controller-1", "--dry-run")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, `controller: controller-1
+state: planned
+models:
+- model-tag: `+mt.String()+`
+  model-name: model-1
+  status: skipped
+  error: no other controller hosts cloud region `+jimmtest.TestCloudName+`/`+jimmtest.TestCloudRegionName+`
+`)
+
+	// A dry run does not start a drain.
+	_, err = cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient), "controller-1", "--status")
+	c.Assert(err, gc.ErrorMatches, `controller "controller-1" is not being drained.*`)
+}
+
+func (s *drainControllerSuite) TestDrainControllerUnauthorized(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient), "controller-1", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *drainControllerSuite) TestDrainControllerInvalidArguments(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `missing controller name`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient), "controller-1", "--pause", "--abort")
+	c.Assert(err, gc.ErrorMatches, `only one of --dry-run, --status, --pause, --resume and --abort can be specified`)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewDrainControllerCommandForTesting(s.ClientStore(), bClient), "controller-1", "--concurrency", "0")
+	c.Assert(err, gc.ErrorMatches, `concurrency must be at least 1`)
+}
@@ -0,0 +1,152 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	disableIdentityDoc = `
+	disable-identity disables an identity in JIMM. A disabled identity
+	cannot log in and any connections it has open are closed.
+
+	Example:
+		jimmctl disable-identity <name>
+`
+
+	enableIdentityDoc = `
+	enable-identity re-enables a disabled identity in JIMM.
+
+	Example:
+		jimmctl enable-identity <name>
+`
+)
+
+// NewDisableIdentityCommand returns a command used to disable an identity.
+func NewDisableIdentityCommand() cmd.Command {
+	cmd := &disableIdentityCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// disableIdentityCommand disables an identity.
+type disableIdentityCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+}
+
+// Info implements the cmd.Command interface.
+func (c *disableIdentityCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "disable-identity",
+		Args:    "<name>",
+		Purpose: "Disables an identity.",
+		Doc:     disableIdentityDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *disableIdentityCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing identity name")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *disableIdentityCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.DisableIdentity(&apiparams.DisableIdentityRequest{Name: c.name}); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewEnableIdentityCommand returns a command used to enable an identity.
+func NewEnableIdentityCommand() cmd.Command {
+	cmd := &enableIdentityCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// enableIdentityCommand enables an identity.
+type enableIdentityCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+}
+
+// Info implements the cmd.Command interface.
+func (c *enableIdentityCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "enable-identity",
+		Args:    "<name>",
+		Purpose: "Enables an identity.",
+		Doc:     enableIdentityDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *enableIdentityCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing identity name")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *enableIdentityCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.EnableIdentity(&apiparams.EnableIdentityRequest{Name: c.name}); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
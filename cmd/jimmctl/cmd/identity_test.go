@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type identitySuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&identitySuite{})
+
+func (s *identitySuite) TestDisableAndEnableIdentity(c *gc.C) {
+	ctx := context.Background()
+	identity, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Assert(s.JIMM.Database.GetIdentity(ctx, identity), gc.IsNil)
+
+	// alice is superuser
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewDisableIdentityCommandForTesting(s.ClientStore(), aClient), "bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Assert(s.JIMM.Database.GetIdentity(ctx, identity), gc.IsNil)
+	c.Assert(identity.Disabled, gc.Equals, true)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewEnableIdentityCommandForTesting(s.ClientStore(), aClient), "bob@canonical.com")
+	c.Assert(err, gc.IsNil)
+	c.Assert(s.JIMM.Database.GetIdentity(ctx, identity), gc.IsNil)
+	c.Assert(identity.Disabled, gc.Equals, false)
+}
+
+func (s *identitySuite) TestDisableIdentityUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableIdentityCommandForTesting(s.ClientStore(), bClient), "alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized`)
+}
+
+func (s *identitySuite) TestDisableIdentityMissingName(c *gc.C) {
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewDisableIdentityCommandForTesting(s.ClientStore(), aClient))
+	c.Assert(err, gc.ErrorMatches, `missing identity name`)
+}
@@ -6,6 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 
 	"github.com/gosuri/uitable"
 	"github.com/juju/cmd/v3"
@@ -26,6 +30,17 @@ var listAuditEventsCommandDoc = `
 	Example:
 		jimmctl list-audit-events --after <time> --before <time> --user-tag <user-tag> --limit <limit>
 		jimmctl audit-events --after <time> --format yaml
+		jimmctl list-audit-events --follow --model <model-uuid>
+		jimmctl list-audit-events --method Deploy --params-path '$.applications[*].application == "postgresql"'
+		jimmctl list-audit-events --errors-only --facade Application
+		jimmctl list-audit-events --correlate --errors-only --format tabular
+
+	With --correlate each request is displayed together with the errors
+	and latency of its response.
+
+	With --follow the command waits for new audit events matching the
+	--user-tag, --method and --model filters and displays them as they
+	occur, until interrupted.
 `
 
 // NewListAuditEventsCommand returns a command to list audit events matching
@@ -47,6 +62,7 @@ type listAuditEventsCommand struct {
 	store    jujuclient.ClientStore
 	dialOpts *jujuapi.DialOpts
 	args     apiparams.FindAuditEventsRequest
+	follow   bool
 }
 
 func (c *listAuditEventsCommand) Info() *cmd.Info {
@@ -64,17 +80,24 @@ func (c *listAuditEventsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
-		"tabular": formatTabular,
+		"tabular": c.formatTabular,
 	})
 	f.StringVar(&c.args.After, "after", "", "display events that happened after specified time")
 	f.StringVar(&c.args.Before, "before", "", "display events that happened before specified time")
 	f.StringVar(&c.args.UserTag, "user-tag", "", "display events performed by authenticated user")
 	f.StringVar(&c.args.Method, "method", "", "display events for a specific method call")
 	f.StringVar(&c.args.Model, "model", "", "display events for a specific model (model name is controller/model)")
+	f.StringVar(&c.args.FacadeName, "facade", "", "display events for a specific facade")
+	f.StringVar(&c.args.ConversationId, "conversation-id", "", "display events for a specific conversation")
+	f.StringVar(&c.args.Type, "type", "", `display only events of the given type, either "request" or "response"`)
+	f.BoolVar(&c.args.ErrorsOnly, "errors-only", false, "display only responses containing errors")
+	f.StringVar(&c.args.ParamsPath, "params-path", "", "display events whose parameters match the given SQL/JSON path predicate")
+	f.StringVar(&c.args.ParamsSearch, "params-search", "", "display events whose parameters contain all the given words")
 	f.IntVar(&c.args.Offset, "offset", 0, "offset the set of returned audit events")
 	f.IntVar(&c.args.Limit, "limit", 0, "limit the maximum number of returned audit events")
 	f.BoolVar(&c.args.SortTime, "reverse", false, "reverse the order of logs, showing the most recent first")
-
+	f.BoolVar(&c.args.Correlate, "correlate", false, "display each request together with its response")
+	f.BoolVar(&c.follow, "follow", false, "wait for new audit events and display them as they occur")
 }
 
 // Init implements the cmd.Command interface.
@@ -82,6 +105,12 @@ func (c *listAuditEventsCommand) Init(args []string) error {
 	if len(args) > 0 {
 		return errors.E("unknown arguments")
 	}
+	if c.follow && (c.args.After != "" || c.args.Before != "" || c.args.Offset != 0 || c.args.SortTime) {
+		return errors.E("--follow cannot be used with --after, --before, --offset or --reverse")
+	}
+	if c.args.Correlate && (c.follow || c.args.Type != "") {
+		return errors.E("--correlate cannot be used with --follow or --type")
+	}
 	return nil
 }
 
@@ -98,6 +127,9 @@ func (c *listAuditEventsCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	client := api.NewClient(apiCaller)
+	if c.follow {
+		return c.followEvents(ctxt, client)
+	}
 	events, err := client.FindAuditEvents(&c.args)
 	if err != nil {
 		return errors.E(err)
@@ -110,6 +142,64 @@ func (c *listAuditEventsCommand) Run(ctxt *cmd.Context) error {
 	return nil
 }
 
+// followEvents displays new audit events as they are reported by an
+// audit event watcher. It returns when the command is interrupted or the
+// command context is cancelled.
+func (c *listAuditEventsCommand) followEvents(ctxt *cmd.Context, client *api.Client) error {
+	resp, err := client.WatchAuditEvents(&c.args)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	ctxt.InterruptNotify(interrupted)
+	defer ctxt.StopInterruptNotify(interrupted)
+
+	var stopOnce sync.Once
+	var stopping atomic.Bool
+	stop := func() {
+		stopOnce.Do(func() {
+			stopping.Store(true)
+			// Stopping the watcher causes any pending call to Next
+			// to return.
+			_ = client.AuditEventWatcherStop(resp.WatcherID)
+		})
+	}
+	defer stop()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupted:
+		case <-ctxt.Done():
+		case <-done:
+			return
+		}
+		stop()
+	}()
+
+	for {
+		events, err := client.AuditEventWatcherNext(resp.WatcherID)
+		if err != nil {
+			if stopping.Load() {
+				return nil
+			}
+			return errors.E(err)
+		}
+		if err := c.out.Write(ctxt, events); err != nil {
+			return errors.E(err)
+		}
+	}
+}
+
+func (c *listAuditEventsCommand) formatTabular(writer io.Writer, value interface{}) error {
+	if c.args.Correlate {
+		return formatCorrelatedTabular(writer, value)
+	}
+	return formatTabular(writer, value)
+}
+
 func formatTabular(writer io.Writer, value interface{}) error {
 	e, ok := value.(apiparams.AuditEvents)
 	if !ok {
@@ -135,3 +225,45 @@ func formatTabular(writer io.Writer, value interface{}) error {
 	fmt.Fprint(writer, table)
 	return nil
 }
+
+func formatCorrelatedTabular(writer io.Writer, value interface{}) error {
+	e, ok := value.(apiparams.AuditEvents)
+	if !ok {
+		return errors.E(fmt.Sprintf("expected value of type %T, got %T", e, value))
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 50
+	table.Wrap = true
+
+	table.AddRow("Time", "User", "Model", "Method", "Latency", "Params", "Errors")
+	for _, event := range e.Events {
+		paramsJSON, err := json.Marshal(event.Params)
+		if err != nil {
+			return errors.E(err)
+		}
+		latency := event.Latency
+		if event.ResponseTime == nil {
+			latency = "-"
+		}
+		method := event.FacadeName + "." + event.FacadeMethod
+		table.AddRow(event.Time, event.UserTag, event.Model, method, latency, string(paramsJSON), strings.Join(errorMessages(event.Errors), "; "))
+	}
+	fmt.Fprint(writer, table)
+	return nil
+}
+
+// errorMessages returns the non-empty error messages from the errors of
+// an audit event.
+func errorMessages(eventErrors map[string]any) []string {
+	var msgs []string
+	results, _ := eventErrors["results"].([]any)
+	for _, r := range results {
+		result, _ := r.(map[string]any)
+		resultErr, _ := result["error"].(map[string]any)
+		if msg, _ := resultErr["message"].(string); msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs
+}
@@ -3,12 +3,19 @@
 package cmd_test
 
 import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/juju/cmd/v3/cmdtesting"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	gc "gopkg.in/check.v1"
 
 	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
@@ -70,3 +77,131 @@ func (s *listAuditEventsSuite) TestListAuditEventsStatus(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient))
 	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
 }
+
+func (s *listAuditEventsSuite) TestListAuditEventsFollow(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	out := &notifyingBuffer{written: make(chan struct{}, 1)}
+	cmdContext := cmdtesting.Context(c).With(ctx)
+	cmdContext.Stdout = out
+	errc := cmdtesting.RunCommandWithContext(cmdContext, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--follow", "--method", "TestFollow", "--format", "json")
+
+	// Add entries until the watcher has started and reported one.
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(10 * time.Second)
+	for done := false; !done; {
+		select {
+		case <-ticker.C:
+			s.JIMM.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+				Time:         time.Now(),
+				IdentityTag:  names.NewUserTag("bob@canonical.com").String(),
+				FacadeName:   "Test",
+				FacadeMethod: "TestFollow",
+			})
+		case <-out.written:
+			done = true
+		case <-timeout:
+			c.Fatalf("timed out waiting for audit events")
+		}
+	}
+	cancel()
+
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.IsNil)
+	case <-time.After(10 * time.Second):
+		c.Fatalf("timed out waiting for command to exit")
+	}
+	c.Check(out.String(), gc.Matches, `(?s)\{"events":\[\{.*"facade-method":"TestFollow".*`)
+	c.Check(strings.Contains(out.String(), "LoginWithSessionToken"), gc.Equals, false)
+}
+
+func (s *listAuditEventsSuite) TestListAuditEventsFollowUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--follow")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *listAuditEventsSuite) TestListAuditEventsFollowInvalidFlags(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--follow", "--reverse")
+	c.Assert(err, gc.ErrorMatches, `--follow cannot be used with --after, --before, --offset or --reverse`)
+}
+
+// notifyingBuffer is a bytes.Buffer that is safe for concurrent use and
+// signals whenever it is written to.
+type notifyingBuffer struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	written chan struct{}
+}
+
+func (b *notifyingBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer func() {
+		select {
+		case b.written <- struct{}{}:
+		default:
+		}
+	}()
+	return b.buf.Write(p)
+}
+
+func (b *notifyingBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (s *listAuditEventsSuite) TestListAuditEventsFilters(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--facade", "Admin", "--type", "request", "--format", "json")
+	c.Assert(err, gc.IsNil)
+	out := cmdtesting.Stdout(context)
+	c.Check(out, gc.Matches, `(?s).*"facade-method":"LoginWithSessionToken".*`)
+	c.Check(strings.Contains(out, `"is-response":true`), gc.Equals, false)
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--type", "reply")
+	c.Assert(err, gc.ErrorMatches, `invalid "type" filter "reply" \(bad request\)`)
+}
+
+func (s *listAuditEventsSuite) TestListAuditEventsCorrelate(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--correlate", "--facade", "Admin")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches,
+		`events:
+- time: .*
+  conversation-id: .*
+  message-id: 1
+  facade-name: Admin
+  facade-method: LoginWithSessionToken
+  facade-version: \d
+  user-tag: user-alice@canonical.com
+  is-response: false
+  params:
+    params: redacted
+  errors:
+    results:
+    - error:
+        code: ""
+        message: ""
+  response-time: .*
+  latency: .*
+[\s\S]*`)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--correlate", "--facade", "Admin", "--format", "tabular")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, `Time\s+User\s+Model\s+Method\s+Latency\s+Params\s+Errors\s*\n.*user-alice@canonical.com\s+Admin.LoginWithSessionToken\s+\S+\s+.*`)
+}
+
+func (s *listAuditEventsSuite) TestListAuditEventsCorrelateInvalidFlags(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewListAuditEventsCommandForTesting(s.ClientStore(), bClient), "--correlate", "--type", "request")
+	c.Assert(err, gc.ErrorMatches, `--correlate cannot be used with --follow or --type`)
+}
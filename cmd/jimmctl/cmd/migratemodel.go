@@ -25,9 +25,15 @@ var migrateModelCommandDoc = `
 	Note that multiple models can be targeted for migration by supplying
 	multiple model uuids.
 
+	The --status flag reports the progress of migrations instead. With no
+	arguments all unfinished migrations are listed, otherwise the most
+	recent migration of each specified model is shown.
+
 	Example:
 		jimmctl migrate <controller-name> <model-uuid> 
 		jimmctl migrate <controller-name> <model-uuid> <model-uuid> <model-uuid>
+		jimmctl migrate --status
+		jimmctl migrate --status <model-uuid> <model-uuid>
 `
 
 // NewMigrateModelCommand returns a command to migrate models.
@@ -48,6 +54,7 @@ type migrateModelCommand struct {
 	dialOpts         *jujuapi.DialOpts
 	targetController string
 	modelTags        []string
+	status           bool
 }
 
 func (c *migrateModelCommand) Info() *cmd.Info {
@@ -65,18 +72,23 @@ func (c *migrateModelCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 		"json": cmd.FormatJson,
 	})
+	f.BoolVar(&c.status, "status", false, "show the status of model migrations")
 }
 
 // Init implements the cmd.Command interface.
 func (c *migrateModelCommand) Init(args []string) error {
+	if c.status {
+		return c.parseModelTags(args)
+	}
 	if len(args) < 2 {
 		return errors.E("Missing controller name and model uuid arguments")
 	}
-	for i, arg := range args {
-		if i == 0 {
-			c.targetController = arg
-			continue
-		}
+	c.targetController = args[0]
+	return c.parseModelTags(args[1:])
+}
+
+func (c *migrateModelCommand) parseModelTags(args []string) error {
+	for _, arg := range args {
 		mt := names.NewModelTag(arg)
 		_, err := names.ParseModelTag(mt.String())
 		if err != nil {
@@ -100,6 +112,9 @@ func (c *migrateModelCommand) Run(ctxt *cmd.Context) error {
 	}
 
 	client := api.NewClient(apiCaller)
+	if c.status {
+		return c.showStatus(ctxt, client)
+	}
 	specs := []apiparams.MigrateModelInfo{}
 	for _, model := range c.modelTags {
 		specs = append(specs, apiparams.MigrateModelInfo{ModelTag: model, TargetController: c.targetController})
@@ -116,3 +131,24 @@ func (c *migrateModelCommand) Run(ctxt *cmd.Context) error {
 	}
 	return nil
 }
+
+// showStatus writes the status of the migrations of the requested models,
+// or of all unfinished migrations if no models were requested.
+func (c *migrateModelCommand) showStatus(ctxt *cmd.Context, client *api.Client) error {
+	var migrations []apiparams.MigrationInfo
+	if len(c.modelTags) == 0 {
+		resp, err := client.ListMigrations(&apiparams.ListMigrationsRequest{Active: true})
+		if err != nil {
+			return err
+		}
+		migrations = resp.Migrations
+	}
+	for _, model := range c.modelTags {
+		info, err := client.MigrationStatus(&apiparams.MigrationStatusRequest{ModelTag: model})
+		if err != nil {
+			return err
+		}
+		migrations = append(migrations, info)
+	}
+	return c.out.Write(ctxt, migrations)
+}
@@ -3,12 +3,16 @@
 package cmd_test
 
 import (
+	"context"
+	"time"
+
 	"github.com/juju/cmd/v3/cmdtesting"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	gc "gopkg.in/check.v1"
 
 	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
@@ -71,3 +75,64 @@ func (s *migrateModelSuite) TestMigrateModelCommandFailsWithMissingArgs(c *gc.C)
 	_, err := cmdtesting.RunCommand(c, cmd.NewMigrateModelCommandForTesting(s.ClientStore(), bClient), "myController")
 	c.Assert(err, gc.ErrorMatches, "Missing controller name and model uuid arguments")
 }
+
+func (s *migrateModelSuite) addMigration(c *gc.C, mt names.ModelTag) {
+	ctx := context.Background()
+	model := dbmodel.Model{}
+	model.SetTag(mt)
+	err := s.JIMM.Database.GetModel(ctx, &model)
+	c.Assert(err, gc.IsNil)
+	err = s.JIMM.Database.AddModelMigration(ctx, &dbmodel.ModelMigration{
+		ModelID:            model.ID,
+		MigrationID:        mt.Id() + ":0",
+		SourceControllerID: model.ControllerID,
+		TargetControllerID: model.ControllerID,
+		InitiatedBy:        "alice@canonical.com",
+		Phase:              dbmodel.MigrationPhaseRunning,
+		Status:             "importing",
+		StartedAt:          time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	})
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *migrateModelSuite) TestMigrateModelStatus(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+	cct := names.NewCloudCredentialTag(jimmtest.TestCloudName + "/charlie@canonical.com/cred")
+	s.UpdateCloudCredential(c, cct, jujuparams.CloudCredential{AuthType: "empty"})
+	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, cct)
+	s.addMigration(c, mt)
+
+	expected := `- id: [0-9]+
+  migration-id: ` + mt.Id() + `:0
+  model-tag: ` + mt.String() + `
+  model-name: model-1
+  source-controller: controller-1
+  target-controller: controller-1
+  initiated-by: alice@canonical.com
+  phase: running
+  status: importing
+  started-at: 2024-01-02T03:04:05Z
+`
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewMigrateModelCommandForTesting(s.ClientStore(), bClient), "--status")
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, expected)
+
+	context, err = cmdtesting.RunCommand(c, cmd.NewMigrateModelCommandForTesting(s.ClientStore(), bClient), "--status", mt.Id())
+	c.Assert(err, gc.IsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Matches, expected)
+}
+
+func (s *migrateModelSuite) TestMigrateModelStatusUnauthorized(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewMigrateModelCommandForTesting(s.ClientStore(), bClient), "--status")
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *migrateModelSuite) TestMigrateModelStatusFailsWithInvalidModelTag(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewMigrateModelCommandForTesting(s.ClientStore(), bClient), "--status", "001")
+	c.Assert(err, gc.ErrorMatches, ".* is not a valid model uuid")
+}
@@ -0,0 +1,298 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	setPlacementPolicyDoc = `
+	set-placement-policy sets the policy JIMM uses to select the
+	controller that hosts a new model. The available policies are
+	"priority", "least-models", "least-units" and "least-machines".
+
+	Example:
+		jimmctl set-placement-policy least-models
+`
+
+	placeModelDoc = `
+	place-model shows which controller, and cloud region, JIMM would
+	place a new model on using the current placement policy, without
+	creating the model.
+
+	Example:
+		jimmctl place-model <name> --owner <user>
+		jimmctl place-model <name> --owner <user> --cloud <cloud> --region <region>
+`
+
+	setControllerMaxModelsDoc = `
+	set-controller-max-models sets the maximum number of models JIMM
+	will place on a controller. A limit of 0 removes the limit.
+
+	Example:
+		jimmctl set-controller-max-models <name> <max-models>
+`
+)
+
+// NewSetPlacementPolicyCommand returns a command used to set the model
+// placement policy.
+func NewSetPlacementPolicyCommand() cmd.Command {
+	cmd := &setPlacementPolicyCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setPlacementPolicyCommand sets the model placement policy.
+type setPlacementPolicyCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	policy string
+}
+
+// Info implements the cmd.Command interface.
+func (c *setPlacementPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-placement-policy",
+		Args:    "<policy>",
+		Purpose: "Sets the model placement policy.",
+		Doc:     setPlacementPolicyDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *setPlacementPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing placement policy")
+	}
+	c.policy, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setPlacementPolicyCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.SetPlacementPolicy(&apiparams.SetPlacementPolicyRequest{Policy: c.policy}); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewPlaceModelCommand returns a command used to show where a new model
+// would be placed.
+func NewPlaceModelCommand() cmd.Command {
+	cmd := &placeModelCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// placeModelCommand shows where a new model would be placed.
+type placeModelCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	modelName string
+	owner     string
+	cloud     string
+	region    string
+}
+
+// Info implements the cmd.Command interface.
+func (c *placeModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "place-model",
+		Args:    "<name>",
+		Purpose: "Shows where a new model would be placed.",
+		Doc:     placeModelDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *placeModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.owner, "owner", "", "the user that would own the model")
+	f.StringVar(&c.cloud, "cloud", "", "the cloud the model would be created in")
+	f.StringVar(&c.region, "region", "", "the cloud region the model would be created in")
+}
+
+// Init implements the cmd.Command interface.
+func (c *placeModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.E("missing model name")
+	}
+	c.modelName, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("unknown arguments")
+	}
+	if c.owner == "" {
+		return errors.E("missing model owner")
+	}
+	if !names.IsValidUser(c.owner) {
+		return errors.E(fmt.Sprintf("invalid model owner %q", c.owner))
+	}
+	if c.cloud != "" && !names.IsValidCloud(c.cloud) {
+		return errors.E(fmt.Sprintf("invalid cloud %q", c.cloud))
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *placeModelCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	req := jujuparams.ModelCreateArgs{
+		Name:        c.modelName,
+		OwnerTag:    names.NewUserTag(c.owner).String(),
+		CloudRegion: c.region,
+	}
+	if c.cloud != "" {
+		req.CloudTag = names.NewCloudTag(c.cloud).String()
+	}
+	client := api.NewClient(apiCaller)
+	placement, err := client.PlaceModel(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, placement)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// NewSetControllerMaxModelsCommand returns a command used to set the
+// maximum number of models placed on a controller.
+func NewSetControllerMaxModelsCommand() cmd.Command {
+	cmd := &setControllerMaxModelsCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// setControllerMaxModelsCommand sets the maximum number of models placed
+// on a controller.
+type setControllerMaxModelsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	controllerName string
+	maxModels      uint
+}
+
+// Info implements the cmd.Command interface.
+func (c *setControllerMaxModelsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-controller-max-models",
+		Args:    "<name> <max-models>",
+		Purpose: "Sets the maximum number of models placed on a controller.",
+		Doc:     setControllerMaxModelsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setControllerMaxModelsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *setControllerMaxModelsCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("missing controller name or model limit")
+	}
+	if len(args) > 2 {
+		return errors.E("unknown arguments")
+	}
+	c.controllerName = args[0]
+	n, err := strconv.ParseUint(args[1], 10, 0)
+	if err != nil {
+		return errors.E(err, "invalid model limit")
+	}
+	c.maxModels = uint(n)
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *setControllerMaxModelsCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	info, err := client.SetControllerMaxModels(&apiparams.SetControllerMaxModelsRequest{
+		Name:      c.controllerName,
+		MaxModels: c.maxModels,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, info)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
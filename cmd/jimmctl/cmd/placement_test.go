@@ -0,0 +1,84 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+type placementSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&placementSuite{})
+
+func (s *placementSuite) TestSetPlacementPolicy(c *gc.C) {
+	// alice is superuser
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetPlacementPolicyCommandForTesting(s.ClientStore(), aClient), "least-models")
+	c.Assert(err, gc.IsNil)
+
+	config := dbmodel.ControllerConfig{Name: "placement"}
+	err = s.JIMM.Database.GetControllerConfig(context.Background(), &config)
+	c.Assert(err, gc.IsNil)
+	c.Check(config.Config["policy"], gc.Equals, "least-models")
+
+	_, err = cmdtesting.RunCommand(c, cmd.NewSetPlacementPolicyCommandForTesting(s.ClientStore(), aClient), "no-such-policy")
+	c.Assert(err, gc.ErrorMatches, `unknown placement policy "no-such-policy".*`)
+}
+
+func (s *placementSuite) TestSetPlacementPolicyUnauthorized(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetPlacementPolicyCommandForTesting(s.ClientStore(), bClient), "least-models")
+	c.Assert(err, gc.ErrorMatches, `unauthorized`)
+}
+
+func (s *placementSuite) TestSetControllerMaxModels(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetControllerMaxModelsCommandForTesting(s.ClientStore(), aClient), "controller-1", "10")
+	c.Assert(err, gc.IsNil)
+
+	ctl := dbmodel.Controller{Name: "controller-1"}
+	err = s.JIMM.Database.GetController(context.Background(), &ctl)
+	c.Assert(err, gc.IsNil)
+	c.Check(ctl.MaxModels, gc.Equals, uint(10))
+}
+
+func (s *placementSuite) TestSetControllerMaxModelsInvalidLimit(c *gc.C) {
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewSetControllerMaxModelsCommandForTesting(s.ClientStore(), aClient), "controller-1", "-1")
+	c.Assert(err, gc.ErrorMatches, `invalid model limit`)
+}
+
+func (s *placementSuite) TestPlaceModel(c *gc.C) {
+	s.AddController(c, "controller-1", s.APIInfo(c))
+
+	// alice is superuser
+	aClient := s.SetupCLIAccess(c, "alice")
+	context, err := cmdtesting.RunCommand(c, cmd.NewPlaceModelCommandForTesting(s.ClientStore(), aClient), "model-1", "--owner", "charlie@canonical.com", "--cloud", jimmtest.TestCloudName)
+	c.Assert(err, gc.IsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, `policy: priority
+controller: controller-1
+cloud-region: `+jimmtest.TestCloudRegionName+`
+`)
+}
+
+func (s *placementSuite) TestPlaceModelInvalidArguments(c *gc.C) {
+	aClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewPlaceModelCommandForTesting(s.ClientStore(), aClient), "model-1")
+	c.Assert(err, gc.ErrorMatches, `missing model owner`)
+	_, err = cmdtesting.RunCommand(c, cmd.NewPlaceModelCommandForTesting(s.ClientStore(), aClient), "--owner", "charlie@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `missing model name`)
+}
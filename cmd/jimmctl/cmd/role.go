@@ -0,0 +1,541 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd/v3"
+	jujucmdv3 "github.com/juju/cmd/v3"
+	"github.com/juju/gnuflag"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+var (
+	roleDoc = `
+role command enables role management for jimm
+`
+
+	addRoleDoc = `
+add command adds role to jimm.
+
+Example:
+	jimmctl auth role add <name>
+`
+	renameRoleDoc = `
+rename command renames a role in jimm.
+
+Example:
+	jimmctl auth role rename <name> <new name>
+`
+	removeRoleDoc = `
+remove command removes a role in jimm.
+
+Usage:
+-y	Remove role without prompting for confirmation
+
+Example:
+	jimmctl auth role remove <name>
+`
+
+	listRolesDoc = `
+list command lists all roles in jimm.
+
+Example:
+	jimmctl auth role list
+`
+
+	assignRoleDoc = `
+assign command assigns a role to an identity or group, granting them
+all of the role's entitlements.
+
+Example:
+	jimmctl auth role assign <name> user-alice@canonical.com
+	jimmctl auth role assign <name> group-db-team#member
+`
+
+	unassignRoleDoc = `
+unassign command removes a role from an identity or group.
+
+Example:
+	jimmctl auth role unassign <name> user-alice@canonical.com
+	jimmctl auth role unassign <name> group-db-team#member
+`
+)
+
+// NewRoleCommand returns a command for role management.
+func NewRoleCommand() *jujucmdv3.SuperCommand {
+	cmd := jujucmd.NewSuperCommand(jujucmdv3.SuperCommandParams{
+		Name:    "role",
+		Doc:     roleDoc,
+		Purpose: "Role management.",
+	})
+	cmd.Register(newAddRoleCommand())
+	cmd.Register(newRenameRoleCommand())
+	cmd.Register(newRemoveRoleCommand())
+	cmd.Register(newListRolesCommand())
+	cmd.Register(newAssignRoleCommand())
+	cmd.Register(newUnassignRoleCommand())
+
+	return cmd
+}
+
+// newAddRoleCommand returns a command to add a role.
+func newAddRoleCommand() cmd.Command {
+	cmd := &addRoleCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// addRoleCommand adds a role.
+type addRoleCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name string
+}
+
+// Info implements the cmd.Command interface.
+func (c *addRoleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add",
+		Purpose: "Add role to jimm.",
+		Doc:     addRoleDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addRoleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *addRoleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("role name not specified")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *addRoleCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	resp, err := client.AddRole(&apiparams.AddRoleRequest{
+		Name: c.name,
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, resp)
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newRenameRoleCommand returns a command to rename a role.
+func newRenameRoleCommand() cmd.Command {
+	cmd := &renameRoleCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// renameRoleCommand renames a role.
+type renameRoleCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name    string
+	newName string
+}
+
+// Info implements the cmd.Command interface.
+func (c *renameRoleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rename",
+		Purpose: "Rename a role.",
+		Doc:     renameRoleDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *renameRoleCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.E("role name not specified")
+	}
+	c.name, c.newName, args = args[0], args[1], args[2:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *renameRoleCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	params := apiparams.RenameRoleRequest{
+		Name:    c.name,
+		NewName: c.newName,
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.RenameRole(&params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	return nil
+}
+
+// newRemoveRoleCommand returns a command to Remove a role.
+func newRemoveRoleCommand() cmd.Command {
+	cmd := &removeRoleCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// removeRoleCommand Removes a role.
+type removeRoleCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name  string
+	force bool
+}
+
+// Info implements the cmd.Command interface.
+func (c *removeRoleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove",
+		Purpose: "Remove a role.",
+		Doc:     removeRoleDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *removeRoleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.E("role name not specified")
+	}
+	c.name, args = args[0], args[1:]
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *removeRoleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "smart", map[string]cmd.Formatter{
+		"smart": cmd.FormatSmart,
+	})
+	f.BoolVar(&c.force, "y", false, "delete role without prompt")
+}
+
+// Run implements Command.Run.
+func (c *removeRoleCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	if !c.force {
+		reader := bufio.NewReader(ctxt.Stdin)
+		// Using Fprintf over c.out.write to avoid printing a new line.
+		_, err := fmt.Fprintf(ctxt.Stdout, "This will also delete all associated relations.\nConfirm you would like to delete role %q (y/N): ", c.name)
+		if err != nil {
+			return err
+		}
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return errors.E(err, "Failed to read from input.")
+		}
+		text = strings.ReplaceAll(text, "\n", "")
+		if !(text == "y" || text == "Y") {
+			return nil
+		}
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	params := apiparams.RemoveRoleRequest{
+		Name: c.name,
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.RemoveRole(&params)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	return nil
+}
+
+// newListRolesCommand returns a command to list all roles.
+func newListRolesCommand() cmd.Command {
+	cmd := &listRolesCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// listRolesCommand Lists all roles.
+type listRolesCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	limit  int
+	offset int
+}
+
+// Info implements the cmd.Command interface.
+func (c *listRolesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "list",
+		Purpose: "List all roles.",
+		Doc:     listRolesDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *listRolesCommand) Init(args []string) error {
+	if len(args) > 1 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listRolesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.IntVar(&c.limit, "limit", 0, "The maximum number of roles to return")
+	f.IntVar(&c.offset, "offset", 0, "The offset to use when requesting roles")
+}
+
+// Run implements Command.Run.
+func (c *listRolesCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	req := apiparams.ListRolesRequest{Limit: c.limit, Offset: c.offset}
+	roles, err := client.ListRoles(&req)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	err = c.out.Write(ctxt, roles)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	return nil
+}
+
+// newAssignRoleCommand returns a command to assign a role.
+func newAssignRoleCommand() cmd.Command {
+	cmd := &assignRoleCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// assignRoleCommand assigns a role to an identity or group.
+type assignRoleCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name     string
+	assignee string
+}
+
+// Info implements the cmd.Command interface.
+func (c *assignRoleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "assign",
+		Args:    "<name> <assignee>",
+		Purpose: "Assign a role to an identity or group.",
+		Doc:     assignRoleDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *assignRoleCommand) Init(args []string) error {
+	var err error
+	c.name, c.assignee, err = parseRoleAssignmentArgs(args)
+	return err
+}
+
+// Run implements Command.Run.
+func (c *assignRoleCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.AddRelation(&apiparams.AddRelationRequest{
+		Tuples: []apiparams.RelationshipTuple{roleAssignmentTuple(c.name, c.assignee)},
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// newUnassignRoleCommand returns a command to unassign a role.
+func newUnassignRoleCommand() cmd.Command {
+	cmd := &unassignRoleCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// unassignRoleCommand removes a role from an identity or group.
+type unassignRoleCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+
+	name     string
+	assignee string
+}
+
+// Info implements the cmd.Command interface.
+func (c *unassignRoleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unassign",
+		Args:    "<name> <assignee>",
+		Purpose: "Remove a role from an identity or group.",
+		Doc:     unassignRoleDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *unassignRoleCommand) Init(args []string) error {
+	var err error
+	c.name, c.assignee, err = parseRoleAssignmentArgs(args)
+	return err
+}
+
+// Run implements Command.Run.
+func (c *unassignRoleCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	err = client.RemoveRelation(&apiparams.RemoveRelationRequest{
+		Tuples: []apiparams.RelationshipTuple{roleAssignmentTuple(c.name, c.assignee)},
+	})
+	if err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+func parseRoleAssignmentArgs(args []string) (name, assignee string, err error) {
+	if len(args) < 2 {
+		return "", "", errors.E("role name or assignee not specified")
+	}
+	if len(args) > 2 {
+		return "", "", errors.E("too many args")
+	}
+	return args[0], args[1], nil
+}
+
+// roleAssignmentTuple returns the tuple assigning the named role to the
+// given identity or group tag.
+func roleAssignmentTuple(name, assignee string) apiparams.RelationshipTuple {
+	return apiparams.RelationshipTuple{
+		Object:       assignee,
+		Relation:     "assignee",
+		TargetObject: "role-" + name,
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2024 Canonical.
+
+package cmd
+
+import (
+	"github.com/juju/cmd/v3"
+	jujuapi "github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/pkg/api"
+)
+
+const rotateJWKSDoc = `
+	rotate-jwks immediately replaces all of the keys JIMM signs JWTs with,
+	including the published next key and any retired keys.
+
+	JIMM rotates its keys periodically without interruption, this command
+	is intended for use when a key may have been compromised. Controllers
+	that have cached JIMM's JWKS will reject JWTs signed by the new key
+	until they fetch the JWKS again.
+
+	Example:
+		jimmctl rotate-jwks
+`
+
+// NewRotateJWKSCommand returns a command to rotate JIMM's JWKS.
+func NewRotateJWKSCommand() cmd.Command {
+	cmd := &rotateJWKSCommand{
+		store: jujuclient.NewFileClientStore(),
+	}
+
+	return modelcmd.WrapBase(cmd)
+}
+
+// rotateJWKSCommand rotates JIMM's JWKS.
+type rotateJWKSCommand struct {
+	modelcmd.ControllerCommandBase
+
+	store    jujuclient.ClientStore
+	dialOpts *jujuapi.DialOpts
+}
+
+// Info implements Command.Info.
+func (c *rotateJWKSCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "rotate-jwks",
+		Purpose: "Immediately replace the keys JIMM signs JWTs with",
+		Doc:     rotateJWKSDoc,
+	})
+}
+
+// Init implements the cmd.Command interface.
+func (c *rotateJWKSCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.E("too many args")
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *rotateJWKSCommand) Run(ctxt *cmd.Context) error {
+	currentController, err := c.store.CurrentController()
+	if err != nil {
+		return errors.E(err, "could not determine controller")
+	}
+
+	apiCaller, err := c.NewAPIRootWithDialOpts(c.store, currentController, "", c.dialOpts)
+	if err != nil {
+		return err
+	}
+
+	client := api.NewClient(apiCaller)
+	if err := client.RotateJWKS(); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical.
+
+package cmd_test
+
+import (
+	"context"
+
+	"github.com/juju/cmd/v3/cmdtesting"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/cmd/jimmctl/cmd"
+	"github.com/canonical/jimm/v3/internal/testutils/cmdtest"
+)
+
+type rotateJWKSSuite struct {
+	cmdtest.JimmCmdSuite
+}
+
+var _ = gc.Suite(&rotateJWKSSuite{})
+
+func (s *rotateJWKSSuite) TestRotateJWKSSuperuser(c *gc.C) {
+	ctx := context.Background()
+	before, err := s.JIMM.CredentialStore.GetJWKS(ctx)
+	c.Assert(err, gc.IsNil)
+
+	// alice is superuser
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err = cmdtesting.RunCommand(c, cmd.NewRotateJWKSCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.IsNil)
+
+	after, err := s.JIMM.CredentialStore.GetJWKS(ctx)
+	c.Assert(err, gc.IsNil)
+	c.Assert(after.Len(), gc.Equals, 2)
+	for i := 0; i < after.Len(); i++ {
+		key, _ := after.Key(i)
+		_, found := before.LookupKeyID(key.KeyID())
+		c.Check(found, gc.Equals, false)
+	}
+}
+
+func (s *rotateJWKSSuite) TestRotateJWKS(c *gc.C) {
+	// bob is not superuser
+	bClient := s.SetupCLIAccess(c, "bob")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateJWKSCommandForTesting(s.ClientStore(), bClient))
+	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+}
+
+func (s *rotateJWKSSuite) TestTooManyArgs(c *gc.C) {
+	bClient := s.SetupCLIAccess(c, "alice")
+	_, err := cmdtesting.RunCommand(c, cmd.NewRotateJWKSCommandForTesting(s.ClientStore(), bClient), "extra")
+	c.Assert(err, gc.ErrorMatches, `too many args`)
+}
@@ -21,16 +21,24 @@ func NewSuperCommand() *jujucmd.SuperCommand {
 		Doc:  jimmctlDoc,
 	})
 	jimmcmd.Register(cmd.NewAddControllerCommand())
+	jimmcmd.Register(cmd.NewCharmReportCommand())
 	jimmcmd.Register(cmd.NewControllerInfoCommand())
+	jimmcmd.Register(cmd.NewDisableIdentityCommand())
+	jimmcmd.Register(cmd.NewDrainControllerCommand())
+	jimmcmd.Register(cmd.NewEnableIdentityCommand())
 	jimmcmd.Register(cmd.NewGrantAuditLogAccessCommand())
 	jimmcmd.Register(cmd.NewImportCloudCredentialsCommand())
 	jimmcmd.Register(cmd.NewImportModelCommand())
 	jimmcmd.Register(cmd.NewListAuditEventsCommand())
 	jimmcmd.Register(cmd.NewListControllersCommand())
 	jimmcmd.Register(cmd.NewModelStatusCommand())
+	jimmcmd.Register(cmd.NewPlaceModelCommand())
 	jimmcmd.Register(cmd.NewRemoveControllerCommand())
 	jimmcmd.Register(cmd.NewRevokeAuditLogAccessCommand())
+	jimmcmd.Register(cmd.NewRotateJWKSCommand())
 	jimmcmd.Register(cmd.NewSetControllerDeprecatedCommand())
+	jimmcmd.Register(cmd.NewSetControllerMaxModelsCommand())
+	jimmcmd.Register(cmd.NewSetPlacementPolicyCommand())
 	jimmcmd.Register(cmd.NewUpdateMigratedModelCommand())
 	jimmcmd.Register(cmd.NewAddCloudToControllerCommand())
 	jimmcmd.Register(cmd.NewRemoveCloudFromControllerCommand())
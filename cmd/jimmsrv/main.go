@@ -4,9 +4,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"syscall"
@@ -17,6 +20,7 @@ import (
 	"go.uber.org/zap"
 
 	jimmsvc "github.com/canonical/jimm/v3/cmd/jimmsrv/service"
+	"github.com/canonical/jimm/v3/internal/auth"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/version"
 )
@@ -118,6 +122,31 @@ func start(ctx context.Context, s *service.Service) error {
 		return errors.E("no oauth client scopes present")
 	}
 
+	var groupMapping auth.GroupMapping
+	if pattern := os.Getenv("JIMM_OAUTH_GROUPS_PATTERN"); pattern != "" {
+		groupMapping.Pattern, err = regexp.Compile(pattern)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse oauth groups pattern", zap.Error(err))
+			return err
+		}
+		groupMapping.Template = os.Getenv("JIMM_OAUTH_GROUPS_NAME_TEMPLATE")
+	}
+
+	var requireEmailVerified bool
+	if v := os.Getenv("JIMM_OAUTH_REQUIRE_EMAIL_VERIFIED"); v != "" {
+		requireEmailVerified, err = strconv.ParseBool(v)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse oauth require email verified", zap.Error(err))
+			return err
+		}
+	}
+
+	providers, err := parseProviders(os.Getenv("JIMM_OAUTH_PROVIDERS"))
+	if err != nil {
+		zapctx.Error(ctx, "failed to parse oauth providers", zap.Error(err))
+		return err
+	}
+
 	insecureSecretStorage := false
 	if _, ok := os.LookupEnv("INSECURE_SECRET_STORAGE"); ok {
 		insecureSecretStorage = true
@@ -146,6 +175,31 @@ func start(ctx context.Context, s *service.Service) error {
 
 	logSQL, _ := strconv.ParseBool(os.Getenv("JIMM_LOG_SQL"))
 
+	var healthCheckCacheTTL time.Duration
+	if durationString := os.Getenv("JIMM_HEALTH_CHECK_CACHE_TTL"); durationString != "" {
+		healthCheckCacheTTL, err = time.ParseDuration(durationString)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse health check cache ttl", zap.Error(err))
+			return err
+		}
+	}
+	var leaderElectionInterval time.Duration
+	if durationString := os.Getenv("JIMM_LEADER_ELECTION_INTERVAL"); durationString != "" {
+		leaderElectionInterval, err = time.ParseDuration(durationString)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse leader election interval", zap.Error(err))
+			return err
+		}
+	}
+	var healthCheckTimeout time.Duration
+	if durationString := os.Getenv("JIMM_HEALTH_CHECK_TIMEOUT"); durationString != "" {
+		healthCheckTimeout, err = time.ParseDuration(durationString)
+		if err != nil {
+			zapctx.Error(ctx, "failed to parse health check timeout", zap.Error(err))
+			return err
+		}
+	}
+
 	jimmsvc, err := jimmsvc.NewService(ctx, jimmsvc.Params{
 		ControllerUUID:    os.Getenv("JIMM_UUID"),
 		DSN:               os.Getenv("JIMM_DSN"),
@@ -166,8 +220,10 @@ func start(ctx context.Context, s *service.Service) error {
 		PrivateKey:                    os.Getenv("BAKERY_PRIVATE_KEY"),
 		PublicKey:                     os.Getenv("BAKERY_PUBLIC_KEY"),
 		AuditLogRetentionPeriodInDays: os.Getenv("JIMM_AUDIT_LOG_RETENTION_PERIOD_IN_DAYS"),
+		AuditSinks:                    strings.Fields(os.Getenv("JIMM_AUDIT_SINKS")),
 		MacaroonExpiryDuration:        macaroonExpiryDuration,
 		JWTExpiryDuration:             jwtExpiryDuration,
+		JWKSAlgorithm:                 os.Getenv("JIMM_JWKS_ALGORITHM"),
 		InsecureSecretStorage:         insecureSecretStorage,
 		OAuthAuthenticatorParams: jimmsvc.OAuthAuthenticatorParams{
 			IssuerURL:            issuerURL,
@@ -178,37 +234,34 @@ func start(ctx context.Context, s *service.Service) error {
 			SessionCookieMaxAge:  sessionCookieMaxAgeInt,
 			JWTSessionKey:        sessionSecretKey,
 			SecureSessionCookies: secureSessionCookies,
+			GroupsClaim:          os.Getenv("JIMM_OAUTH_GROUPS_CLAIM"),
+			GroupMapping:         groupMapping,
+			IdentityClaim:        os.Getenv("JIMM_OAUTH_IDENTITY_CLAIM"),
+			IdentitySuffix:       os.Getenv("JIMM_OAUTH_IDENTITY_SUFFIX"),
+			RequireEmailVerified: requireEmailVerified,
+			AllowedDomains:       strings.Fields(os.Getenv("JIMM_OAUTH_ALLOWED_DOMAINS")),
+			Providers:            providers,
 		},
 		DashboardFinalRedirectURL: os.Getenv("JIMM_DASHBOARD_FINAL_REDIRECT_URL"),
 		CookieSessionKey:          []byte(sessionSecretKey),
 		CorsAllowedOrigins:        corsAllowedOrigins,
 		LogSQL:                    logSQL,
+		HealthCheckCacheTTL:       healthCheckCacheTTL,
+		HealthCheckTimeout:        healthCheckTimeout,
+		LeaderElectionInterval:    leaderElectionInterval,
 	})
 	if err != nil {
 		return err
 	}
 
-	isLeader := os.Getenv("JIMM_IS_LEADER") != ""
-	if isLeader {
-		s.Go(func() error { return jimmsvc.WatchControllers(ctx) }) // Deletes dead/dying models, updates model config.
-	}
+	// Only the elected leader runs the controller watcher, migration
+	// tracker, JWKS rotator and audit log cleanup. Model summaries are
+	// published to clients connected to this replica, so every replica
+	// watches them, and every replica closes the connections of disabled
+	// identities.
+	s.Go(func() error { return jimmsvc.RunLeaderElection(ctx) })
 	s.Go(func() error { return jimmsvc.WatchModelSummaries(ctx) })
-
-	if isLeader {
-		zapctx.Info(ctx, "attempting to start JWKS rotator and generate OAuth secret key")
-		s.Go(func() error {
-			if err := jimmsvc.StartJWKSRotator(ctx, time.NewTicker(time.Hour).C, time.Now().UTC().AddDate(0, 3, 0)); err != nil {
-				zapctx.Error(ctx, "failed to start JWKS rotator", zap.Error(err))
-				return err
-			}
-			return nil
-		})
-	}
-
-	if isLeader {
-		// No need for s.Go() since this routine doesn't return an error.
-		go jimmsvc.MonitorResources(ctx)
-	}
+	s.Go(func() error { return jimmsvc.WatchDisabledIdentities(ctx) })
 
 	httpsrv := &http.Server{
 		Addr:              addr,
@@ -230,3 +283,54 @@ func start(ctx context.Context, s *service.Service) error {
 	zapctx.Info(ctx, "Successfully started JIMM server")
 	return nil
 }
+
+// parseProviders parses the JSON list of additional identity providers
+// held in the JIMM_OAUTH_PROVIDERS environment variable.
+func parseProviders(s string) ([]auth.ProviderParams, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var providers []struct {
+		Name                 string `json:"name"`
+		IssuerURL            string `json:"issuer-url"`
+		ClientID             string `json:"client-id"`
+		ClientSecret         string `json:"client-secret"`
+		Scopes               string `json:"scopes"`
+		IdentityClaim        string `json:"identity-claim"`
+		IdentitySuffix       string `json:"identity-suffix"`
+		RequireEmailVerified bool   `json:"require-email-verified"`
+		GroupsClaim          string `json:"groups-claim"`
+		GroupsPattern        string `json:"groups-pattern"`
+		GroupsNameTemplate   string `json:"groups-name-template"`
+		ServiceAccounts      bool   `json:"service-accounts"`
+	}
+	if err := json.Unmarshal([]byte(s), &providers); err != nil {
+		return nil, errors.E(err, "invalid oauth providers")
+	}
+	params := make([]auth.ProviderParams, len(providers))
+	for i, p := range providers {
+		params[i] = auth.ProviderParams{
+			Name:                 p.Name,
+			IssuerURL:            p.IssuerURL,
+			ClientID:             p.ClientID,
+			ClientSecret:         p.ClientSecret,
+			Scopes:               strings.Fields(p.Scopes),
+			IdentityClaim:        p.IdentityClaim,
+			IdentitySuffix:       p.IdentitySuffix,
+			RequireEmailVerified: p.RequireEmailVerified,
+			GroupsClaim:          p.GroupsClaim,
+			ServiceAccounts:      p.ServiceAccounts,
+		}
+		if p.GroupsPattern != "" {
+			pattern, err := regexp.Compile(p.GroupsPattern)
+			if err != nil {
+				return nil, errors.E(err, fmt.Sprintf("invalid groups pattern for oauth provider %q", p.Name))
+			}
+			params[i].GroupMapping = auth.GroupMapping{
+				Pattern:  pattern,
+				Template: p.GroupsNameTemplate,
+			}
+		}
+	}
+	return params, nil
+}
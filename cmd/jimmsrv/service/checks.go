@@ -0,0 +1,168 @@
+// Copyright 2024 Canonical.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	jimmcreds "github.com/canonical/jimm/v3/internal/jimm/credentials"
+	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/vault"
+)
+
+const (
+	// defaultHealthCheckCacheTTL is the default length of time the
+	// result of a readiness check is reused for.
+	defaultHealthCheckCacheTTL = 10 * time.Second
+
+	// defaultHealthCheckTimeout is the default time limit for a single
+	// readiness check.
+	defaultHealthCheckTimeout = 5 * time.Second
+)
+
+// databaseCheck returns a status check that pings the database.
+func databaseCheck(database *db.Database) jimmhttp.StatusCheck {
+	return jimmhttp.MakeStatusCheck("postgres", func(ctx context.Context) (interface{}, error) {
+		if err := database.Ping(ctx); err != nil {
+			return nil, err
+		}
+		return "ok", nil
+	})
+}
+
+// openFGACheck returns a status check that performs a check request
+// against the OpenFGA store.
+func openFGACheck(client *openfga.OFGAClient, controllerUUID string) jimmhttp.StatusCheck {
+	tuple := openfga.Tuple{
+		Object:   ofganames.ConvertTag(names.NewUserTag(ofganames.EveryoneUser)),
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(names.NewControllerTag(controllerUUID)),
+	}
+	return jimmhttp.MakeStatusCheck("openfga", func(ctx context.Context) (interface{}, error) {
+		if _, err := client.CheckRelation(ctx, tuple, false); err != nil {
+			return nil, err
+		}
+		return "ok", nil
+	})
+}
+
+// vaultCheck returns a status check that checks the vault token and
+// reads from the KV store.
+func vaultCheck(store *vault.VaultStore) jimmhttp.StatusCheck {
+	return jimmhttp.MakeStatusCheck("vault", func(ctx context.Context) (interface{}, error) {
+		if err := store.Check(ctx); err != nil {
+			return nil, err
+		}
+		return "ok", nil
+	})
+}
+
+// oidcDiscoveryCheck returns a status check that fetches the discovery
+// document of the given OIDC issuer.
+func oidcDiscoveryCheck(issuerURL string) jimmhttp.StatusCheck {
+	discoveryURL := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	return jimmhttp.MakeStatusCheck("oidc discovery", func(ctx context.Context) (interface{}, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.E(fmt.Sprintf("unexpected status %q fetching %s", resp.Status, discoveryURL))
+		}
+		var doc struct {
+			Issuer string `json:"issuer"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+			return nil, errors.E(err, "cannot decode discovery document")
+		}
+		if doc.Issuer == "" {
+			return nil, errors.E("discovery document has no issuer")
+		}
+		return doc.Issuer, nil
+	})
+}
+
+// jwksExpiryMargin is the length of time after the JWKS expiry before the
+// JWKS readiness check fails. The expiry is the time the JWKS is next
+// due to be rotated by the leader, which checks hourly, so a JWKS that
+// has only just expired is still in use and valid.
+const jwksExpiryMargin = 24 * time.Hour
+
+// jwksCheck returns a readiness check that checks the JWKS and private
+// key used to sign tokens for controllers exist and that the JWKS has not
+// been left unrotated long past its expiry.
+func jwksCheck(store jimmcreds.CredentialStore) jimmhttp.StatusCheck {
+	return jimmhttp.MakeStatusCheck("jwks", func(ctx context.Context) (interface{}, error) {
+		if _, err := store.GetJWKS(ctx); err != nil {
+			return nil, errors.E(err, "cannot get JWKS")
+		}
+		if _, err := store.GetJWKSPrivateKey(ctx); err != nil {
+			return nil, errors.E(err, "cannot get JWKS private key")
+		}
+		expiry, err := store.GetJWKSExpiry(ctx)
+		if err != nil {
+			return nil, errors.E(err, "cannot get JWKS expiry")
+		}
+		if time.Now().After(expiry.Add(jwksExpiryMargin)) {
+			return nil, errors.E(fmt.Sprintf("JWKS expired at %s", expiry.Format(time.RFC3339)))
+		}
+		return expiry, nil
+	})
+}
+
+// jwksExpiryCheck returns a status check that reports when the JWKS is
+// next due to be rotated.
+func jwksExpiryCheck(store jimmcreds.CredentialStore) jimmhttp.StatusCheck {
+	return jimmhttp.MakeStatusCheck("jwks expiry", func(ctx context.Context) (interface{}, error) {
+		return store.GetJWKSExpiry(ctx)
+	})
+}
+
+// readinessChecks returns the checks used to decide whether the service
+// is ready to receive requests. Each check is cached for the configured
+// TTL so that frequent probes do not overload the dependencies.
+func (s *Service) readinessChecks(p Params) map[string]jimmhttp.StatusCheck {
+	ttl := p.HealthCheckCacheTTL
+	if ttl == 0 {
+		ttl = defaultHealthCheckCacheTTL
+	}
+	timeout := p.HealthCheckTimeout
+	if timeout == 0 {
+		timeout = defaultHealthCheckTimeout
+	}
+
+	checks := map[string]jimmhttp.StatusCheck{
+		"postgres": databaseCheck(&s.jimm.Database),
+		"openfga":  openFGACheck(s.jimm.OpenFGAClient, p.ControllerUUID),
+		"jwks":     jwksCheck(s.jimm.CredentialStore),
+	}
+	if vs, ok := s.jimm.CredentialStore.(*vault.VaultStore); ok {
+		checks["vault"] = vaultCheck(vs)
+	}
+	if p.OAuthAuthenticatorParams.IssuerURL != "" {
+		checks["oidc"] = oidcDiscoveryCheck(p.OAuthAuthenticatorParams.IssuerURL)
+	}
+	for _, provider := range p.OAuthAuthenticatorParams.Providers {
+		checks["oidc-"+provider.Name] = oidcDiscoveryCheck(provider.IssuerURL)
+	}
+	for k, check := range checks {
+		checks[k] = jimmhttp.MakeCachedStatusCheck(check, ttl, timeout)
+	}
+	return checks
+}
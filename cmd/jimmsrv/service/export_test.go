@@ -8,3 +8,8 @@ var NewOpenFGAClient = newOpenFGAClient
 func (s *Service) GetCleanups() []func() error {
 	return s.cleanups
 }
+
+var (
+	JWKSCheck          = jwksCheck
+	OIDCDiscoveryCheck = oidcDiscoveryCheck
+)
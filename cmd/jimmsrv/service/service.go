@@ -19,6 +19,7 @@ import (
 	vaultapi "github.com/hashicorp/vault/api"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 	"go.uber.org/zap"
@@ -30,6 +31,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/discharger"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
 	jimmcreds "github.com/canonical/jimm/v3/internal/jimm/credentials"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
 	"github.com/canonical/jimm/v3/internal/jimmhttp/rebac_admin"
@@ -46,6 +48,10 @@ import (
 
 const (
 	localDischargePath = "/macaroons"
+
+	// controllerHealthRetentionPeriod is the length of time controller
+	// health events are kept for.
+	controllerHealthRetentionPeriod = 30 * 24 * time.Hour
 )
 
 // OpenFGAParams holds parameters needed to connect to the OpenFGA server.
@@ -89,6 +95,35 @@ type OAuthAuthenticatorParams struct {
 	// JWTSessionKey holds the secret key used for signing/verifying JWT tokens.
 	// See internal/auth/oauth2.go AuthenticationService.SessionSecretkey for more details.
 	JWTSessionKey string
+
+	// GroupsClaim holds the name of the id token claim listing the groups
+	// an identity belongs to. If set, the identity's membership of
+	// externally managed groups is synchronised on every login.
+	GroupsClaim string
+
+	// GroupMapping maps the group names in the groups claim to JIMM
+	// group names.
+	GroupMapping auth.GroupMapping
+
+	// IdentityClaim holds the name of the id token claim used as the
+	// identity name. If empty the "email" claim is used.
+	IdentityClaim string
+
+	// IdentitySuffix is the suffix of the identity names. An email
+	// identity claim must end with it, other claims have it appended.
+	IdentitySuffix string
+
+	// RequireEmailVerified decides whether the email_verified claim must
+	// be true for a user to log in.
+	RequireEmailVerified bool
+
+	// AllowedDomains holds the domains of the identities allowed to log
+	// in. If empty identities from any domain may log in.
+	AllowedDomains []string
+
+	// Providers holds the additional identity providers users may log
+	// in with.
+	Providers []auth.ProviderParams
 }
 
 // A Params structure contains the parameters required to initialise a new
@@ -153,6 +188,10 @@ type Params struct {
 	// to keep an audit log for before purging it from the database.
 	AuditLogRetentionPeriodInDays string
 
+	// AuditSinks holds the URLs of additional destinations audit log
+	// entries are streamed to. See auditsink.New for the supported URLs.
+	AuditSinks []string
+
 	// MacaroonExpiryDuration holds the expiry duration of authentication macaroons.
 	MacaroonExpiryDuration time.Duration
 
@@ -160,6 +199,10 @@ type Params struct {
 	// for controller to JIMM communication ONLY.
 	JWTExpiryDuration time.Duration
 
+	// JWKSAlgorithm holds the signature algorithm of newly generated JWKS
+	// keys. If empty RS256 is used.
+	JWKSAlgorithm string
+
 	// InsecureSecretStorage instructs JIMM to store secrets in its database
 	// instead of dedicated secure storage. SHOULD NOT BE USED IN PRODUCTION.
 	InsecureSecretStorage bool
@@ -184,11 +227,29 @@ type Params struct {
 	// LogSQL determines whether ORM queries are printed when debug logs are enabled.
 	// This may leak secrets in logs when sensitive values are stored in the DB like OAuth tokens.
 	LogSQL bool
+
+	// HealthCheckCacheTTL is the length of time the result of each
+	// /debug/readyz check is reused for. If this is zero a default of
+	// 10 seconds is used.
+	HealthCheckCacheTTL time.Duration
+
+	// HealthCheckTimeout is the time limit for each /debug/readyz check.
+	// If this is zero a default of 5 seconds is used.
+	HealthCheckTimeout time.Duration
+
+	// LeaderElectionInterval is the interval at which a replica that is
+	// not the leader tries to become the leader, and the leader checks
+	// that it is still the leader. If this is zero a default of 10
+	// seconds is used.
+	LeaderElectionInterval time.Duration
 }
 
 // A Service is the implementation of a JIMM server.
 type Service struct {
-	jimm jimm.JIMM
+	jimm   jimm.JIMM
+	leader jimm.LeaderElector
+
+	auditLogRetentionPeriod int
 
 	mux      *chi.Mux
 	cleanups []func() error
@@ -227,6 +288,33 @@ func (s *Service) WatchModelSummaries(ctx context.Context) error {
 	return w.WatchAllModelSummaries(ctx, 10*time.Minute)
 }
 
+// TrackMigrations polls the controllers involved in any unfinished model
+// migrations and updates the models once their migrations complete.
+// TrackMigrations finishes when the given context is canceled, or there
+// is a fatal error querying the database.
+func (s *Service) TrackMigrations(ctx context.Context) error {
+	w := jimm.Watcher{
+		Database: s.jimm.Database,
+		Dialer:   s.jimm.Dialer,
+	}
+	return w.WatchMigrations(ctx, 30*time.Second)
+}
+
+// WatchDisabledIdentities closes the connections to this replica of any
+// identity disabled through another replica. WatchDisabledIdentities
+// finishes when the given context is canceled.
+func (s *Service) WatchDisabledIdentities(ctx context.Context) error {
+	s.jimm.WatchDisabledIdentities(ctx, 30*time.Second)
+	return nil
+}
+
+// RunControllerDrains migrates the models of the controllers being
+// drained. RunControllerDrains finishes when the given context is canceled.
+func (s *Service) RunControllerDrains(ctx context.Context) error {
+	s.jimm.RunControllerDrains(ctx)
+	return nil
+}
+
 // StartJWKSRotator see internal/jimmjwx/jwks.go for details.
 func (s *Service) StartJWKSRotator(ctx context.Context, checkRotateRequired <-chan time.Time, initialRotateRequiredTime time.Time) error {
 	if s.jimm.JWKService == nil {
@@ -236,6 +324,53 @@ func (s *Service) StartJWKSRotator(ctx context.Context, checkRotateRequired <-ch
 	return s.jimm.JWKService.StartJWKSRotator(ctx, checkRotateRequired, initialRotateRequiredTime)
 }
 
+// RunLeaderElection campaigns for leadership amongst the JIMM replicas
+// sharing the database. While this replica is the leader it runs the
+// background workers that must only run once: the controller watcher,
+// the migration tracker, the controller drains, the JWKS rotator, the
+// audit log cleanup, the controller health history cleanup and resource
+// monitoring.
+// RunLeaderElection finishes when the given context is canceled.
+func (s *Service) RunLeaderElection(ctx context.Context) error {
+	return s.leader.Run(ctx, func(ctx context.Context) error {
+		return jimm.RunLeaderWorkers(ctx,
+			s.WatchControllers,    // Deletes dead/dying models, updates model config.
+			s.TrackMigrations,     // Moves migrated models to their target controller.
+			s.RunControllerDrains, // Migrates the models of drained controllers.
+			func(ctx context.Context) error {
+				ticker := time.NewTicker(time.Hour)
+				context.AfterFunc(ctx, ticker.Stop)
+				if err := s.StartJWKSRotator(ctx, ticker.C, time.Now().UTC().AddDate(0, 3, 0)); err != nil {
+					zapctx.Error(ctx, "failed to start JWKS rotator", zap.Error(err))
+					return err
+				}
+				return nil
+			},
+			func(ctx context.Context) error {
+				if s.auditLogRetentionPeriod != 0 {
+					// Run the cleanup in this worker so that it stops
+					// before leadership is released.
+					jimm.NewAuditLogCleanupService(s.jimm.Database, s.auditLogRetentionPeriod).Run(ctx)
+				}
+				return nil
+			},
+			func(ctx context.Context) error {
+				s.jimm.PruneControllerHealthEvents(ctx, controllerHealthRetentionPeriod)
+				return nil
+			},
+			func(ctx context.Context) error {
+				s.MonitorResources(ctx)
+				return nil
+			},
+		)
+	})
+}
+
+// LeaderStatus returns the leadership status of this replica.
+func (s *Service) LeaderStatus() jimm.LeaderStatus {
+	return s.leader.Status()
+}
+
 // MonitorResources periodically updates metrics.
 func (s *Service) MonitorResources(ctx context.Context) {
 	s.jimm.UpdateMetrics(ctx)
@@ -308,9 +443,20 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		if period < 0 {
 			return nil, errors.E(op, "retention period cannot be less than 0")
 		}
-		if period != 0 {
-			jimm.NewAuditLogCleanupService(s.jimm.Database, period).Start(ctx)
+		s.auditLogRetentionPeriod = period
+	}
+	s.leader = jimm.LeaderElector{
+		Database: s.jimm.Database,
+		Interval: p.LeaderElectionInterval,
+	}
+
+	for _, sinkURL := range p.AuditSinks {
+		sink, err := auditsink.New(ctx, sinkURL)
+		if err != nil {
+			return nil, errors.E(op, err, "failed to setup audit sink")
 		}
+		s.jimm.AuditSinks = append(s.jimm.AuditSinks, sink)
+		s.AddCleanup(sink.Close)
 	}
 
 	openFGAclient, err := newOpenFGAClient(ctx, p.OpenFGAParams)
@@ -343,17 +489,25 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 	authSvc, err := auth.NewAuthenticationService(
 		ctx,
 		auth.AuthenticationServiceParams{
-			IssuerURL:           p.OAuthAuthenticatorParams.IssuerURL,
-			ClientID:            p.OAuthAuthenticatorParams.ClientID,
-			ClientSecret:        p.OAuthAuthenticatorParams.ClientSecret,
-			Scopes:              p.OAuthAuthenticatorParams.Scopes,
-			SessionTokenExpiry:  p.OAuthAuthenticatorParams.SessionTokenExpiry,
-			SessionCookieMaxAge: p.OAuthAuthenticatorParams.SessionCookieMaxAge,
-			JWTSessionKey:       p.OAuthAuthenticatorParams.JWTSessionKey,
-			SecureCookies:       p.OAuthAuthenticatorParams.SecureSessionCookies,
-			Store:               &s.jimm.Database,
-			SessionStore:        sessionStore,
-			RedirectURL:         redirectUrl,
+			IssuerURL:            p.OAuthAuthenticatorParams.IssuerURL,
+			ClientID:             p.OAuthAuthenticatorParams.ClientID,
+			ClientSecret:         p.OAuthAuthenticatorParams.ClientSecret,
+			Scopes:               p.OAuthAuthenticatorParams.Scopes,
+			SessionTokenExpiry:   p.OAuthAuthenticatorParams.SessionTokenExpiry,
+			SessionCookieMaxAge:  p.OAuthAuthenticatorParams.SessionCookieMaxAge,
+			JWTSessionKey:        p.OAuthAuthenticatorParams.JWTSessionKey,
+			SecureCookies:        p.OAuthAuthenticatorParams.SecureSessionCookies,
+			Store:                &s.jimm.Database,
+			SessionStore:         sessionStore,
+			RedirectURL:          redirectUrl,
+			GroupsClaim:          p.OAuthAuthenticatorParams.GroupsClaim,
+			GroupMapping:         p.OAuthAuthenticatorParams.GroupMapping,
+			GroupSyncer:          &s.jimm,
+			IdentityClaim:        p.OAuthAuthenticatorParams.IdentityClaim,
+			IdentitySuffix:       p.OAuthAuthenticatorParams.IdentitySuffix,
+			RequireEmailVerified: p.OAuthAuthenticatorParams.RequireEmailVerified,
+			AllowedDomains:       p.OAuthAuthenticatorParams.AllowedDomains,
+			Providers:            p.OAuthAuthenticatorParams.Providers,
 		},
 	)
 	s.jimm.OAuthAuthenticator = authSvc
@@ -366,12 +520,20 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 		p.JWTExpiryDuration = 24 * time.Hour
 	}
 
-	s.jimm.JWKService = jimmjwx.NewJWKSService(s.jimm.CredentialStore)
+	jwksAlgorithm := jwa.RS256
+	if p.JWKSAlgorithm != "" {
+		jwksAlgorithm = jwa.SignatureAlgorithm(p.JWKSAlgorithm)
+	}
+	if err := jimmjwx.ValidateKeyAlgorithm(jwksAlgorithm); err != nil {
+		return nil, errors.E(op, err)
+	}
+	s.jimm.JWKService = jimmjwx.NewJWKSService(s.jimm.CredentialStore, jwksAlgorithm)
 	s.jimm.JWTService = jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
 		Host:   p.PublicDNSName,
 		Store:  s.jimm.CredentialStore,
 		Expiry: p.JWTExpiryDuration,
 	})
+	s.jimm.JWKService.OnRotate(s.jimm.JWTService.InvalidateCache)
 	s.jimm.Dialer = &jujuclient.Dialer{
 		ControllerCredentialsStore: s.jimm.CredentialStore,
 		JWTService:                 s.jimm.JWTService,
@@ -409,11 +571,23 @@ func NewService(ctx context.Context, p Params) (*Service, error) {
 
 	mountHandler(
 		"/debug",
-		jimmhttp.NewDebugHandler(
-			map[string]jimmhttp.StatusCheck{
+		jimmhttp.NewDebugHandler(jimmhttp.DebugHandlerParams{
+			StatusChecks: map[string]jimmhttp.StatusCheck{
 				"start_time": jimmhttp.ServerStartTime,
+				"leader": jimmhttp.MakeStatusCheck("leader", func(context.Context) (interface{}, error) {
+					return s.leader.Status(), nil
+				}),
+				"jwks_expiry": jwksExpiryCheck(s.jimm.CredentialStore),
+			},
+			ReadinessChecks: s.readinessChecks(p),
+			LivenessChecks: map[string]jimmhttp.StatusCheck{
+				"start_time": jimmhttp.ServerStartTime,
+			},
+			Controllers: &s.jimm,
+			ControllersAuth: func(next http.Handler) http.Handler {
+				return middleware.AuthenticateWithSessionTokenViaBasicAuth(middleware.AuthorizeJIMMAdmin(next), &s.jimm)
 			},
-		),
+		}),
 	)
 	mountHandler(
 		"/.well-known",
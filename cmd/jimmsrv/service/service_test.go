@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	cofga "github.com/canonical/ofga"
 	qt "github.com/frankban/quicktest"
@@ -22,6 +23,7 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/macaroon"
 	"github.com/juju/names/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 
 	jimmsvc "github.com/canonical/jimm/v3/cmd/jimmsrv/service"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
@@ -553,3 +555,61 @@ func TestCORS(t *testing.T) {
 	c.Assert(response.Header.Get("Access-Control-Allow-Credentials"), qt.Equals, "true")
 	c.Assert(response.Header.Get("Access-Control-Allow-Origin"), qt.Equals, allowedOrigin)
 }
+
+func TestJWKSCheck(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	check := jimmsvc.JWKSCheck(store)
+
+	// The check fails until the JWKS has been created.
+	_, err := check.Check(ctx)
+	c.Check(err, qt.ErrorMatches, `cannot get JWKS`)
+
+	err = store.PutJWKS(ctx, jwk.NewSet())
+	c.Assert(err, qt.IsNil)
+	_, err = check.Check(ctx)
+	c.Check(err, qt.ErrorMatches, `cannot get JWKS private key`)
+
+	err = store.PutJWKSPrivateKey(ctx, []byte("private key"))
+	c.Assert(err, qt.IsNil)
+	err = store.PutJWKSExpiry(ctx, time.Now().Add(-25*time.Hour))
+	c.Assert(err, qt.IsNil)
+	_, err = check.Check(ctx)
+	c.Check(err, qt.ErrorMatches, `JWKS expired at .*`)
+
+	// A JWKS that is due to be rotated is still in use.
+	expiry := time.Now().Add(-time.Minute)
+	err = store.PutJWKSExpiry(ctx, expiry)
+	c.Assert(err, qt.IsNil)
+	v, err := check.Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, expiry)
+
+	expiry = time.Now().Add(time.Hour)
+	err = store.PutJWKSExpiry(ctx, expiry)
+	c.Assert(err, qt.IsNil)
+	v, err = check.Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, expiry)
+}
+
+func TestOIDCDiscoveryCheck(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/good/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"issuer": "https://issuer.example.com/good"}`)
+	})
+	srv := httptest.NewServer(mux)
+	c.Cleanup(srv.Close)
+
+	v, err := jimmsvc.OIDCDiscoveryCheck(srv.URL + "/good/").Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, "https://issuer.example.com/good")
+
+	_, err = jimmsvc.OIDCDiscoveryCheck(srv.URL + "/bad").Check(ctx)
+	c.Check(err, qt.ErrorMatches, `unexpected status "404 Not Found" fetching .*`)
+}
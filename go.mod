@@ -24,6 +24,7 @@ require (
 	github.com/itchyny/gojq v0.12.12
 	github.com/jackc/pgconn v1.14.3
 	github.com/jackc/pgx/v4 v4.18.3
+	github.com/jmespath/go-jmespath v0.4.0
 	github.com/juju/charm/v12 v12.0.2
 	github.com/juju/cmd/v3 v3.0.15
 	github.com/juju/errors v1.0.0
@@ -168,7 +169,6 @@ require (
 	github.com/jackc/pgtype v1.14.3 // indirect
 	github.com/jinzhu/inflection v1.0.0 // indirect
 	github.com/jinzhu/now v1.1.5 // indirect
-	github.com/jmespath/go-jmespath v0.4.0 // indirect
 	github.com/josharian/intern v1.0.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/juju/ansiterm v1.0.0 // indirect
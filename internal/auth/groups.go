@@ -0,0 +1,115 @@
+// Copyright 2024 Canonical.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// A GroupSyncer reconciles an identity's membership of the groups managed
+// by the identity provider.
+type GroupSyncer interface {
+	SyncIdentityGroups(ctx context.Context, identityName string, groupNames []string) error
+}
+
+// A GroupMapping maps the group names found in an identity provider's
+// groups claim to the names of JIMM groups.
+type GroupMapping struct {
+	// Pattern matches the identity provider groups that are synchronised
+	// with JIMM, groups that do not match are ignored. If Pattern is nil
+	// every group is synchronised.
+	Pattern *regexp.Regexp
+
+	// Template is the template used to build the JIMM group name from
+	// the submatches of Pattern, as in regexp.Regexp.Expand. If Template
+	// is empty, or Pattern is nil, the identity provider group name is
+	// used unchanged.
+	Template string
+}
+
+// Map returns the JIMM group names for the given identity provider group
+// names. Duplicate names are removed.
+func (m GroupMapping) Map(groups []string) []string {
+	seen := make(map[string]bool, len(groups))
+	names := make([]string, 0, len(groups))
+	for _, g := range groups {
+		name := g
+		if m.Pattern != nil {
+			match := m.Pattern.FindStringSubmatchIndex(g)
+			if match == nil {
+				continue
+			}
+			if m.Template != "" {
+				name = string(m.Pattern.ExpandString(nil, m.Template, g, match))
+			}
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// groupsFromClaims returns the group names held in the given claim. The
+// claim may either be a list of strings, or a single string. A missing
+// claim holds no groups.
+func groupsFromClaims(idToken *oidc.IDToken, claim string) ([]string, error) {
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, errors.E(err, "failed to extract claims")
+	}
+	switch v := claims[claim].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		groups := make([]string, 0, len(v))
+		for _, g := range v {
+			s, ok := g.(string)
+			if !ok {
+				return nil, errors.E(fmt.Sprintf("invalid %q claim: unexpected value of type %T", claim, g))
+			}
+			groups = append(groups, s)
+		}
+		return groups, nil
+	default:
+		return nil, errors.E(fmt.Sprintf("invalid %q claim: unexpected type %T", claim, v))
+	}
+}
+
+// syncGroups synchronises the identity's groups with those held in the
+// groups claim of the id token, issued by the given provider, in the given
+// oauth2 token. If the token does not contain an id token, as may happen
+// when refreshing an access token, the groups are left unchanged.
+func (as *AuthenticationService) syncGroups(ctx context.Context, p *identityProvider, identityName string, token *oauth2.Token) error {
+	const op = errors.Op("auth.AuthenticationService.syncGroups")
+
+	if _, ok := token.Extra("id_token").(string); !ok {
+		zapctx.Debug(ctx, "no id token, not synchronising groups", zap.String("identity", identityName))
+		return nil
+	}
+	idToken, err := as.ExtractAndVerifyIDToken(ctx, p.name, token)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	groups, err := groupsFromClaims(idToken, p.groupsClaim)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := as.groupSyncer.SyncIdentityGroups(ctx, identityName, p.groupMapping.Map(groups)); err != nil {
+		return errors.E(op, err, "failed to synchronise groups")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical.
+
+package auth_test
+
+import (
+	"regexp"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/auth"
+)
+
+func TestGroupMapping(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about   string
+		mapping auth.GroupMapping
+		groups  []string
+		expect  []string
+	}{{
+		about:  "no mapping",
+		groups: []string{"devs", "ops", "devs"},
+		expect: []string{"devs", "ops"},
+	}, {
+		about: "pattern filters groups",
+		mapping: auth.GroupMapping{
+			Pattern: regexp.MustCompile(`^jaas-`),
+		},
+		groups: []string{"jaas-devs", "other", "jaas-ops"},
+		expect: []string{"jaas-devs", "jaas-ops"},
+	}, {
+		about: "template renames groups",
+		mapping: auth.GroupMapping{
+			Pattern:  regexp.MustCompile(`^/jaas/(.+)$`),
+			Template: "idp-$1",
+		},
+		groups: []string{"/jaas/devs", "/other/ops", "/jaas/ops"},
+		expect: []string{"idp-devs", "idp-ops"},
+	}, {
+		about:  "no groups",
+		expect: []string{},
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			c.Check(test.mapping.Map(test.groups), qt.DeepEquals, test.expect)
+		})
+	}
+}
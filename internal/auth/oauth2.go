@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -44,6 +45,10 @@ const (
 
 	// StateKey is the key for the OAuth callback state stored within a user's cookie.
 	StateKey = "jimm-oauth-state"
+
+	// ProviderKey is the key for the name of the identity provider used for
+	// a browser login stored within a user's cookie.
+	ProviderKey = "jimm-oauth-provider"
 )
 
 type sessionIdentityContextKey struct{}
@@ -69,10 +74,9 @@ func SessionIdentityFromContext(ctx context.Context) string {
 
 // AuthenticationService handles authentication within JIMM.
 type AuthenticationService struct {
-	oauthConfig oauth2.Config
-	// provider holds a OIDC provider wrapper for the OAuth2.0 /x/oauth package,
-	// enabling UserInfo calls, wellknown retrieval and jwks verification.
-	provider *oidc.Provider
+	// providers holds the identity providers users may log in with. The
+	// default provider is always first.
+	providers []*identityProvider
 	// sessionTokenExpiry holds the expiry time for JIMM minted session tokens (JWTs).
 	sessionTokenExpiry time.Duration
 	// sessionCookieMaxAge holds the max age for session cookies in seconds.
@@ -90,6 +94,13 @@ type AuthenticationService struct {
 	db IdentityStore
 
 	sessionStore sessions.Store
+
+	// groupSyncer updates the identity's groups.
+	groupSyncer GroupSyncer
+
+	// allowedDomains holds the domains of identities allowed to log in.
+	// If empty identities from any domain may log in.
+	allowedDomains []string
 }
 
 // Identity store holds the necessary methods to get and update an identity
@@ -100,7 +111,9 @@ type IdentityStore interface {
 }
 
 // AuthenticationServiceParams holds the parameters to initialise
-// an Authentication Service.
+// an Authentication Service. The OAuth2.0 parameters configure the
+// default identity provider, further providers may be added with
+// Providers.
 type AuthenticationServiceParams struct {
 	// IssuerURL is the URL of the OAuth2.0 server.
 	// I.e., http://localhost:8082/realms/jimm in the case of keycloak.
@@ -141,6 +154,45 @@ type AuthenticationServiceParams struct {
 
 	// SessionStore holds the store for creating, getting and saving gorrila sessions.
 	SessionStore sessions.Store
+
+	// GroupsClaim holds the name of the id token claim listing the
+	// identity provider groups the identity belongs to, for example
+	// "groups". If set, the identity's membership of externally managed
+	// JIMM groups is synchronised with the claim on every login.
+	GroupsClaim string
+
+	// GroupMapping maps the group names in the groups claim to JIMM
+	// group names.
+	GroupMapping GroupMapping
+
+	// GroupSyncer updates the identity's groups. It must be set if
+	// any provider has a groups claim.
+	GroupSyncer GroupSyncer
+
+	// IdentityClaim holds the name of the id token claim used as the
+	// JIMM identity name, for example "email", "preferred_username" or
+	// "sub". If empty the "email" claim is used.
+	IdentityClaim string
+
+	// IdentitySuffix is the suffix of the names of the default
+	// provider's identities, for example "@example.com". When the
+	// identity claim is "email" the email address must end with the
+	// suffix, otherwise the suffix is appended to the value of the claim.
+	// The resulting name must be an email address.
+	IdentitySuffix string
+
+	// RequireEmailVerified decides whether the email_verified claim must
+	// be true for a user to log in.
+	RequireEmailVerified bool
+
+	// AllowedDomains holds the domains of the identities allowed to log
+	// in, for example "canonical.com". If empty identities from any
+	// domain may log in.
+	AllowedDomains []string
+
+	// Providers holds the parameters of any identity providers users may
+	// log in with in addition to the default provider.
+	Providers []ProviderParams
 }
 
 // NewAuthenticationService returns a new authentication service for handling
@@ -148,21 +200,36 @@ type AuthenticationServiceParams struct {
 func NewAuthenticationService(ctx context.Context, params AuthenticationServiceParams) (*AuthenticationService, error) {
 	const op = errors.Op("auth.NewAuthenticationService")
 
-	provider, err := oidc.NewProvider(ctx, params.IssuerURL)
-	if err != nil {
-		zapctx.Error(ctx, "failed to create oidc provider", zap.Error(err))
-		return nil, errors.E(op, errors.CodeServerConfiguration, err, "failed to create oidc provider")
+	providerParams := append([]ProviderParams{{
+		Name:                 DefaultProvider,
+		IssuerURL:            params.IssuerURL,
+		ClientID:             params.ClientID,
+		ClientSecret:         params.ClientSecret,
+		Scopes:               params.Scopes,
+		IdentityClaim:        params.IdentityClaim,
+		IdentitySuffix:       params.IdentitySuffix,
+		RequireEmailVerified: params.RequireEmailVerified,
+		GroupsClaim:          params.GroupsClaim,
+		GroupMapping:         params.GroupMapping,
+	}}, params.Providers...)
+	if err := validateProviders(providerParams); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	providers := make([]*identityProvider, len(providerParams))
+	for i, pp := range providerParams {
+		if pp.GroupsClaim != "" && params.GroupSyncer == nil {
+			return nil, errors.E(op, errors.CodeServerConfiguration, "groups claim set without a group syncer")
+		}
+		p, err := newIdentityProvider(ctx, pp, params.RedirectURL)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		providers[i] = p
 	}
 
 	return &AuthenticationService{
-		provider: provider,
-		oauthConfig: oauth2.Config{
-			ClientID:     params.ClientID,
-			ClientSecret: params.ClientSecret,
-			Endpoint:     provider.Endpoint(),
-			Scopes:       params.Scopes,
-			RedirectURL:  params.RedirectURL,
-		},
+		providers:           providers,
 		sessionTokenExpiry:  params.SessionTokenExpiry,
 		jwtSessionKey:       params.JWTSessionKey,
 		signingAlg:          jwa.HS256,
@@ -170,26 +237,33 @@ func NewAuthenticationService(ctx context.Context, params AuthenticationServiceP
 		sessionStore:        params.SessionStore,
 		sessionCookieMaxAge: params.SessionCookieMaxAge,
 		secureCookies:       params.SecureCookies,
+		groupSyncer:         params.GroupSyncer,
+		allowedDomains:      params.AllowedDomains,
 	}, nil
 }
 
 // AuthCodeURL returns a URL that will be used to redirect a browser to the identity provider.
 // It also generates a random state string that was used as part of the auth code URL. The state string
 // is returned alongside the auth code URL and any errors that occured during state generation.
-func (as *AuthenticationService) AuthCodeURL() (string, string, error) {
+// If provider is empty the default identity provider is used.
+func (as *AuthenticationService) AuthCodeURL(provider string) (string, string, error) {
 	// Hydra requires the state parameter to be at least 8 characters.
 	// Note that state is primarily a guard against csrf attacks.
 	// A good reference is https://spring.io/blog/2011/11/30/cross-site-request-forgery-and-oauth2
 	// Because Hydra only accepts return addresses that have been pre-registered
 	// the risk of csrf attacks is largely eliminated, but this may not be the case with other IdPs.
 	const op = errors.Op("AuthenticationService.AuthCodeURL")
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return "", "", errors.E(op, err)
+	}
 	b := make([]byte, 8)
-	_, err := rand.Read(b)
+	_, err = rand.Read(b)
 	if err != nil {
 		return "", "", errors.E(op, fmt.Sprintf("failed to generate state secret: %s", err.Error()))
 	}
 	state := base64.RawURLEncoding.EncodeToString(b)
-	return as.oauthConfig.AuthCodeURL(state), state, nil
+	return p.oauthConfig.AuthCodeURL(state), state, nil
 }
 
 // Exchange exchanges an authorisation code for an access token.
@@ -198,13 +272,17 @@ func (as *AuthenticationService) AuthCodeURL() (string, string, error) {
 // this may need some thought as to whether its actually worth testing or are we
 // just testing the library. The handler test essentially covers this so perhaps
 // its ok to leave it as is?
-func (as *AuthenticationService) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+func (as *AuthenticationService) Exchange(ctx context.Context, provider, code string) (*oauth2.Token, error) {
 	const op = errors.Op("auth.AuthenticationService.Exchange")
 
-	t, err := as.oauthConfig.Exchange(
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	t, err := p.oauthConfig.Exchange(
 		ctx,
 		code,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", p.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		return nil, errors.E(op, err, "authorisation code exchange failed")
@@ -227,12 +305,18 @@ func (as *AuthenticationService) Exchange(ctx context.Context, code string) (*oa
 // into the uri.
 //
 // The interval, expiry and device code and used to poll the token endpoint for completion.
-func (as *AuthenticationService) Device(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+//
+// If provider is empty the default identity provider is used.
+func (as *AuthenticationService) Device(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error) {
 	const op = errors.Op("auth.AuthenticationService.Device")
 
-	resp, err := as.oauthConfig.DeviceAuth(
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	resp, err := p.oauthConfig.DeviceAuth(
 		ctx,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", p.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		zapctx.Error(ctx, "device auth call failed", zap.Error(err))
@@ -246,13 +330,17 @@ func (as *AuthenticationService) Device(ctx context.Context) (*oauth2.DeviceAuth
 // and is step TWO.
 //
 // See Device(...) godoc for more info pertaining to the flow.
-func (as *AuthenticationService) DeviceAccessToken(ctx context.Context, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
+func (as *AuthenticationService) DeviceAccessToken(ctx context.Context, provider string, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
 	const op = errors.Op("auth.AuthenticationService.DeviceAccessToken")
 
-	t, err := as.oauthConfig.DeviceAccessToken(
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	t, err := p.oauthConfig.DeviceAccessToken(
 		ctx,
 		res,
-		oauth2.SetAuthURLParam("client_secret", as.oauthConfig.ClientSecret),
+		oauth2.SetAuthURLParam("client_secret", p.oauthConfig.ClientSecret),
 	)
 	if err != nil {
 		return nil, errors.E(op, err, "device access token call failed")
@@ -262,18 +350,24 @@ func (as *AuthenticationService) DeviceAccessToken(ctx context.Context, res *oau
 }
 
 // ExtractAndVerifyIDToken extracts the id token from the extras claims of an oauth2 token
-// and performs signature verification of the token.
-func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error) {
+// and performs signature verification of the token using the keys of the given
+// identity provider.
+func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, provider string, oauth2Token *oauth2.Token) (*oidc.IDToken, error) {
 	const op = errors.Op("auth.AuthenticationService.ExtractAndVerifyIDToken")
 
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	// Extract the ID Token from oauth2 token.
 	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
 	if !ok {
 		return nil, errors.E(op, "failed to extract id token")
 	}
 
-	verifier := as.provider.Verifier(&oidc.Config{
-		ClientID: as.oauthConfig.ClientID,
+	verifier := p.provider.Verifier(&oidc.Config{
+		ClientID: p.oauthConfig.ClientID,
 	})
 
 	token, err := verifier.Verify(ctx, rawIDToken)
@@ -285,23 +379,74 @@ func (as *AuthenticationService) ExtractAndVerifyIDToken(ctx context.Context, oa
 	return token, nil
 }
 
-// Email retrieves the users email from an id token via the email claim
-func (as *AuthenticationService) Email(idToken *oidc.IDToken) (string, error) {
+// Email retrieves the user's identity name from an id token issued by the
+// given identity provider. The name is taken from the provider's identity
+// claim, the email claim by default. An email address must end with the
+// provider's identity suffix, the value of any other claim is followed by
+// the suffix. If required, the email_verified claim must be true and the name
+// must belong to one of the allowed domains.
+func (as *AuthenticationService) Email(provider string, idToken *oidc.IDToken) (string, error) {
 	const op = errors.Op("auth.AuthenticationService.Email")
 
-	var claims struct {
-		Email         string `json:"email"`
-		EmailVerified bool   `json:"email_verified"` // TODO(ale8k): Add verification logic
-	}
 	if idToken == nil {
 		return "", errors.E(op, "id token is nil")
 	}
 
+	p, err := as.identityProvider(provider)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+
+	var claims map[string]any
 	if err := idToken.Claims(&claims); err != nil {
 		return "", errors.E(op, err, "failed to extract claims")
 	}
 
-	return claims.Email, nil
+	if p.requireEmailVerified {
+		if verified, _ := claims["email_verified"].(bool); !verified {
+			return "", errors.E(op, errors.CodeUnauthorized, "email not verified")
+		}
+	}
+
+	value, _ := claims[p.identityClaim].(string)
+	if value == "" {
+		return "", errors.E(op, fmt.Sprintf("id token has no %q claim", p.identityClaim))
+	}
+	name, err := p.identityName(value)
+	if err != nil {
+		return "", errors.E(op, err)
+	}
+	if err := as.checkIdentityName(name); err != nil {
+		return "", errors.E(op, err)
+	}
+	// Prevent the identities of one provider impersonating those of
+	// another.
+	if as.providerForIdentity(name) != p {
+		return "", errors.E(op, errors.CodeUnauthorized, fmt.Sprintf("identity %q belongs to another identity provider", name))
+	}
+	return name, nil
+}
+
+// checkIdentityName checks that the given identity name is a bare email
+// address in one of the allowed domains.
+func (as *AuthenticationService) checkIdentityName(name string) error {
+	// ParseAddress also accepts addresses with a display name, such as
+	// "Bob <bob@example.com>", so the name must be exactly the parsed
+	// address.
+	addr, err := mail.ParseAddress(name)
+	if err != nil || addr.Address != name {
+		return errors.E(errors.CodeBadRequest, "failed to parse email")
+	}
+	if len(as.allowedDomains) == 0 {
+		return nil
+	}
+	domain := addr.Address[strings.LastIndex(addr.Address, "@")+1:]
+	for _, d := range as.allowedDomains {
+		if strings.EqualFold(domain, d) {
+			return nil
+		}
+	}
+	return errors.E(errors.CodeUnauthorized, fmt.Sprintf("domain %q is not allowed", domain))
 }
 
 // MintSessionToken mints a session token to be used when logging into JIMM
@@ -364,15 +509,16 @@ func (as *AuthenticationService) VerifySessionToken(token string) (_ jwt.Token,
 		return nil, errorFn(err.Error())
 	}
 
-	if _, err = mail.ParseAddress(parsedToken.Subject()); err != nil {
-		return nil, errorFn("failed to parse email")
+	if err := as.checkIdentityName(parsedToken.Subject()); err != nil {
+		return nil, errorFn(err.Error())
 	}
 
 	return parsedToken, nil
 }
 
 // UpdateIdentity updates the database with the display name and access token set for the user.
-// And, if present, a refresh token.
+// And, if present, a refresh token. If a groups claim is configured the user's
+// externally managed groups are synchronised with the claim in the id token.
 func (as *AuthenticationService) UpdateIdentity(ctx context.Context, email string, token *oauth2.Token) error {
 	const op = errors.Op("auth.UpdateIdentity")
 
@@ -401,10 +547,17 @@ func (as *AuthenticationService) UpdateIdentity(ctx context.Context, email strin
 		return errors.E(op, err)
 	}
 
+	if p := as.providerForIdentity(u.Name); p.groupsClaim != "" {
+		if err := as.syncGroups(ctx, p, u.Name, token); err != nil {
+			return errors.E(op, err)
+		}
+	}
+
 	return nil
 }
 
-// VerifyClientCredentials verifies the provided client ID and client secret.
+// VerifyClientCredentials verifies the provided client ID and client secret
+// with the identity provider that verifies service accounts.
 func (as *AuthenticationService) VerifyClientCredentials(ctx context.Context, clientID string, clientSecret string) (err error) {
 	defer func() {
 		if err != nil {
@@ -414,12 +567,13 @@ func (as *AuthenticationService) VerifyClientCredentials(ctx context.Context, cl
 		}
 	}()
 
+	p := as.serviceAccountProvider()
 	cfg := clientcredentials.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
-		TokenURL:     as.oauthConfig.Endpoint.TokenURL,
-		Scopes:       as.oauthConfig.Scopes,
-		AuthStyle:    oauth2.AuthStyle(as.oauthConfig.Endpoint.AuthStyle),
+		TokenURL:     p.oauthConfig.Endpoint.TokenURL,
+		Scopes:       p.oauthConfig.Scopes,
+		AuthStyle:    oauth2.AuthStyle(p.oauthConfig.Endpoint.AuthStyle),
 	}
 
 	_, err = cfg.Token(ctx)
@@ -578,6 +732,7 @@ func (as *AuthenticationService) Whoami(ctx context.Context) (*params.WhoamiResp
 	return &params.WhoamiResponse{
 		DisplayName: u.DisplayName,
 		Email:       u.Name,
+		Provider:    as.providerForIdentity(u.Name).name,
 	}, nil
 
 }
@@ -604,6 +759,14 @@ func (as *AuthenticationService) validateAndUpdateAccessToken(ctx context.Contex
 		return errors.E(op, err)
 	}
 
+	if u.Disabled {
+		return errors.E(op, errors.CodeUnauthorized, "identity disabled")
+	}
+
+	if err := as.checkIdentityName(u.Name); err != nil {
+		return errors.E(op, errors.CodeUnauthorized, err)
+	}
+
 	t := &oauth2.Token{
 		AccessToken:  u.AccessToken,
 		RefreshToken: u.RefreshToken,
@@ -631,7 +794,7 @@ func (as *AuthenticationService) validateAndUpdateAccessToken(ctx context.Contex
 func (as *AuthenticationService) refreshIdentitiesToken(ctx context.Context, email string, t *oauth2.Token) error {
 	const op = errors.Op("auth.AuthenticationService.refreshIdentitiesToken")
 
-	tSrc := as.oauthConfig.TokenSource(ctx, t)
+	tSrc := as.providerForIdentity(email).oauthConfig.TokenSource(ctx, t)
 
 	// Get a new access and refresh token (token source only has Token())
 	newToken, err := tSrc.Token()
@@ -0,0 +1,248 @@
+// Copyright 2024 Canonical.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	qt "github.com/frankban/quicktest"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestCheckIdentityName(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about          string
+		allowedDomains []string
+		name           string
+		expectCode     errors.Code
+		expectError    string
+	}{{
+		about: "any domain allowed",
+		name:  "alice@example.com",
+	}, {
+		about:       "not an email",
+		name:        "alice",
+		expectCode:  errors.CodeBadRequest,
+		expectError: "failed to parse email",
+	}, {
+		about:       "display name",
+		name:        "Bob <bob@example.com>",
+		expectCode:  errors.CodeBadRequest,
+		expectError: "failed to parse email",
+	}, {
+		about:          "display name hiding domain",
+		allowedDomains: []string{"canonical.com"},
+		name:           "bob@example.com <bob@canonical.com>",
+		expectCode:     errors.CodeBadRequest,
+		expectError:    "failed to parse email",
+	}, {
+		about:          "allowed domain",
+		allowedDomains: []string{"canonical.com", "example.com"},
+		name:           "alice@Example.com",
+	}, {
+		about:          "domain not allowed",
+		allowedDomains: []string{"canonical.com"},
+		name:           "alice@example.com",
+		expectCode:     errors.CodeUnauthorized,
+		expectError:    `domain "example.com" is not allowed`,
+	}, {
+		about:          "subdomain not allowed",
+		allowedDomains: []string{"canonical.com"},
+		name:           "alice@evil.canonical.com.example",
+		expectCode:     errors.CodeUnauthorized,
+		expectError:    `domain "evil.canonical.com.example" is not allowed`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			as := &AuthenticationService{allowedDomains: test.allowedDomains}
+			err := as.checkIdentityName(test.name)
+			if test.expectError == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+			c.Check(err, qt.ErrorMatches, test.expectError)
+			c.Check(errors.ErrorCode(err), qt.Equals, test.expectCode)
+		})
+	}
+}
+
+func TestValidateProviders(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about       string
+		providers   []ProviderParams
+		expectError string
+	}{{
+		about: "valid providers",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{Name: "partner", IdentitySuffix: "@partner.example.com"},
+			{Name: "other", IdentitySuffix: "@other.example.com"},
+		},
+	}, {
+		about: "missing name",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{IdentitySuffix: "@partner.example.com"},
+		},
+		expectError: "identity provider has no name",
+	}, {
+		about: "duplicate name",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{Name: DefaultProvider, IdentitySuffix: "@partner.example.com"},
+		},
+		expectError: `duplicate identity provider "default"`,
+	}, {
+		about: "missing suffix",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{Name: "partner"},
+		},
+		expectError: `identity provider "partner" has no identity suffix`,
+	}, {
+		about: "overlapping suffixes",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{Name: "partner", IdentitySuffix: "@partner.example.com"},
+			{Name: "other", IdentitySuffix: ".Example.com"},
+		},
+		expectError: `identity providers "partner" and "other" have overlapping identity suffixes`,
+	}, {
+		about: "default suffix overlaps",
+		providers: []ProviderParams{
+			{Name: DefaultProvider, IdentitySuffix: "example.com"},
+			{Name: "partner", IdentitySuffix: "@partner.example.com"},
+		},
+		expectError: `identity providers "default" and "partner" have overlapping identity suffixes`,
+	}, {
+		about: "two service account providers",
+		providers: []ProviderParams{
+			{Name: DefaultProvider},
+			{Name: "partner", IdentitySuffix: "@partner.example.com", ServiceAccounts: true},
+			{Name: "other", IdentitySuffix: "@other.example.com", ServiceAccounts: true},
+		},
+		expectError: `identity providers "partner" and "other" both verify service accounts`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			err := validateProviders(test.providers)
+			if test.expectError == "" {
+				c.Check(err, qt.IsNil)
+				return
+			}
+			c.Check(err, qt.ErrorMatches, test.expectError)
+			c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+		})
+	}
+}
+
+func TestProviderForIdentity(t *testing.T) {
+	c := qt.New(t)
+
+	defaultProvider := &identityProvider{name: DefaultProvider}
+	partner := &identityProvider{name: "partner", identitySuffix: "@partner.example.com"}
+	as := &AuthenticationService{providers: []*identityProvider{defaultProvider, partner}}
+
+	c.Check(as.providerForIdentity("alice@canonical.com"), qt.Equals, defaultProvider)
+	c.Check(as.providerForIdentity("bob@Partner.example.com"), qt.Equals, partner)
+
+	p, err := as.identityProvider("")
+	c.Assert(err, qt.IsNil)
+	c.Check(p, qt.Equals, defaultProvider)
+	p, err = as.identityProvider("partner")
+	c.Assert(err, qt.IsNil)
+	c.Check(p, qt.Equals, partner)
+	_, err = as.identityProvider("unknown")
+	c.Check(err, qt.ErrorMatches, `unknown identity provider "unknown"`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+
+	c.Check(as.serviceAccountProvider(), qt.Equals, defaultProvider)
+	partner.serviceAccounts = true
+	c.Check(as.serviceAccountProvider(), qt.Equals, partner)
+}
+
+func TestEmailAdditionalProvider(t *testing.T) {
+	c := qt.New(t)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, qt.IsNil)
+	verifier := oidc.NewVerifier("https://idp.example.com", &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&key.PublicKey}}, &oidc.Config{SkipClientIDCheck: true})
+	idToken := func(claims map[string]any) *oidc.IDToken {
+		b := jwt.NewBuilder().Issuer("https://idp.example.com").Expiration(time.Now().Add(time.Hour))
+		for k, v := range claims {
+			b = b.Claim(k, v)
+		}
+		token, err := b.Build()
+		c.Assert(err, qt.IsNil)
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+		c.Assert(err, qt.IsNil)
+		t, err := verifier.Verify(context.Background(), string(signed))
+		c.Assert(err, qt.IsNil)
+		return t
+	}
+
+	as := &AuthenticationService{providers: []*identityProvider{
+		{name: DefaultProvider, identityClaim: "email"},
+		{name: "partner", identityClaim: "email", identitySuffix: "@partner.example.com"},
+		{name: "other", identityClaim: "sub", identitySuffix: "@other.example.com"},
+	}}
+
+	tests := []struct {
+		about       string
+		provider    string
+		claims      map[string]any
+		expectName  string
+		expectError string
+	}{{
+		about:      "default provider",
+		claims:     map[string]any{"email": "alice@canonical.com"},
+		expectName: "alice@canonical.com",
+	}, {
+		about:      "email claim ending with the suffix",
+		provider:   "partner",
+		claims:     map[string]any{"email": "bob@Partner.example.com"},
+		expectName: "bob@Partner.example.com",
+	}, {
+		about:       "email claim not ending with the suffix",
+		provider:    "partner",
+		claims:      map[string]any{"email": "bob@canonical.com"},
+		expectError: `identity "bob@canonical.com" does not end with "@partner.example.com"`,
+	}, {
+		about:      "suffix appended to other claims",
+		provider:   "other",
+		claims:     map[string]any{"sub": "carol"},
+		expectName: "carol@other.example.com",
+	}, {
+		about:       "default provider identity of another provider",
+		claims:      map[string]any{"email": "mallory@partner.example.com"},
+		expectError: `identity "mallory@partner.example.com" belongs to another identity provider`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			name, err := as.Email(test.provider, idToken(test.claims))
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(name, qt.Equals, test.expectName)
+		})
+	}
+}
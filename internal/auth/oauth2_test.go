@@ -69,7 +69,7 @@ func TestAuthCodeURL(t *testing.T) {
 	authSvc, _, _, cleanup := setupTestAuthSvc(ctx, c, time.Hour)
 	defer cleanup()
 
-	url, state, err := authSvc.AuthCodeURL()
+	url, state, err := authSvc.AuthCodeURL("")
 	c.Assert(err, qt.IsNil)
 	c.Assert(
 		url,
@@ -98,7 +98,7 @@ func TestDevice(t *testing.T) {
 	authSvc, db, _, cleanup := setupTestAuthSvc(ctx, c, time.Hour)
 	defer cleanup()
 
-	res, err := authSvc.Device(ctx)
+	res, err := authSvc.Device(ctx, "")
 	c.Assert(err, qt.IsNil)
 
 	jar, err := cookiejar.New(nil)
@@ -151,12 +151,12 @@ func TestDevice(t *testing.T) {
 	c.Assert(re.MatchString(string(b)), qt.IsTrue)
 
 	// Retrieve access token
-	token, err := authSvc.DeviceAccessToken(ctx, res)
+	token, err := authSvc.DeviceAccessToken(ctx, "", res)
 	c.Assert(err, qt.IsNil)
 	c.Assert(token, qt.IsNotNil)
 
 	// Extract and verify id token
-	idToken, err := authSvc.ExtractAndVerifyIDToken(ctx, token)
+	idToken, err := authSvc.ExtractAndVerifyIDToken(ctx, "", token)
 	c.Assert(err, qt.IsNil)
 	c.Assert(idToken, qt.IsNotNil)
 
@@ -164,7 +164,7 @@ func TestDevice(t *testing.T) {
 	c.Assert(idToken.Subject, qt.Equals, u.Id)
 
 	// Retrieve the email
-	email, err := authSvc.Email(idToken)
+	email, err := authSvc.Email("", idToken)
 	c.Assert(err, qt.IsNil)
 	c.Assert(email, qt.Equals, u.Email)
 
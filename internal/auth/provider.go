@@ -0,0 +1,225 @@
+// Copyright 2024 Canonical.
+
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// DefaultProvider is the name of the identity provider configured by the
+// top level fields of AuthenticationServiceParams. It is used whenever a
+// client does not specify a provider.
+const DefaultProvider = "default"
+
+// ProviderParams holds the parameters of an additional OIDC identity
+// provider.
+type ProviderParams struct {
+	// Name is the name clients use to select the provider.
+	Name string
+
+	// IssuerURL is the URL of the OAuth2.0 server.
+	IssuerURL string
+
+	// ClientID holds the OAuth2.0 client id. The client IS expected to be confidential.
+	ClientID string
+
+	// ClientSecret holds the OAuth2.0 "client-secret" to authenticate when performing
+	// /auth and /token requests.
+	ClientSecret string
+
+	// Scopes holds the scopes that you wish to retrieve.
+	Scopes []string
+
+	// IdentityClaim holds the name of the id token claim used as the
+	// JIMM identity name. If empty the "email" claim is used.
+	IdentityClaim string
+
+	// IdentitySuffix, for example "@partner.example.com", is the suffix
+	// of the names of the provider's identities. When the identity claim
+	// is "email" the email address must already end with the suffix,
+	// otherwise the suffix is appended to the value of the claim. The
+	// suffix is required and must not overlap with the suffix of any
+	// other provider so that the names of the identities of different
+	// providers cannot collide.
+	IdentitySuffix string
+
+	// RequireEmailVerified decides whether the email_verified claim must
+	// be true for a user to log in.
+	RequireEmailVerified bool
+
+	// GroupsClaim holds the name of the id token claim listing the
+	// identity provider groups the identity belongs to.
+	GroupsClaim string
+
+	// GroupMapping maps the group names in the groups claim to JIMM
+	// group names.
+	GroupMapping GroupMapping
+
+	// ServiceAccounts decides whether the provider verifies the client
+	// credentials of service accounts. At most one provider may set it,
+	// if none does the default provider is used.
+	ServiceAccounts bool
+}
+
+// An identityProvider is an OIDC identity provider users may log in with.
+type identityProvider struct {
+	name        string
+	oauthConfig oauth2.Config
+	// provider holds a OIDC provider wrapper for the OAuth2.0 /x/oauth package,
+	// enabling UserInfo calls, wellknown retrieval and jwks verification.
+	provider *oidc.Provider
+
+	// identityClaim holds the name of the id token claim used as the
+	// identity name.
+	identityClaim string
+	// identitySuffix is the suffix of the names of the provider's
+	// identities.
+	identitySuffix string
+	// requireEmailVerified decides whether the email_verified claim must
+	// be true for a user to log in.
+	requireEmailVerified bool
+
+	// groupsClaim holds the name of the id token claim holding the
+	// identity's groups. If empty groups are not synchronised.
+	groupsClaim string
+	// groupMapping maps the identity provider's group names to JIMM
+	// group names.
+	groupMapping GroupMapping
+
+	// serviceAccounts decides whether the provider verifies the client
+	// credentials of service accounts.
+	serviceAccounts bool
+}
+
+// newIdentityProvider returns an identityProvider for the given
+// parameters, fetching the provider's discovery document.
+func newIdentityProvider(ctx context.Context, p ProviderParams, redirectURL string) (*identityProvider, error) {
+	provider, err := oidc.NewProvider(ctx, p.IssuerURL)
+	if err != nil {
+		zapctx.Error(ctx, "failed to create oidc provider", zap.String("provider", p.Name), zap.Error(err))
+		return nil, errors.E(errors.CodeServerConfiguration, err, "failed to create oidc provider")
+	}
+	identityClaim := p.IdentityClaim
+	if identityClaim == "" {
+		identityClaim = "email"
+	}
+	return &identityProvider{
+		name:     p.Name,
+		provider: provider,
+		oauthConfig: oauth2.Config{
+			ClientID:     p.ClientID,
+			ClientSecret: p.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       p.Scopes,
+			RedirectURL:  redirectURL,
+		},
+		identityClaim:        identityClaim,
+		identitySuffix:       p.IdentitySuffix,
+		requireEmailVerified: p.RequireEmailVerified,
+		groupsClaim:          p.GroupsClaim,
+		groupMapping:         p.GroupMapping,
+		serviceAccounts:      p.ServiceAccounts,
+	}, nil
+}
+
+// validateProviders checks that the given providers have unique names,
+// that, apart from the default provider, each has an identity suffix,
+// that no two identity suffixes overlap and that at most one provider
+// verifies service account credentials.
+func validateProviders(providers []ProviderParams) error {
+	names := make(map[string]bool, len(providers))
+	suffixes := make(map[string]string, len(providers))
+	serviceAccounts := ""
+	for i, p := range providers {
+		if p.Name == "" {
+			return errors.E(errors.CodeServerConfiguration, "identity provider has no name")
+		}
+		if names[p.Name] {
+			return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("duplicate identity provider %q", p.Name))
+		}
+		names[p.Name] = true
+		if p.ServiceAccounts {
+			if serviceAccounts != "" {
+				return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("identity providers %q and %q both verify service accounts", serviceAccounts, p.Name))
+			}
+			serviceAccounts = p.Name
+		}
+		if p.IdentitySuffix == "" {
+			if i == 0 {
+				// The default provider is not required to have a suffix.
+				continue
+			}
+			return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("identity provider %q has no identity suffix", p.Name))
+		}
+		suffix := strings.ToLower(p.IdentitySuffix)
+		for s, other := range suffixes {
+			if strings.HasSuffix(s, suffix) || strings.HasSuffix(suffix, s) {
+				return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("identity providers %q and %q have overlapping identity suffixes", other, p.Name))
+			}
+		}
+		suffixes[suffix] = p.Name
+	}
+	return nil
+}
+
+// identityProvider returns the identity provider with the given name. If
+// the name is empty the default provider is returned.
+func (as *AuthenticationService) identityProvider(name string) (*identityProvider, error) {
+	if name == "" {
+		name = DefaultProvider
+	}
+	for _, p := range as.providers {
+		if p.name == name {
+			return p, nil
+		}
+	}
+	return nil, errors.E(errors.CodeBadRequest, fmt.Sprintf("unknown identity provider %q", name))
+}
+
+// providerForIdentity returns the identity provider that the identity
+// with the given name logs in with. Identities whose names end with the
+// identity suffix of an additional provider belong to that provider,
+// all others belong to the default provider.
+func (as *AuthenticationService) providerForIdentity(identityName string) *identityProvider {
+	name := strings.ToLower(identityName)
+	for _, p := range as.providers[1:] {
+		if strings.HasSuffix(name, strings.ToLower(p.identitySuffix)) {
+			return p
+		}
+	}
+	return as.providers[0]
+}
+
+// identityName returns the name of the identity with the given value of
+// the provider's identity claim. Email addresses must end with the
+// provider's identity suffix, the values of other claims have the suffix
+// appended.
+func (p *identityProvider) identityName(value string) (string, error) {
+	if p.identityClaim != "email" {
+		return value + p.identitySuffix, nil
+	}
+	if !strings.HasSuffix(strings.ToLower(value), strings.ToLower(p.identitySuffix)) {
+		return "", errors.E(errors.CodeUnauthorized, fmt.Sprintf("identity %q does not end with %q", value, p.identitySuffix))
+	}
+	return value, nil
+}
+
+// serviceAccountProvider returns the identity provider that verifies the
+// client credentials of service accounts.
+func (as *AuthenticationService) serviceAccountProvider() *identityProvider {
+	for _, p := range as.providers {
+		if p.serviceAccounts {
+			return p
+		}
+	}
+	return as.providers[0]
+}
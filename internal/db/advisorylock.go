@@ -0,0 +1,72 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// An AdvisoryLock is a postgres session level advisory lock. The lock is
+// held on a dedicated database connection, it is released when the lock
+// is released or if the connection is lost.
+type AdvisoryLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+// TryAdvisoryLock attempts to acquire the session level advisory lock
+// with the given key without waiting. If the lock is held by another
+// session TryAdvisoryLock returns a nil lock and no error.
+func (d *Database) TryAdvisoryLock(ctx context.Context, key int64) (_ *AdvisoryLock, err error) {
+	const op = errors.Op("db.TryAdvisoryLock")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, errors.E(op, dbError(err))
+	}
+	if !acquired {
+		conn.Close()
+		return nil, nil
+	}
+	return &AdvisoryLock{conn: conn, key: key}, nil
+}
+
+// Check checks that the connection holding the lock is still alive, and
+// therefore that the lock is still held.
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	const op = errors.Op("db.AdvisoryLock.Check")
+	if err := l.conn.PingContext(ctx); err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// Release releases the lock and closes the connection holding it.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	const op = errors.Op("db.AdvisoryLock.Release")
+	defer l.conn.Close()
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
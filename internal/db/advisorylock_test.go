@@ -0,0 +1,51 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestTryAdvisoryLockUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.TryAdvisoryLock(context.Background(), 1)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestAdvisoryLock(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	lock, err := s.Database.TryAdvisoryLock(ctx, 42)
+	c.Assert(err, qt.IsNil)
+	c.Assert(lock, qt.Not(qt.IsNil))
+	c.Check(lock.Check(ctx), qt.IsNil)
+
+	// The lock is held by another session.
+	lock2, err := s.Database.TryAdvisoryLock(ctx, 42)
+	c.Assert(err, qt.IsNil)
+	c.Check(lock2, qt.IsNil)
+
+	// A different key is independent.
+	lock3, err := s.Database.TryAdvisoryLock(ctx, 43)
+	c.Assert(err, qt.IsNil)
+	c.Assert(lock3, qt.Not(qt.IsNil))
+	c.Check(lock3.Release(ctx), qt.IsNil)
+
+	err = lock.Release(ctx)
+	c.Assert(err, qt.IsNil)
+	lock2, err = s.Database.TryAdvisoryLock(ctx, 42)
+	c.Assert(err, qt.IsNil)
+	c.Assert(lock2, qt.Not(qt.IsNil))
+	c.Check(lock2.Release(ctx), qt.IsNil)
+}
@@ -3,9 +3,12 @@
 package db
 
 import (
+	"bytes"
 	"context"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/servermon"
@@ -23,12 +26,41 @@ func (d *Database) AddAuditLogEntry(ctx context.Context, ale *dbmodel.AuditLogEn
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
+	ale.Params = sanitiseJSONB(ale.Params)
+	ale.Errors = sanitiseJSONB(ale.Errors)
 	if err := d.DB.WithContext(ctx).Create(ale).Error; err != nil {
 		return errors.E(op, dbError(err))
 	}
 	return nil
 }
 
+// sanitiseJSONB replaces any \u0000 escapes in the given JSON with
+// \ufffd. JSONB cannot store the NUL character, without this any entry
+// containing one would fail to be stored.
+func sanitiseJSONB(j dbmodel.JSON) dbmodel.JSON {
+	nul := []byte(`\u0000`)
+	if !bytes.Contains(j, nul) {
+		return j
+	}
+	out := make(dbmodel.JSON, 0, len(j))
+	for i := 0; i < len(j); i++ {
+		if j[i] != '\\' || i+1 == len(j) {
+			out = append(out, j[i])
+			continue
+		}
+		if bytes.HasPrefix(j[i:], nul) {
+			out = append(out, `\ufffd`...)
+			i += len(nul) - 1
+			continue
+		}
+		// Copy the escape sequence as is, so that an escaped
+		// backslash is not mistaken for the start of another escape.
+		out = append(out, j[i], j[i+1])
+		i++
+	}
+	return out
+}
+
 // An AuditLogFilter defines a filter for audit-log entries.
 type AuditLogFilter struct {
 	// Start defines the earliest time to show audit events for. If
@@ -53,6 +85,33 @@ type AuditLogFilter struct {
 	// called a specific facade method.
 	Method string `json:"method,omitempty"`
 
+	// FacadeName is used to filter the event log to only contain events
+	// that called a method on a specific facade.
+	FacadeName string `json:"facadeName,omitempty"`
+
+	// ConversationId is used to filter the event log to only contain
+	// events from a specific conversation.
+	ConversationId string `json:"conversationId,omitempty"`
+
+	// IsResponse is used to filter the event log to only contain
+	// responses, if true, or requests, if false. If this is nil both
+	// requests and responses are matched.
+	IsResponse *bool `json:"isResponse,omitempty"`
+
+	// ErrorsOnly is used to filter the event log to only contain
+	// responses that contain at least one error.
+	ErrorsOnly bool `json:"errorsOnly,omitempty"`
+
+	// ParamsPath is an SQL/JSON path predicate that the params of the
+	// audit log entry must match, if this is empty all params are
+	// matched.
+	ParamsPath string `json:"paramsPath,omitempty"`
+
+	// ParamsSearch is a full-text search query that the string values
+	// in the params of the audit log entry must match, if this is empty
+	// all params are matched.
+	ParamsSearch string `json:"paramsSearch,omitempty"`
+
 	// Offset is an offset that will be added when retrieving audit logs.
 	// An empty offset is equivalent to zero.
 	Offset int `json:"offset,omitempty"`
@@ -64,6 +123,19 @@ type AuditLogFilter struct {
 	// SortTime will sort by most recent first (time descending) when true.
 	// When false no explicit ordering will be applied.
 	SortTime bool `json:"sortTime,omitempty"`
+
+	// AfterID is used to filter the event log to only contain events
+	// with an ID greater than the given value. A value of zero matches
+	// all events.
+	AfterID uint `json:"afterID,omitempty"`
+
+	// SortID will sort by ID ascending (oldest first) when true. It is
+	// ignored if SortTime is also set.
+	SortID bool `json:"sortID,omitempty"`
+
+	// SortIDDesc will sort by ID descending (newest first) when true. It
+	// is ignored if SortTime or SortID is also set.
+	SortIDDesc bool `json:"sortIDDesc,omitempty"`
 }
 
 // ForEachAuditLogEntry iterates through all audit log entries that match
@@ -79,7 +151,30 @@ func (d *Database) ForEachAuditLogEntry(ctx context.Context, filter AuditLogFilt
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
-	db := d.DB.WithContext(ctx).Model(&dbmodel.AuditLogEntry{})
+	db := auditLogQuery(d.DB.WithContext(ctx).Model(&dbmodel.AuditLogEntry{}), filter)
+	rows, err := db.Rows()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ale dbmodel.AuditLogEntry
+		if err := db.ScanRows(rows, &ale); err != nil {
+			return errors.E(op, err)
+		}
+		if err := f(&ale); err != nil {
+			return err
+		}
+	}
+	if rows.Err() != nil {
+		return errors.E(op, rows.Err())
+	}
+	return nil
+}
+
+// auditLogQuery adds the conditions, ordering and limits described by
+// the given filter to the given query on the audit_log table.
+func auditLogQuery(db *gorm.DB, filter AuditLogFilter) *gorm.DB {
 	if !filter.Start.IsZero() {
 		db = db.Where("time >= ?", filter.Start)
 	}
@@ -95,32 +190,120 @@ func (d *Database) ForEachAuditLogEntry(ctx context.Context, filter AuditLogFilt
 	if filter.Method != "" {
 		db = db.Where("facade_method = ?", filter.Method)
 	}
+	if filter.FacadeName != "" {
+		db = db.Where("facade_name = ?", filter.FacadeName)
+	}
+	if filter.ConversationId != "" {
+		db = db.Where("conversation_id = ?", filter.ConversationId)
+	}
+	if filter.IsResponse != nil {
+		db = db.Where("is_response = ?", *filter.IsResponse)
+	}
+	if filter.ErrorsOnly {
+		// The expression must match the one used in the
+		// idx_audit_log_errors partial index.
+		db = db.Where(`is_response AND jsonb_path_exists(errors, '$.results[*].error.message ? (@ != "")')`)
+	}
+	if filter.ParamsPath != "" {
+		db = db.Where("params @@ CAST(? AS jsonpath)", filter.ParamsPath)
+	}
+	if filter.ParamsSearch != "" {
+		// The expression must match the one used in the
+		// idx_audit_log_params_search index.
+		db = db.Where(`jsonb_to_tsvector('simple', params, '["string"]') @@ plainto_tsquery('simple', ?)`, filter.ParamsSearch)
+	}
+	if filter.AfterID != 0 {
+		db = db.Where("id > ?", filter.AfterID)
+	}
 	if filter.SortTime {
 		db = db.Order("time DESC")
+	} else if filter.SortID {
+		db = db.Order("id ASC")
+	} else if filter.SortIDDesc {
+		db = db.Order("id DESC")
 	}
 	db = db.Limit(filter.Limit)
 	db = db.Offset(filter.Offset)
+	return db
 
-	rows, err := db.Rows()
-	if err != nil {
+}
+
+// ForEachCorrelatedAuditLogEntry iterates through all audit log requests
+// that match the given filter calling f for each request along with its
+// response, if one has been recorded. The IsResponse field of the filter
+// is ignored and ErrorsOnly matches requests whose response contains an
+// error. If f returns an error iteration stops immediately and the error
+// is returned unmodified.
+func (d *Database) ForEachCorrelatedAuditLogEntry(ctx context.Context, filter AuditLogFilter, f func(*dbmodel.CorrelatedAuditLogEntry) error) (err error) {
+	const op = errors.Op("db.ForEachCorrelatedAuditLogEntry")
+	if err := d.ready(); err != nil {
 		return errors.E(op, err)
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var ale dbmodel.AuditLogEntry
-		if err := db.ScanRows(rows, &ale); err != nil {
-			return errors.E(op, err)
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	errorsOnly := filter.ErrorsOnly
+	isResponse := false
+	filter.IsResponse = &isResponse
+	filter.ErrorsOnly = false
+
+	db := auditLogQuery(d.DB.WithContext(ctx).Model(&dbmodel.AuditLogEntry{}), filter)
+	if errorsOnly {
+		db = db.Where(`EXISTS (SELECT 1 FROM audit_log AS response WHERE response.is_response AND response.conversation_id = audit_log.conversation_id AND response.message_id = audit_log.message_id AND jsonb_path_exists(response.errors, '$.results[*].error.message ? (@ != "")'))`)
+	}
+	var requests []dbmodel.AuditLogEntry
+	if err := db.Find(&requests).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+
+	type messageKey struct {
+		conversationId string
+		messageId      uint64
+	}
+	responses := make(map[messageKey]*dbmodel.AuditLogEntry, len(requests))
+	for i := 0; i < len(requests); i += correlateBatchSize {
+		batch := requests[i:min(i+correlateBatchSize, len(requests))]
+		keys := make([][]any, len(batch))
+		for j, req := range batch {
+			keys[j] = []any{req.ConversationId, req.MessageId}
 		}
-		if err := f(&ale); err != nil {
-			return err
+		var batchResponses []dbmodel.AuditLogEntry
+		err := d.DB.WithContext(ctx).
+			Where("is_response").
+			Where("(conversation_id, message_id) IN ?", keys).
+			Order("id ASC").
+			Find(&batchResponses).Error
+		if err != nil {
+			return errors.E(op, dbError(err))
+		}
+		for j := range batchResponses {
+			key := messageKey{batchResponses[j].ConversationId, batchResponses[j].MessageId}
+			// Keep the first response if more than one is
+			// recorded for the same request.
+			if _, ok := responses[key]; !ok {
+				responses[key] = &batchResponses[j]
+			}
 		}
 	}
-	if rows.Err() != nil {
-		return errors.E(op, rows.Err())
+
+	for i := range requests {
+		entry := dbmodel.CorrelatedAuditLogEntry{
+			Request:  requests[i],
+			Response: responses[messageKey{requests[i].ConversationId, requests[i].MessageId}],
+		}
+		if err := f(&entry); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// correlateBatchSize is the maximum number of requests for which
+// responses are fetched in a single query.
+const correlateBatchSize = 500
+
 // CleanupAuditLogs cleans up audit logs after the auditLogRetentionPeriodInDays,
 // HARD deleting them from the database.
 func (d *Database) DeleteAuditLogsBefore(ctx context.Context, before time.Time) (_ int64, err error) {
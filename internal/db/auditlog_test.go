@@ -4,6 +4,7 @@ package db_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -109,8 +110,65 @@ var forEachAuditLogEntryTests = []struct {
 		IdentityTag: names.NewUserTag("alice@canonical.com").String(),
 	},
 	expectEntries: []int{0, 1, 3},
+}, {
+	name: "AfterIDFilter",
+	filter: db.AuditLogFilter{
+		AfterID: 2,
+		SortID:  true,
+	},
+	expectEntries: []int{2, 3},
+}, {
+	name: "LatestIDFilter",
+	filter: db.AuditLogFilter{
+		SortIDDesc: true,
+		Limit:      1,
+	},
+	expectEntries: []int{3},
 }}
 
+func (s *dbSuite) TestAddAuditLogEntrySanitisesNUL(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	ale := dbmodel.AuditLogEntry{
+		Time:   time.Now().UTC().Round(time.Millisecond),
+		Params: dbmodel.JSON(`{"a":"x\u0000y","b":"\\u0000"}`),
+	}
+	err = s.Database.AddAuditLogEntry(ctx, &ale)
+	c.Assert(err, qt.IsNil)
+
+	var entries []dbmodel.AuditLogEntry
+	err = s.Database.ForEachAuditLogEntry(ctx, db.AuditLogFilter{}, func(ale *dbmodel.AuditLogEntry) error {
+		entries = append(entries, *ale)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(entries, qt.HasLen, 1)
+	var params map[string]string
+	err = json.Unmarshal(entries[0].Params, &params)
+	c.Assert(err, qt.IsNil)
+	c.Check(params, qt.DeepEquals, map[string]string{"a": "x\ufffdy", "b": `\u0000`})
+}
+
+func TestSanitiseJSONB(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		json   string
+		expect string
+	}{
+		{`{"a":"b"}`, `{"a":"b"}`},
+		{`{"a":"\u0000"}`, `{"a":"\ufffd"}`},
+		{`{"a":"x\u0000\u0000y"}`, `{"a":"x\ufffd\ufffdy"}`},
+		{`{"a":"\\u0000"}`, `{"a":"\\u0000"}`},
+		{`{"a":"\\\u0000"}`, `{"a":"\\\ufffd"}`},
+	}
+	for _, test := range tests {
+		c.Check(string(db.SanitiseJSONB(dbmodel.JSON(test.json))), qt.Equals, test.expect, qt.Commentf("%s", test.json))
+	}
+}
+
 func (s *dbSuite) TestForEachAuditLogEntry(c *qt.C) {
 	ctx := context.Background()
 
@@ -150,6 +208,201 @@ func (s *dbSuite) TestForEachAuditLogEntry(c *qt.C) {
 	c.Check(err, qt.DeepEquals, testError)
 }
 
+func (s *dbSuite) TestForEachAuditLogEntryExtendedFilters(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	entries := []dbmodel.AuditLogEntry{{
+		Time:           time.Date(2020, time.February, 20, 20, 2, 20, 0, time.UTC),
+		ConversationId: "conversation-1",
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+		Params:         dbmodel.JSON(`{"applications":[{"application":"postgresql","charm-url":"ch:postgresql"}]}`),
+	}, {
+		Time:           time.Date(2020, time.February, 20, 20, 2, 21, 0, time.UTC),
+		ConversationId: "conversation-1",
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+		IsResponse:     true,
+		Errors:         dbmodel.JSON(`{"results":[{"error":{"message":"","code":""}}]}`),
+	}, {
+		Time:           time.Date(2020, time.February, 20, 20, 2, 22, 0, time.UTC),
+		ConversationId: "conversation-2",
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+		Params:         dbmodel.JSON(`{"applications":[{"application":"mysql","charm-url":"ch:mysql"}]}`),
+	}, {
+		Time:           time.Date(2020, time.February, 20, 20, 2, 23, 0, time.UTC),
+		ConversationId: "conversation-2",
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+		IsResponse:     true,
+		Errors:         dbmodel.JSON(`{"results":[{"error":{"message":"charm not found","code":"not found"}},{"error":{"message":"","code":""}}]}`),
+	}, {
+		Time:           time.Date(2020, time.February, 20, 20, 2, 24, 0, time.UTC),
+		ConversationId: "conversation-2",
+		FacadeName:     "ModelManager",
+		FacadeMethod:   "ListModels",
+		Params:         dbmodel.JSON(`{"tag":"user-alice"}`),
+	}}
+	for i := range entries {
+		err := s.Database.AddAuditLogEntry(ctx, &entries[i])
+		c.Assert(err, qt.IsNil)
+	}
+
+	isResponse := true
+	isRequest := false
+	tests := []struct {
+		name          string
+		filter        db.AuditLogFilter
+		expectEntries []int
+	}{{
+		name:          "FacadeNameFilter",
+		filter:        db.AuditLogFilter{FacadeName: "ModelManager"},
+		expectEntries: []int{4},
+	}, {
+		name:          "ConversationIdFilter",
+		filter:        db.AuditLogFilter{ConversationId: "conversation-1"},
+		expectEntries: []int{0, 1},
+	}, {
+		name:          "ResponseFilter",
+		filter:        db.AuditLogFilter{IsResponse: &isResponse},
+		expectEntries: []int{1, 3},
+	}, {
+		name:          "RequestFilter",
+		filter:        db.AuditLogFilter{IsResponse: &isRequest},
+		expectEntries: []int{0, 2, 4},
+	}, {
+		name:          "ErrorsOnlyFilter",
+		filter:        db.AuditLogFilter{ErrorsOnly: true},
+		expectEntries: []int{3},
+	}, {
+		name:          "ParamsPathFilter",
+		filter:        db.AuditLogFilter{Method: "Deploy", ParamsPath: `$.applications[*].application == "postgresql"`},
+		expectEntries: []int{0},
+	}, {
+		name:          "ParamsSearchFilter",
+		filter:        db.AuditLogFilter{ParamsSearch: "mysql"},
+		expectEntries: []int{2},
+	}}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			var ids []uint
+			err := s.Database.ForEachAuditLogEntry(ctx, test.filter, func(ale *dbmodel.AuditLogEntry) error {
+				ids = append(ids, ale.ID)
+				return nil
+			})
+			c.Assert(err, qt.IsNil)
+			expectIDs := make([]uint, len(test.expectEntries))
+			for i, n := range test.expectEntries {
+				expectIDs[i] = entries[n].ID
+			}
+			c.Check(ids, qt.ContentEquals, expectIDs)
+		})
+	}
+}
+
+func (s *dbSuite) TestForEachCorrelatedAuditLogEntry(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.ForEachCorrelatedAuditLogEntry(ctx, db.AuditLogFilter{}, nil)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	start := time.Date(2020, time.February, 20, 20, 2, 20, 0, time.UTC)
+	entries := []dbmodel.AuditLogEntry{{
+		Time:           start,
+		ConversationId: "conversation-1",
+		MessageId:      1,
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+	}, {
+		Time:           start.Add(10 * time.Millisecond),
+		ConversationId: "conversation-1",
+		MessageId:      2,
+		FacadeName:     "Client",
+		FacadeMethod:   "FullStatus",
+	}, {
+		Time:           start.Add(250 * time.Millisecond),
+		ConversationId: "conversation-1",
+		MessageId:      1,
+		FacadeName:     "Application",
+		FacadeMethod:   "Deploy",
+		IsResponse:     true,
+		Errors:         dbmodel.JSON(`{"results":[{"error":{"message":"charm not found","code":"not found"}}]}`),
+	}, {
+		Time:           start.Add(time.Second),
+		ConversationId: "conversation-2",
+		MessageId:      1,
+		FacadeName:     "Client",
+		FacadeMethod:   "FullStatus",
+	}, {
+		Time:           start.Add(1100 * time.Millisecond),
+		ConversationId: "conversation-2",
+		MessageId:      1,
+		FacadeName:     "Client",
+		FacadeMethod:   "FullStatus",
+		IsResponse:     true,
+		Errors:         dbmodel.JSON(`{"results":[{"error":{"message":"","code":""}}]}`),
+	}}
+	for i := range entries {
+		err := s.Database.AddAuditLogEntry(ctx, &entries[i])
+		c.Assert(err, qt.IsNil)
+	}
+
+	tests := []struct {
+		name            string
+		filter          db.AuditLogFilter
+		expectRequests  []int
+		expectResponses []int
+	}{{
+		name:            "NoFilter",
+		filter:          db.AuditLogFilter{SortID: true},
+		expectRequests:  []int{0, 1, 3},
+		expectResponses: []int{2, -1, 4},
+	}, {
+		name:            "MethodFilter",
+		filter:          db.AuditLogFilter{Method: "FullStatus", SortID: true},
+		expectRequests:  []int{1, 3},
+		expectResponses: []int{-1, 4},
+	}, {
+		name:            "ErrorsOnlyFilter",
+		filter:          db.AuditLogFilter{ErrorsOnly: true},
+		expectRequests:  []int{0},
+		expectResponses: []int{2},
+	}}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			var requestIDs, responseIDs []uint
+			err := s.Database.ForEachCorrelatedAuditLogEntry(ctx, test.filter, func(e *dbmodel.CorrelatedAuditLogEntry) error {
+				requestIDs = append(requestIDs, e.Request.ID)
+				if e.Response == nil {
+					responseIDs = append(responseIDs, 0)
+				} else {
+					responseIDs = append(responseIDs, e.Response.ID)
+				}
+				return nil
+			})
+			c.Assert(err, qt.IsNil)
+			var expectRequestIDs, expectResponseIDs []uint
+			for i := range test.expectRequests {
+				expectRequestIDs = append(expectRequestIDs, entries[test.expectRequests[i]].ID)
+				if test.expectResponses[i] < 0 {
+					expectResponseIDs = append(expectResponseIDs, 0)
+				} else {
+					expectResponseIDs = append(expectResponseIDs, entries[test.expectResponses[i]].ID)
+				}
+			}
+			c.Check(requestIDs, qt.DeepEquals, expectRequestIDs)
+			c.Check(responseIDs, qt.DeepEquals, expectResponseIDs)
+		})
+	}
+}
+
 func (s *dbSuite) TestDeleteAuditLogsBefore(c *qt.C) {
 	ctx := context.Background()
 	now := time.Now()
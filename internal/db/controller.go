@@ -52,6 +52,7 @@ func (d *Database) GetController(ctx context.Context, controller *dbmodel.Contro
 		db = db.Where("name = ?", controller.Name)
 	}
 	db = db.Preload("CloudRegions").Preload("CloudRegions.CloudRegion").Preload("CloudRegions.CloudRegion.Cloud")
+	db = db.Preload("Health")
 	if err := db.First(&controller).Error; err != nil {
 		err = dbError(err)
 		if errors.ErrorCode(err) == errors.CodeNotFound {
@@ -63,7 +64,7 @@ func (d *Database) GetController(ctx context.Context, controller *dbmodel.Contro
 }
 
 // UpdateController updates the given controller record. UpdateController will not store any
-// changes to a controller's CloudRegions or Models.
+// changes to a controller's CloudRegions, Models or Health.
 func (d *Database) UpdateController(ctx context.Context, controller *dbmodel.Controller) (err error) {
 	const op = errors.Op("db.UpdateController")
 
@@ -80,7 +81,7 @@ func (d *Database) UpdateController(ctx context.Context, controller *dbmodel.Con
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
 	db := d.DB.WithContext(ctx)
-	db = db.Omit("CloudRegions").Omit("Models")
+	db = db.Omit("CloudRegions").Omit("Models").Omit("Health")
 	if err := db.Save(controller).Error; err != nil {
 		return errors.E(op, dbError(err))
 	}
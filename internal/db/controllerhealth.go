@@ -0,0 +1,169 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// UpsertControllerHealth stores the given controller health, replacing
+// any existing health recorded for the controller.
+func (d *Database) UpsertControllerHealth(ctx context.Context, health *dbmodel.ControllerHealth) (err error) {
+	const op = errors.Op("db.UpsertControllerHealth")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "controller_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "watcher_state", "agent_version", "dial_latency", "last_dial", "ping_latency", "last_ping", "error_streak", "degraded_since", "last_error"}),
+	}).Create(health).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetControllerHealth fills in the given controller health using the
+// ControllerID. If no health has been recorded for the controller an
+// error with a code of CodeNotFound will be returned.
+func (d *Database) GetControllerHealth(ctx context.Context, health *dbmodel.ControllerHealth) (err error) {
+	const op = errors.Op("db.GetControllerHealth")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("controller_id = ?", health.ControllerID).First(health).Error; err != nil {
+		err = dbError(err)
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return errors.E(op, err, "controller health not found")
+		}
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListControllerHealth returns the health recorded for every controller.
+func (d *Database) ListControllerHealth(ctx context.Context) (_ []dbmodel.ControllerHealth, err error) {
+	const op = errors.Op("db.ListControllerHealth")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var health []dbmodel.ControllerHealth
+	db := d.DB.WithContext(ctx)
+	if err := db.Order("controller_id").Find(&health).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return health, nil
+}
+
+// AddControllerHealthEvent records a change in the health of a
+// controller.
+func (d *Database) AddControllerHealthEvent(ctx context.Context, event *dbmodel.ControllerHealthEvent) (err error) {
+	const op = errors.Op("db.AddControllerHealthEvent")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Create(event).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// FindControllerHealthEvents returns the most recent health events for
+// the controller with the given ID, newest first. At most limit events
+// are returned, if limit is greater than zero.
+func (d *Database) FindControllerHealthEvents(ctx context.Context, controllerID uint, limit int) (_ []dbmodel.ControllerHealthEvent, err error) {
+	const op = errors.Op("db.FindControllerHealthEvents")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	db = db.Where("controller_id = ?", controllerID).Order("created_at desc, id desc")
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	var events []dbmodel.ControllerHealthEvent
+	if err := db.Find(&events).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return events, nil
+}
+
+// FindRecentControllerHealthEvents returns, at most, the limit most
+// recent health events for every controller in a single query. The
+// events are ordered by controller ID and then newest first.
+func (d *Database) FindRecentControllerHealthEvents(ctx context.Context, limit int) (_ []dbmodel.ControllerHealthEvent, err error) {
+	const op = errors.Op("db.FindRecentControllerHealthEvents")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	ranked := db.Model(&dbmodel.ControllerHealthEvent{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY controller_id ORDER BY created_at DESC, id DESC) AS row_num")
+	var events []dbmodel.ControllerHealthEvent
+	err = db.Table("(?) AS controller_health_events", ranked).
+		Where("row_num <= ?", limit).
+		Order("controller_id, created_at DESC, id DESC").
+		Find(&events).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return events, nil
+}
+
+// DeleteControllerHealthEventsBefore deletes the controller health events
+// recorded before the given time, returning the number of events deleted.
+func (d *Database) DeleteControllerHealthEventsBefore(ctx context.Context, before time.Time) (_ int64, err error) {
+	const op = errors.Op("db.DeleteControllerHealthEventsBefore")
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	tx := d.DB.WithContext(ctx).Where("created_at < ?", before).Delete(&dbmodel.ControllerHealthEvent{})
+	if tx.Error != nil {
+		return 0, errors.E(op, dbError(tx.Error))
+	}
+	return tx.RowsAffected, nil
+}
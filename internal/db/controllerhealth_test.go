@@ -0,0 +1,138 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestUpsertControllerHealthUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.UpsertControllerHealth(context.Background(), &dbmodel.ControllerHealth{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestControllerHealth(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "test-provider",
+		Regions: []dbmodel.CloudRegion{{
+			Name: "test-region",
+		}},
+	}
+	c.Assert(s.Database.DB.Create(&cloud).Error, qt.IsNil)
+
+	controller := dbmodel.Controller{
+		Name:        "test-controller",
+		UUID:        "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName:   "test-cloud",
+		CloudRegion: "test-region",
+	}
+	err = s.Database.AddController(ctx, &controller)
+	c.Assert(err, qt.Equals, nil)
+
+	health := dbmodel.ControllerHealth{ControllerID: controller.ID}
+	err = s.Database.GetControllerHealth(ctx, &health)
+	c.Check(err, qt.ErrorMatches, `controller health not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	health = dbmodel.ControllerHealth{
+		ControllerID: controller.ID,
+		UpdatedAt:    db.Now().Time,
+		WatcherState: dbmodel.WatcherStateWatching,
+		AgentVersion: "3.5.0",
+		DialLatency:  100 * time.Millisecond,
+		LastDial:     db.Now(),
+	}
+	err = s.Database.UpsertControllerHealth(ctx, &health)
+	c.Assert(err, qt.IsNil)
+
+	// Upserting again replaces the existing health.
+	health.ErrorStreak = 2
+	health.DegradedSince = db.Now()
+	health.LastError = "ping timeout"
+	err = s.Database.UpsertControllerHealth(ctx, &health)
+	c.Assert(err, qt.IsNil)
+
+	dbHealth := dbmodel.ControllerHealth{ControllerID: controller.ID}
+	err = s.Database.GetControllerHealth(ctx, &dbHealth)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbHealth.ErrorStreak, qt.Equals, 2)
+	c.Check(dbHealth.LastError, qt.Equals, "ping timeout")
+	c.Check(dbHealth.DialLatency, qt.Equals, 100*time.Millisecond)
+	c.Check(dbHealth.Healthy(), qt.IsFalse)
+
+	all, err := s.Database.ListControllerHealth(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(all, qt.HasLen, 1)
+	c.Check(all[0].ControllerID, qt.Equals, controller.ID)
+
+	dbController := dbmodel.Controller{Name: controller.Name}
+	err = s.Database.GetController(ctx, &dbController)
+	c.Assert(err, qt.IsNil)
+	c.Assert(dbController.Health, qt.Not(qt.IsNil))
+	c.Check(dbController.Health.LastError, qt.Equals, "ping timeout")
+
+	// Updating the controller does not change its health.
+	dbController.Health.ErrorStreak = 0
+	dbController.Health.DegradedSince = sql.NullTime{}
+	err = s.Database.UpdateController(ctx, &dbController)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetControllerHealth(ctx, &dbHealth)
+	c.Assert(err, qt.IsNil)
+	c.Check(dbHealth.ErrorStreak, qt.Equals, 2)
+
+	for _, e := range []dbmodel.ControllerHealthEvent{{
+		ControllerID: controller.ID,
+		WatcherState: dbmodel.WatcherStateConnecting,
+	}, {
+		ControllerID: controller.ID,
+		WatcherState: dbmodel.WatcherStateWatching,
+		Healthy:      true,
+	}, {
+		ControllerID: controller.ID,
+		WatcherState: dbmodel.WatcherStateWatching,
+		Error:        "ping timeout",
+	}} {
+		err = s.Database.AddControllerHealthEvent(ctx, &e)
+		c.Assert(err, qt.IsNil)
+	}
+	events, err := s.Database.FindControllerHealthEvents(ctx, controller.ID, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(events, qt.HasLen, 2)
+	c.Check(events[0].Error, qt.Equals, "ping timeout")
+	c.Check(events[1].Healthy, qt.IsTrue)
+
+	events, err = s.Database.FindControllerHealthEvents(ctx, controller.ID, 0)
+	c.Assert(err, qt.IsNil)
+	c.Check(events, qt.HasLen, 3)
+
+	events, err = s.Database.FindRecentControllerHealthEvents(ctx, 2)
+	c.Assert(err, qt.IsNil)
+	c.Assert(events, qt.HasLen, 2)
+	c.Check(events[0].Error, qt.Equals, "ping timeout")
+	c.Check(events[1].Healthy, qt.IsTrue)
+
+	deleted, err := s.Database.DeleteControllerHealthEventsBefore(ctx, time.Now().Add(time.Hour))
+	c.Assert(err, qt.IsNil)
+	c.Check(deleted, qt.Equals, int64(3))
+	events, err = s.Database.FindControllerHealthEvents(ctx, controller.ID, 0)
+	c.Assert(err, qt.IsNil)
+	c.Check(events, qt.HasLen, 0)
+}
@@ -126,6 +126,22 @@ func (d *Database) Close() error {
 	return nil
 }
 
+// Ping checks that the database can be reached.
+func (d *Database) Ping(ctx context.Context) error {
+	const op = errors.Op("db.Ping")
+	if d == nil || d.DB == nil {
+		return errors.E(op, errors.CodeServerConfiguration, "database not configured")
+	}
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // Now returns the current time as a valid sql.NullTime. The time that is
 // returned is in UTC and is truncated to milliseconds which is the
 // resolution supported on all databases.
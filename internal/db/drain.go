@@ -0,0 +1,135 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AddControllerDrain stores the given controller drain along with its
+// models. AddControllerDrain returns an error with the code
+// CodeAlreadyExists if the controller already has a drain that is running
+// or paused.
+func (d *Database) AddControllerDrain(ctx context.Context, drain *dbmodel.ControllerDrain) (err error) {
+	const op = errors.Op("db.AddControllerDrain")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Omit("Controller").Create(drain).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// GetControllerDrain fills in the given controller drain and its models.
+// If the ID of the drain is set that drain is returned, otherwise the
+// most recent drain of the drain's ControllerID is returned.
+// GetControllerDrain returns an error with the code CodeNotFound if there
+// is no such drain.
+func (d *Database) GetControllerDrain(ctx context.Context, drain *dbmodel.ControllerDrain) (err error) {
+	const op = errors.Op("db.GetControllerDrain")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	switch {
+	case drain.ID != 0:
+		db = db.Where("id = ?", drain.ID)
+	case drain.ControllerID != 0:
+		db = db.Where("controller_id = ?", drain.ControllerID)
+	default:
+		return errors.E(op, errors.CodeNotFound, "controller drain not found")
+	}
+	db = db.Preload("Controller").Preload("Models", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+	if err := db.Order("id DESC").First(drain).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateControllerDrainState sets the state of the given drain, provided
+// its stored state is one of the given states. UpdateControllerDrainState
+// returns an error with the code CodeNotFound if the drain is not in one
+// of the given states.
+func (d *Database) UpdateControllerDrainState(ctx context.Context, drain *dbmodel.ControllerDrain, state string, from ...string) (err error) {
+	const op = errors.Op("db.UpdateControllerDrainState")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	result := d.DB.WithContext(ctx).Model(&dbmodel.ControllerDrain{ID: drain.ID}).Where("state IN ?", from).Update("state", state)
+	if result.Error != nil {
+		return errors.E(op, dbError(result.Error))
+	}
+	if result.RowsAffected == 0 {
+		return errors.E(op, errors.CodeNotFound, "controller drain not found")
+	}
+	drain.State = state
+	return nil
+}
+
+// UpdateControllerDrainModel updates the stored progress of a model in a
+// controller drain.
+func (d *Database) UpdateControllerDrainModel(ctx context.Context, m *dbmodel.ControllerDrainModel) (err error) {
+	const op = errors.Op("db.UpdateControllerDrainModel")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Save(m).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// ForEachControllerDrain iterates through the controller drains in any of
+// the given states, oldest first, calling f for each drain. The models of
+// the drains are not loaded. If f returns an error iteration stops
+// immediately and the error is returned unmodified.
+func (d *Database) ForEachControllerDrain(ctx context.Context, states []string, f func(*dbmodel.ControllerDrain) error) (err error) {
+	const op = errors.Op("db.ForEachControllerDrain")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var drains []dbmodel.ControllerDrain
+	if err := d.DB.WithContext(ctx).Where("state IN ?", states).Preload("Controller").Order("id").Find(&drains).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	for i := range drains {
+		if err := f(&drains[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
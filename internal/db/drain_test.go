@@ -0,0 +1,103 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestAddControllerDrainUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddControllerDrain(context.Background(), &dbmodel.ControllerDrain{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestControllerDrains(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testModelMigrationEnv)
+	env.PopulateDB(c, *s.Database)
+	ctl1 := env.Controller("controller-1").DBObject(c, *s.Database)
+
+	d := dbmodel.ControllerDrain{ControllerID: ctl1.ID}
+	err = s.Database.GetControllerDrain(ctx, &d)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	first := dbmodel.ControllerDrain{
+		ControllerID: ctl1.ID,
+		State:        dbmodel.DrainStateRunning,
+		Concurrency:  2,
+		InitiatedBy:  "alice@canonical.com",
+		Models: []dbmodel.ControllerDrainModel{{
+			ModelUUID:        "00000002-0000-0000-0000-000000000001",
+			ModelName:        "model-1",
+			TargetController: "controller-2",
+			Status:           dbmodel.DrainModelPending,
+		}},
+	}
+	err = s.Database.AddControllerDrain(ctx, &first)
+	c.Assert(err, qt.IsNil)
+
+	// Only one drain of a controller can be active.
+	err = s.Database.AddControllerDrain(ctx, &dbmodel.ControllerDrain{ControllerID: ctl1.ID, State: dbmodel.DrainStatePaused})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	d = dbmodel.ControllerDrain{ControllerID: ctl1.ID}
+	err = s.Database.GetControllerDrain(ctx, &d)
+	c.Assert(err, qt.IsNil)
+	c.Check(d.ID, qt.Equals, first.ID)
+	c.Check(d.Controller.Name, qt.Equals, "controller-1")
+	c.Assert(d.Models, qt.HasLen, 1)
+	c.Check(d.Models[0].ModelName, qt.Equals, "model-1")
+
+	d.Models[0].Status = dbmodel.DrainModelMigrating
+	err = s.Database.UpdateControllerDrainModel(ctx, &d.Models[0])
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.UpdateControllerDrainState(ctx, &d, dbmodel.DrainStateDone, dbmodel.DrainStatePaused)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+	err = s.Database.UpdateControllerDrainState(ctx, &d, dbmodel.DrainStatePaused, dbmodel.DrainStateRunning)
+	c.Assert(err, qt.IsNil)
+
+	var ids []uint
+	err = s.Database.ForEachControllerDrain(ctx, []string{dbmodel.DrainStateRunning, dbmodel.DrainStatePaused}, func(d *dbmodel.ControllerDrain) error {
+		ids = append(ids, d.ID)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []uint{first.ID})
+
+	err = s.Database.UpdateControllerDrainState(ctx, &d, dbmodel.DrainStateAborted, dbmodel.DrainStateRunning, dbmodel.DrainStatePaused)
+	c.Assert(err, qt.IsNil)
+
+	// Once the first drain has finished the controller can be drained
+	// again.
+	second := dbmodel.ControllerDrain{ControllerID: ctl1.ID, State: dbmodel.DrainStateRunning, Concurrency: 1}
+	err = s.Database.AddControllerDrain(ctx, &second)
+	c.Assert(err, qt.IsNil)
+
+	d = dbmodel.ControllerDrain{ControllerID: ctl1.ID}
+	err = s.Database.GetControllerDrain(ctx, &d)
+	c.Assert(err, qt.IsNil)
+	c.Check(d.ID, qt.Equals, second.ID)
+
+	d = dbmodel.ControllerDrain{ID: first.ID}
+	err = s.Database.GetControllerDrain(ctx, &d)
+	c.Assert(err, qt.IsNil)
+	c.Check(d.State, qt.Equals, dbmodel.DrainStateAborted)
+	c.Assert(d.Models, qt.HasLen, 1)
+	c.Check(d.Models[0].Status, qt.Equals, dbmodel.DrainModelMigrating)
+}
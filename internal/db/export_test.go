@@ -11,4 +11,5 @@ var (
 	OAuthKeyTag                = oauthKeyTag
 	OAuthSessionStoreSecretTag = oauthSessionStoreSecretTag
 	NewUUID                    = &newUUID
+	SanitiseJSONB              = sanitiseJSONB
 )
@@ -17,21 +17,41 @@ var newUUID = uuid.NewString
 // AddGroup adds a new group.
 func (d *Database) AddGroup(ctx context.Context, name string) (ge *dbmodel.GroupEntry, err error) {
 	const op = errors.Op("db.AddGroup")
-	if err := d.ready(); err != nil {
+	ge, err = d.addGroup(ctx, op, name, false)
+	if err != nil {
 		return nil, errors.E(op, err)
 	}
+	return ge, nil
+}
+
+// AddExternallyManagedGroup adds a new group whose membership is managed
+// by the identity provider.
+func (d *Database) AddExternallyManagedGroup(ctx context.Context, name string) (ge *dbmodel.GroupEntry, err error) {
+	const op = errors.Op("db.AddExternallyManagedGroup")
+	ge, err = d.addGroup(ctx, op, name, true)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return ge, nil
+}
+
+func (d *Database) addGroup(ctx context.Context, op errors.Op, name string, externallyManaged bool) (ge *dbmodel.GroupEntry, err error) {
+	if err := d.ready(); err != nil {
+		return nil, err
+	}
 
 	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
 	ge = &dbmodel.GroupEntry{
-		Name: name,
-		UUID: newUUID(),
+		Name:              name,
+		UUID:              newUUID(),
+		ExternallyManaged: externallyManaged,
 	}
 
 	if err := d.DB.WithContext(ctx).Create(ge).Error; err != nil {
-		return nil, errors.E(op, dbError(err))
+		return nil, dbError(err)
 	}
 	return ge, nil
 }
@@ -168,3 +188,22 @@ func (d *Database) RemoveGroup(ctx context.Context, group *dbmodel.GroupEntry) (
 	}
 	return nil
 }
+
+// FindExternallyManagedGroups returns all the groups whose membership is
+// managed by the identity provider.
+func (d *Database) FindExternallyManagedGroups(ctx context.Context) (_ []dbmodel.GroupEntry, err error) {
+	const op = errors.Op("db.FindExternallyManagedGroups")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var groups []dbmodel.GroupEntry
+	if err := d.DB.WithContext(ctx).Where("externally_managed = ?", true).Order("name asc").Find(&groups).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return groups, nil
+}
@@ -213,3 +213,32 @@ func (s *dbSuite) TestForEachGroup(c *qt.C) {
 		c.Assert(secondGroups[i].Name, qt.Equals, fmt.Sprintf("test-group-%d", i+5))
 	}
 }
+
+func (s *dbSuite) TestExternallyManagedGroups(c *qt.C) {
+	ctx := context.Background()
+
+	_, err := s.Database.AddExternallyManagedGroup(ctx, "idp-group")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	_, err = s.Database.AddGroup(ctx, "manual-group")
+	c.Assert(err, qt.IsNil)
+	ge, err := s.Database.AddExternallyManagedGroup(ctx, "idp-group")
+	c.Assert(err, qt.IsNil)
+	c.Check(ge.ExternallyManaged, qt.IsTrue)
+
+	_, err = s.Database.AddExternallyManagedGroup(ctx, "manual-group")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	group := dbmodel.GroupEntry{Name: "idp-group"}
+	err = s.Database.GetGroup(ctx, &group)
+	c.Assert(err, qt.IsNil)
+	c.Check(group.ExternallyManaged, qt.IsTrue)
+
+	groups, err := s.Database.FindExternallyManagedGroups(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(groups, qt.HasLen, 1)
+	c.Check(groups[0].UUID, qt.Equals, ge.UUID)
+}
@@ -19,7 +19,8 @@ import (
 // GetIdentityClouds, GetIdentityCloudCredentials, and GetIdentityModels to retrieve
 // this information.
 //
-// GetIdentity returns an error with CodeNotFound if the identity name is invalid.
+// GetIdentity returns an error with CodeNotFound if the identity name is invalid
+// or if the identity has been removed.
 func (d *Database) GetIdentity(ctx context.Context, u *dbmodel.Identity) (err error) {
 	const op = errors.Op("db.GetIdentity")
 
@@ -35,10 +36,15 @@ func (d *Database) GetIdentity(ctx context.Context, u *dbmodel.Identity) (err er
 	defer durationObserver()
 	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
 
+	// Removed identities are soft-deleted, so include them in the lookup
+	// to avoid trying to create a new identity with the same name.
 	db := d.DB.WithContext(ctx)
-	if err := db.Where("name = ?", u.Name).FirstOrCreate(&u).Error; err != nil {
+	if err := db.Unscoped().Where("name = ?", u.Name).FirstOrCreate(&u).Error; err != nil {
 		return errors.E(op, err)
 	}
+	if u.DeletedAt.Valid {
+		return errors.E(op, errors.CodeNotFound, "identity has been removed")
+	}
 	return nil
 }
 
@@ -63,7 +69,7 @@ func (d *Database) FetchIdentity(ctx context.Context, u *dbmodel.Identity) (err
 
 	db := d.DB.WithContext(ctx)
 	if err := db.Where("name = ?", u.Name).First(&u).Error; err != nil {
-		return errors.E(op, err)
+		return errors.E(op, dbError(err))
 	}
 	return nil
 }
@@ -96,6 +102,32 @@ func (d *Database) UpdateIdentity(ctx context.Context, u *dbmodel.Identity) (err
 	return nil
 }
 
+// RemoveIdentity soft-deletes the given identity. A removed identity keeps
+// its name reserved, so that it cannot be recreated by a subsequent login.
+//
+// RemoveIdentity returns an error with CodeNotFound if the identity name is
+// invalid.
+func (d *Database) RemoveIdentity(ctx context.Context, u *dbmodel.Identity) (err error) {
+	const op = errors.Op("db.RemoveIdentity")
+
+	if u.ID == 0 || u.Name == "" {
+		return errors.E(op, errors.CodeNotFound, `invalid identity name ""`)
+	}
+
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Delete(u).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
 // GetIdentityCloudCredentials fetches identity's cloud credentials for the specified cloud.
 func (d *Database) GetIdentityCloudCredentials(ctx context.Context, u *dbmodel.Identity, cloud string) (_ []dbmodel.CloudCredential, err error) {
 	const op = errors.Op("db.GetIdentityCloudCredentials")
@@ -178,3 +210,27 @@ func (d *Database) CountIdentities(ctx context.Context) (_ int, err error) {
 	}
 	return int(count), nil
 }
+
+// EnabledIdentityNames returns those of the given identity names that
+// belong to identities that exist and are not disabled.
+func (d *Database) EnabledIdentityNames(ctx context.Context, names []string) (_ []string, err error) {
+	const op = errors.Op("db.EnabledIdentityNames")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+	var enabled []string
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(&dbmodel.Identity{}).Where("name IN ? AND NOT disabled", names).Pluck("name", &enabled).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return enabled, nil
+}
@@ -119,6 +119,50 @@ func (s *dbSuite) TestUpdateIdentity(c *qt.C) {
 	c.Assert(u4, qt.DeepEquals, u3)
 }
 
+func TestRemoveIdentityUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+
+	i, err := dbmodel.NewIdentity("bob")
+	c.Assert(err, qt.IsNil)
+	i.ID = 1
+	err = d.RemoveIdentity(context.Background(), i)
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestRemoveIdentity(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	u, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	err = s.Database.RemoveIdentity(ctx, u)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = s.Database.GetIdentity(ctx, u)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.RemoveIdentity(ctx, u)
+	c.Assert(err, qt.IsNil)
+
+	u2, err := dbmodel.NewIdentity(u.Name)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.FetchIdentity(ctx, u2)
+	c.Check(err, qt.ErrorMatches, `record not found`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// Getting a removed identity must not recreate it.
+	u3, err := dbmodel.NewIdentity(u.Name)
+	c.Assert(err, qt.IsNil)
+	err = s.Database.GetIdentity(ctx, u3)
+	c.Check(err, qt.ErrorMatches, `identity has been removed`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
+
 func TestGetIdentityCloudCredentialsUnconfiguredDatabase(t *testing.T) {
 	c := qt.New(t)
 
@@ -230,3 +274,30 @@ func (s *dbSuite) TestForEachIdentityError(c *qt.C) {
 	c.Assert(err, qt.IsNotNil)
 	c.Assert(err.Error(), qt.Equals, errTest.Error())
 }
+
+func (s *dbSuite) TestEnabledIdentityNames(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com", "carol@canonical.com"} {
+		i, err := dbmodel.NewIdentity(name)
+		c.Assert(err, qt.IsNil)
+		c.Assert(s.Database.GetIdentity(ctx, i), qt.IsNil)
+		switch name {
+		case "bob@canonical.com":
+			i.Disabled = true
+			c.Assert(s.Database.UpdateIdentity(ctx, i), qt.IsNil)
+		case "carol@canonical.com":
+			c.Assert(s.Database.RemoveIdentity(ctx, i), qt.IsNil)
+		}
+	}
+
+	names, err := s.Database.EnabledIdentityNames(ctx, []string{"alice@canonical.com", "bob@canonical.com", "carol@canonical.com", "dave@canonical.com"})
+	c.Assert(err, qt.IsNil)
+	c.Check(names, qt.DeepEquals, []string{"alice@canonical.com"})
+
+	names, err = s.Database.EnabledIdentityNames(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(names, qt.HasLen, 0)
+}
@@ -0,0 +1,448 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+var (
+	// upsertModelApplication replaces any existing record for an
+	// application in the same model.
+	upsertModelApplication = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "model_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "charm_url", "charm_name", "charm_revision", "life", "status", "workload_version", "exposed", "subordinate"}),
+	}
+
+	// upsertModelUnit replaces any existing record for a unit in the
+	// same model.
+	upsertModelUnit = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "model_id"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "application", "charm_url", "machine_id", "principal", "life", "workload_status", "workload_message", "agent_status"}),
+	}
+
+	// upsertModelMachine replaces any existing record for a machine in
+	// the same model.
+	upsertModelMachine = clause.OnConflict{
+		Columns:   []clause.Column{{Name: "model_id"}, {Name: "machine_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "base", "instance_id", "hostname", "cores", "life", "agent_status", "instance_status"}),
+	}
+)
+
+// UpsertModelApplication stores the given application, replacing any
+// existing record for the application in the same model.
+func (d *Database) UpsertModelApplication(ctx context.Context, app *dbmodel.ModelApplication) (err error) {
+	const op = errors.Op("db.UpsertModelApplication")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Model").Clauses(upsertModelApplication).Create(app).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteModelApplication removes the application with the given name
+// from the model with the given ID.
+func (d *Database) DeleteModelApplication(ctx context.Context, modelID uint, name string) (err error) {
+	const op = errors.Op("db.DeleteModelApplication")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ? AND name = ?", modelID, name).Delete(&dbmodel.ModelApplication{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpsertModelUnit stores the given unit, replacing any existing record
+// for the unit in the same model.
+func (d *Database) UpsertModelUnit(ctx context.Context, unit *dbmodel.ModelUnit) (err error) {
+	const op = errors.Op("db.UpsertModelUnit")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Model").Clauses(upsertModelUnit).Create(unit).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteModelUnit removes the unit with the given name from the model
+// with the given ID.
+func (d *Database) DeleteModelUnit(ctx context.Context, modelID uint, name string) (err error) {
+	const op = errors.Op("db.DeleteModelUnit")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ? AND name = ?", modelID, name).Delete(&dbmodel.ModelUnit{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpsertModelMachine stores the given machine, replacing any existing
+// record for the machine in the same model.
+func (d *Database) UpsertModelMachine(ctx context.Context, machine *dbmodel.ModelMachine) (err error) {
+	const op = errors.Op("db.UpsertModelMachine")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Omit("Model").Clauses(upsertModelMachine).Create(machine).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// DeleteModelMachine removes the machine with the given ID from the
+// model with the given ID.
+func (d *Database) DeleteModelMachine(ctx context.Context, modelID uint, machineID string) (err error) {
+	const op = errors.Op("db.DeleteModelMachine")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if err := db.Where("model_id = ? AND machine_id = ?", modelID, machineID).Delete(&dbmodel.ModelMachine{}).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// An InventoryUpdate holds a batch of changes to the inventory of one or
+// more models. Each application, unit and machine must appear at most
+// once in the update.
+type InventoryUpdate struct {
+	// Applications, Units and Machines hold the records to store,
+	// replacing any existing records for the same entities.
+	Applications []dbmodel.ModelApplication
+	Units        []dbmodel.ModelUnit
+	Machines     []dbmodel.ModelMachine
+
+	// RemovedApplications, RemovedUnits and RemovedMachines hold the
+	// records to remove. Only the ModelID and Name, or MachineID, of
+	// each record is used.
+	RemovedApplications []dbmodel.ModelApplication
+	RemovedUnits        []dbmodel.ModelUnit
+	RemovedMachines     []dbmodel.ModelMachine
+}
+
+// Empty reports whether the update has no changes.
+func (u *InventoryUpdate) Empty() bool {
+	return len(u.Applications)+len(u.Units)+len(u.Machines)+len(u.RemovedApplications)+len(u.RemovedUnits)+len(u.RemovedMachines) == 0
+}
+
+// UpdateModelInventory applies the given batch of inventory changes in
+// a single transaction.
+func (d *Database) UpdateModelInventory(ctx context.Context, u *InventoryUpdate) (err error) {
+	const op = errors.Op("db.UpdateModelInventory")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	remove := func(tx *gorm.DB, value any, column string, keys [][]any) error {
+		if len(keys) == 0 {
+			return nil
+		}
+		return tx.Where("(model_id, "+column+") IN ?", keys).Delete(value).Error
+	}
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(u.Applications) > 0 {
+			if err := tx.Omit("Model").Clauses(upsertModelApplication).Create(&u.Applications).Error; err != nil {
+				return err
+			}
+		}
+		if len(u.Units) > 0 {
+			if err := tx.Omit("Model").Clauses(upsertModelUnit).Create(&u.Units).Error; err != nil {
+				return err
+			}
+		}
+		if len(u.Machines) > 0 {
+			if err := tx.Omit("Model").Clauses(upsertModelMachine).Create(&u.Machines).Error; err != nil {
+				return err
+			}
+		}
+		keys := make([][]any, 0, len(u.RemovedApplications))
+		for _, app := range u.RemovedApplications {
+			keys = append(keys, []any{app.ModelID, app.Name})
+		}
+		if err := remove(tx, &dbmodel.ModelApplication{}, "name", keys); err != nil {
+			return err
+		}
+		keys = make([][]any, 0, len(u.RemovedUnits))
+		for _, unit := range u.RemovedUnits {
+			keys = append(keys, []any{unit.ModelID, unit.Name})
+		}
+		if err := remove(tx, &dbmodel.ModelUnit{}, "name", keys); err != nil {
+			return err
+		}
+		keys = make([][]any, 0, len(u.RemovedMachines))
+		for _, machine := range u.RemovedMachines {
+			keys = append(keys, []any{machine.ModelID, machine.MachineID})
+		}
+		return remove(tx, &dbmodel.ModelMachine{}, "machine_id", keys)
+	})
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// PruneModelInventory removes all the applications, units and machines
+// in the model with the given ID other than those named.
+func (d *Database) PruneModelInventory(ctx context.Context, modelID uint, applications, units, machines []string) (err error) {
+	const op = errors.Op("db.PruneModelInventory")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	prune := func(tx *gorm.DB, value any, column string, keep []string) error {
+		tx = tx.Where("model_id = ?", modelID)
+		if len(keep) > 0 {
+			tx = tx.Where(column+" NOT IN ?", keep)
+		}
+		return tx.Delete(value).Error
+	}
+	err = d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := prune(tx, &dbmodel.ModelApplication{}, "name", applications); err != nil {
+			return err
+		}
+		if err := prune(tx, &dbmodel.ModelUnit{}, "name", units); err != nil {
+			return err
+		}
+		return prune(tx, &dbmodel.ModelMachine{}, "machine_id", machines)
+	})
+	if err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// An InventoryFilter restricts the inventory records returned by the
+// Find methods.
+type InventoryFilter struct {
+	// ModelUUIDs, if not nil, matches only records in the models with
+	// the given UUIDs.
+	ModelUUIDs []string
+
+	// Limit is the maximum number of records to return. A value of
+	// zero will ignore the limit.
+	Limit int
+
+	// Offset is the number of records to skip.
+	Offset int
+}
+
+// apply adds the filter's conditions to the given query, which must be
+// a new session.
+func (f InventoryFilter) apply(db *gorm.DB) *gorm.DB {
+	q := db
+	if f.ModelUUIDs != nil {
+		q = q.Where("model_id IN (?)", db.Model(&dbmodel.Model{}).Select("id").Where("uuid IN ?", f.ModelUUIDs))
+	}
+	if f.Limit > 0 {
+		q = q.Limit(f.Limit)
+	}
+	if f.Offset > 0 {
+		q = q.Offset(f.Offset)
+	}
+	return q.Preload("Model").Order("model_id").Order("id")
+}
+
+// A ModelApplicationFilter defines a filter for application records.
+// Applications must match every non-empty field.
+type ModelApplicationFilter struct {
+	InventoryFilter
+
+	// Name matches applications with the given name.
+	Name string
+
+	// CharmName matches applications running the charm with the given
+	// name.
+	CharmName string
+
+	// CharmRevisionBelow, if non-zero, matches applications with a
+	// known charm revision less than this value. Applications whose
+	// charm URL has no revision are never matched.
+	CharmRevisionBelow int
+
+	// Status matches applications with the given status.
+	Status string
+}
+
+// FindModelApplications returns the applications matching the given
+// filter.
+func (d *Database) FindModelApplications(ctx context.Context, filter ModelApplicationFilter) (_ []dbmodel.ModelApplication, err error) {
+	const op = errors.Op("db.FindModelApplications")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	q := filter.apply(db)
+	if filter.Name != "" {
+		q = q.Where("name = ?", filter.Name)
+	}
+	if filter.CharmName != "" {
+		q = q.Where("charm_name = ?", filter.CharmName)
+	}
+	if filter.CharmRevisionBelow != 0 {
+		q = q.Where("charm_revision >= 0 AND charm_revision < ?", filter.CharmRevisionBelow)
+	}
+	if filter.Status != "" {
+		q = q.Where("status = ?", filter.Status)
+	}
+	var apps []dbmodel.ModelApplication
+	if err := q.Find(&apps).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return apps, nil
+}
+
+// A ModelUnitFilter defines a filter for unit records. Units must match
+// every non-empty field.
+type ModelUnitFilter struct {
+	InventoryFilter
+
+	// Application matches units of the application with the given
+	// name.
+	Application string
+
+	// MachineID matches units hosted on the machine with the given ID.
+	MachineID string
+
+	// WorkloadStatus matches units with the given workload status.
+	WorkloadStatus string
+
+	// AgentStatus matches units with the given agent status.
+	AgentStatus string
+}
+
+// FindModelUnits returns the units matching the given filter.
+func (d *Database) FindModelUnits(ctx context.Context, filter ModelUnitFilter) (_ []dbmodel.ModelUnit, err error) {
+	const op = errors.Op("db.FindModelUnits")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	q := filter.apply(db)
+	if filter.Application != "" {
+		q = q.Where("application = ?", filter.Application)
+	}
+	if filter.MachineID != "" {
+		q = q.Where("machine_id = ?", filter.MachineID)
+	}
+	if filter.WorkloadStatus != "" {
+		q = q.Where("workload_status = ?", filter.WorkloadStatus)
+	}
+	if filter.AgentStatus != "" {
+		q = q.Where("agent_status = ?", filter.AgentStatus)
+	}
+	var units []dbmodel.ModelUnit
+	if err := q.Find(&units).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return units, nil
+}
+
+// A ModelMachineFilter defines a filter for machine records. Machines
+// must match every non-empty field.
+type ModelMachineFilter struct {
+	InventoryFilter
+
+	// Base matches machines with the given OS base.
+	Base string
+
+	// InstanceID matches machines with the given instance ID.
+	InstanceID string
+
+	// AgentStatus matches machines with the given agent status.
+	AgentStatus string
+}
+
+// FindModelMachines returns the machines matching the given filter.
+func (d *Database) FindModelMachines(ctx context.Context, filter ModelMachineFilter) (_ []dbmodel.ModelMachine, err error) {
+	const op = errors.Op("db.FindModelMachines")
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	q := filter.apply(db)
+	if filter.Base != "" {
+		q = q.Where("base = ?", filter.Base)
+	}
+	if filter.InstanceID != "" {
+		q = q.Where("instance_id = ?", filter.InstanceID)
+	}
+	if filter.AgentStatus != "" {
+		q = q.Where("agent_status = ?", filter.AgentStatus)
+	}
+	var machines []dbmodel.ModelMachine
+	if err := q.Find(&machines).Error; err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	return machines, nil
+}
@@ -0,0 +1,281 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestUpsertModelApplicationUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.UpsertModelApplication(context.Background(), &dbmodel.ModelApplication{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+const testInventoryEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+- name: model-2
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+`
+
+func (s *dbSuite) TestModelInventory(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testInventoryEnv)
+	env.PopulateDB(c, *s.Database)
+	model1 := env.Model("alice@canonical.com", "model-1").DBObject(c, *s.Database)
+	model2 := env.Model("alice@canonical.com", "model-2").DBObject(c, *s.Database)
+
+	for _, app := range []dbmodel.ModelApplication{{
+		ModelID:       model1.ID,
+		Name:          "db",
+		CharmURL:      "ch:amd64/postgresql-42",
+		CharmName:     "postgresql",
+		CharmRevision: 42,
+		Status:        "active",
+	}, {
+		ModelID:       model1.ID,
+		Name:          "app",
+		CharmURL:      "ch:amd64/wordpress-7",
+		CharmName:     "wordpress",
+		CharmRevision: 7,
+		Status:        "blocked",
+	}, {
+		ModelID:       model2.ID,
+		Name:          "db",
+		CharmURL:      "ch:amd64/postgresql-50",
+		CharmName:     "postgresql",
+		CharmRevision: 50,
+		Status:        "active",
+	}} {
+		err = s.Database.UpsertModelApplication(ctx, &app)
+		c.Assert(err, qt.IsNil)
+	}
+
+	// Upserting an existing application updates it.
+	err = s.Database.UpsertModelApplication(ctx, &dbmodel.ModelApplication{
+		ModelID:       model1.ID,
+		Name:          "db",
+		CharmURL:      "ch:amd64/postgresql-45",
+		CharmName:     "postgresql",
+		CharmRevision: 45,
+		Status:        "active",
+	})
+	c.Assert(err, qt.IsNil)
+
+	apps, err := s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{CharmName: "postgresql"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 2)
+	c.Check(apps[0].Model.UUID, qt.Equals, model1.UUID.String)
+	c.Check(apps[0].CharmRevision, qt.Equals, 45)
+	c.Check(apps[1].Model.UUID, qt.Equals, model2.UUID.String)
+
+	// Applications with no known charm revision are never reported as
+	// having a revision below a value.
+	err = s.Database.UpsertModelApplication(ctx, &dbmodel.ModelApplication{
+		ModelID:       model1.ID,
+		Name:          "local-db",
+		CharmURL:      "local:postgresql",
+		CharmName:     "postgresql",
+		CharmRevision: -1,
+	})
+	c.Assert(err, qt.IsNil)
+
+	apps, err = s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{CharmName: "postgresql", CharmRevisionBelow: 50})
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 1)
+	c.Check(apps[0].Name, qt.Equals, "db")
+	c.Check(apps[0].ModelID, qt.Equals, model1.ID)
+
+	apps, err = s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{
+		InventoryFilter: db.InventoryFilter{ModelUUIDs: []string{model2.UUID.String}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 1)
+	c.Check(apps[0].ModelID, qt.Equals, model2.ID)
+
+	apps, err = s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{
+		InventoryFilter: db.InventoryFilter{ModelUUIDs: []string{}},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(apps, qt.HasLen, 0)
+
+	for _, unit := range []dbmodel.ModelUnit{{
+		ModelID:        model1.ID,
+		Name:           "db/0",
+		Application:    "db",
+		MachineID:      "0",
+		WorkloadStatus: "active",
+		AgentStatus:    "idle",
+	}, {
+		ModelID:        model1.ID,
+		Name:           "app/0",
+		Application:    "app",
+		MachineID:      "1",
+		WorkloadStatus: "blocked",
+		AgentStatus:    "idle",
+	}} {
+		err = s.Database.UpsertModelUnit(ctx, &unit)
+		c.Assert(err, qt.IsNil)
+	}
+	units, err := s.Database.FindModelUnits(ctx, db.ModelUnitFilter{WorkloadStatus: "blocked"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(units, qt.HasLen, 1)
+	c.Check(units[0].Name, qt.Equals, "app/0")
+
+	for _, machine := range []dbmodel.ModelMachine{{
+		ModelID:     model1.ID,
+		MachineID:   "0",
+		Base:        "ubuntu@22.04",
+		AgentStatus: "started",
+	}, {
+		ModelID:     model1.ID,
+		MachineID:   "1",
+		Base:        "ubuntu@20.04",
+		AgentStatus: "started",
+	}} {
+		err = s.Database.UpsertModelMachine(ctx, &machine)
+		c.Assert(err, qt.IsNil)
+	}
+	machines, err := s.Database.FindModelMachines(ctx, db.ModelMachineFilter{Base: "ubuntu@20.04"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(machines, qt.HasLen, 1)
+	c.Check(machines[0].MachineID, qt.Equals, "1")
+
+	err = s.Database.DeleteModelUnit(ctx, model1.ID, "app/0")
+	c.Assert(err, qt.IsNil)
+	units, err = s.Database.FindModelUnits(ctx, db.ModelUnitFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(units, qt.HasLen, 1)
+	c.Check(units[0].Name, qt.Equals, "db/0")
+
+	err = s.Database.PruneModelInventory(ctx, model1.ID, []string{"db"}, nil, []string{"0"})
+	c.Assert(err, qt.IsNil)
+	apps, err = s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 2)
+	c.Check(apps[0].ModelID, qt.Equals, model1.ID)
+	c.Check(apps[0].Name, qt.Equals, "db")
+	c.Check(apps[1].ModelID, qt.Equals, model2.ID)
+	units, err = s.Database.FindModelUnits(ctx, db.ModelUnitFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Check(units, qt.HasLen, 0)
+	machines, err = s.Database.FindModelMachines(ctx, db.ModelMachineFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(machines, qt.HasLen, 1)
+	c.Check(machines[0].MachineID, qt.Equals, "0")
+}
+
+func (s *dbSuite) TestUpdateModelInventory(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testInventoryEnv)
+	env.PopulateDB(c, *s.Database)
+	model1 := env.Model("alice@canonical.com", "model-1").DBObject(c, *s.Database)
+	model2 := env.Model("alice@canonical.com", "model-2").DBObject(c, *s.Database)
+
+	err = s.Database.UpdateModelInventory(ctx, &db.InventoryUpdate{
+		Applications: []dbmodel.ModelApplication{{
+			ModelID:       model1.ID,
+			Name:          "db",
+			CharmName:     "postgresql",
+			CharmRevision: 42,
+		}, {
+			ModelID:       model2.ID,
+			Name:          "db",
+			CharmName:     "postgresql",
+			CharmRevision: 50,
+		}},
+		Units: []dbmodel.ModelUnit{{
+			ModelID:     model1.ID,
+			Name:        "db/0",
+			Application: "db",
+			MachineID:   "0",
+		}},
+		Machines: []dbmodel.ModelMachine{{
+			ModelID:   model1.ID,
+			MachineID: "0",
+		}, {
+			ModelID:   model2.ID,
+			MachineID: "0",
+		}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.UpdateModelInventory(ctx, &db.InventoryUpdate{
+		Applications: []dbmodel.ModelApplication{{
+			ModelID:       model1.ID,
+			Name:          "db",
+			CharmName:     "postgresql",
+			CharmRevision: 45,
+		}},
+		RemovedApplications: []dbmodel.ModelApplication{{
+			ModelID: model2.ID,
+			Name:    "db",
+		}},
+		RemovedUnits: []dbmodel.ModelUnit{{
+			ModelID: model1.ID,
+			Name:    "db/0",
+		}},
+		RemovedMachines: []dbmodel.ModelMachine{{
+			ModelID:   model2.ID,
+			MachineID: "0",
+		}},
+	})
+	c.Assert(err, qt.IsNil)
+
+	apps, err := s.Database.FindModelApplications(ctx, db.ModelApplicationFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(apps, qt.HasLen, 1)
+	c.Check(apps[0].ModelID, qt.Equals, model1.ID)
+	c.Check(apps[0].CharmRevision, qt.Equals, 45)
+	units, err := s.Database.FindModelUnits(ctx, db.ModelUnitFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Check(units, qt.HasLen, 0)
+	machines, err := s.Database.FindModelMachines(ctx, db.ModelMachineFilter{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(machines, qt.HasLen, 1)
+	c.Check(machines[0].ModelID, qt.Equals, model1.ID)
+}
@@ -0,0 +1,105 @@
+// Copyright 2024 Canonical.
+
+package db
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// AddModelMigration stores the given model migration record.
+func (d *Database) AddModelMigration(ctx context.Context, migration *dbmodel.ModelMigration) (err error) {
+	const op = errors.Op("db.AddModelMigration")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Omit("Model", "SourceController", "TargetController").Create(migration).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// UpdateModelMigration updates the stored model migration record with
+// the values in the given record.
+func (d *Database) UpdateModelMigration(ctx context.Context, migration *dbmodel.ModelMigration) (err error) {
+	const op = errors.Op("db.UpdateModelMigration")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	if err := d.DB.WithContext(ctx).Omit("Model", "SourceController", "TargetController").Save(migration).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	return nil
+}
+
+// A ModelMigrationFilter defines a filter for model migration records.
+type ModelMigrationFilter struct {
+	// ID, if non-zero, matches only the migration record with the given
+	// ID.
+	ID uint
+
+	// ModelID, if non-zero, matches only migrations of the model with
+	// the given ID.
+	ModelID uint
+
+	// Active, if true, matches only migrations that have not finished.
+	Active bool
+
+	// Limit is the maximum number of migrations to return. A value of
+	// zero will ignore the limit.
+	Limit int
+}
+
+// ForEachModelMigration iterates through all model migrations that match
+// the given filter, most recently started first, calling f for each
+// migration. If f returns an error iteration stops immediately and the
+// error is returned unmodified.
+func (d *Database) ForEachModelMigration(ctx context.Context, filter ModelMigrationFilter, f func(*dbmodel.ModelMigration) error) (err error) {
+	const op = errors.Op("db.ForEachModelMigration")
+	if err := d.ready(); err != nil {
+		return errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	db := d.DB.WithContext(ctx)
+	if filter.ID != 0 {
+		db = db.Where("id = ?", filter.ID)
+	}
+	if filter.ModelID != 0 {
+		db = db.Where("model_id = ?", filter.ModelID)
+	}
+	if filter.Active {
+		db = db.Where("finished_at IS NULL")
+	}
+	if filter.Limit > 0 {
+		db = db.Limit(filter.Limit)
+	}
+	db = db.Preload("Model").Preload("SourceController").Preload("TargetController")
+
+	var migrations []dbmodel.ModelMigration
+	if err := db.Order("started_at DESC").Order("id DESC").Find(&migrations).Error; err != nil {
+		return errors.E(op, dbError(err))
+	}
+	for i := range migrations {
+		if err := f(&migrations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestAddModelMigrationUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	err := d.AddModelMigration(context.Background(), &dbmodel.ModelMigration{})
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+const testModelMigrationEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+`
+
+func (s *dbSuite) TestModelMigrations(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testModelMigrationEnv)
+	env.PopulateDB(c, *s.Database)
+	model := env.Model("alice@canonical.com", "model-1").DBObject(c, *s.Database)
+	ctl1 := env.Controller("controller-1").DBObject(c, *s.Database)
+	ctl2 := env.Controller("controller-2").DBObject(c, *s.Database)
+
+	first := dbmodel.ModelMigration{
+		ModelID:            model.ID,
+		MigrationID:        "00000002-0000-0000-0000-000000000001:0",
+		SourceControllerID: ctl1.ID,
+		TargetControllerID: ctl2.ID,
+		InitiatedBy:        "alice@canonical.com",
+		Phase:              dbmodel.MigrationPhaseRunning,
+		StartedAt:          time.Now().Add(-time.Hour).UTC().Round(time.Millisecond),
+	}
+	err = s.Database.AddModelMigration(ctx, &first)
+	c.Assert(err, qt.IsNil)
+
+	first.Phase = dbmodel.MigrationPhaseAborted
+	first.Error = "aborted"
+	first.FinishedAt = sql.NullTime{Time: time.Now().UTC().Round(time.Millisecond), Valid: true}
+	err = s.Database.UpdateModelMigration(ctx, &first)
+	c.Assert(err, qt.IsNil)
+
+	second := dbmodel.ModelMigration{
+		ModelID:            model.ID,
+		MigrationID:        "00000002-0000-0000-0000-000000000001:1",
+		SourceControllerID: ctl1.ID,
+		TargetControllerID: ctl2.ID,
+		InitiatedBy:        "alice@canonical.com",
+		Phase:              dbmodel.MigrationPhaseRunning,
+		StartedAt:          time.Now().UTC().Round(time.Millisecond),
+	}
+	err = s.Database.AddModelMigration(ctx, &second)
+	c.Assert(err, qt.IsNil)
+
+	var ids []uint
+	err = s.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model.ID}, func(m *dbmodel.ModelMigration) error {
+		c.Check(m.Model.Name, qt.Equals, "model-1")
+		c.Check(m.SourceController.Name, qt.Equals, "controller-1")
+		c.Check(m.TargetController.Name, qt.Equals, "controller-2")
+		ids = append(ids, m.ID)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []uint{second.ID, first.ID})
+
+	ids = nil
+	err = s.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{Active: true}, func(m *dbmodel.ModelMigration) error {
+		ids = append(ids, m.ID)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []uint{second.ID})
+
+	ids = nil
+	err = s.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ID: first.ID}, func(m *dbmodel.ModelMigration) error {
+		ids = append(ids, m.ID)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(ids, qt.DeepEquals, []uint{first.ID})
+
+	testError := errors.E("test error")
+	err = s.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{}, func(*dbmodel.ModelMigration) error {
+		return testError
+	})
+	c.Check(err, qt.Equals, testError)
+}
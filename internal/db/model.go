@@ -248,3 +248,79 @@ func (d *Database) CountModelsByController(ctx context.Context, ctl dbmodel.Cont
 	}
 	return int(count), nil
 }
+
+// A ControllerLoad contains the aggregate load on a controller.
+type ControllerLoad struct {
+	// Models is the number of models hosted on the controller.
+	Models int
+
+	// Units is the total number of units in those models.
+	Units int64
+
+	// Machines is the total number of machines in those models.
+	Machines int64
+}
+
+// ControllerLoads determines the load on each of the controllers with the
+// given IDs using a single aggregate query. Controllers hosting no models
+// are not included in the returned map.
+func (d *Database) ControllerLoads(ctx context.Context, controllerIDs []uint) (_ map[uint]ControllerLoad, err error) {
+	const op = errors.Op("db.ControllerLoads")
+
+	if err := d.ready(); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	loads := make(map[uint]ControllerLoad, len(controllerIDs))
+	if len(controllerIDs) == 0 {
+		return loads, nil
+	}
+	var rows []struct {
+		ControllerID uint
+		Models       int
+		Units        int64
+		Machines     int64
+	}
+	db := d.DB.WithContext(ctx)
+	err = db.Model(&dbmodel.Model{}).
+		Select("controller_id, COUNT(*) AS models, COALESCE(SUM(units), 0) AS units, COALESCE(SUM(machines), 0) AS machines").
+		Where("controller_id IN ?", controllerIDs).
+		Group("controller_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, errors.E(op, dbError(err))
+	}
+	for _, r := range rows {
+		loads[r.ControllerID] = ControllerLoad{
+			Models:   r.Models,
+			Units:    r.Units,
+			Machines: r.Machines,
+		}
+	}
+	return loads, nil
+}
+
+// CountModelsByOwner counts the number of models owned by the given
+// identity.
+func (d *Database) CountModelsByOwner(ctx context.Context, owner *dbmodel.Identity) (_ int, err error) {
+	const op = errors.Op("db.CountModelsByOwner")
+
+	if err := d.ready(); err != nil {
+		return 0, errors.E(op, err)
+	}
+
+	durationObserver := servermon.DurationObserver(servermon.DBQueryDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.DBQueryErrorCount, &err, string(op))
+
+	var count int64
+	db := d.DB.WithContext(ctx)
+	if err := db.Model(&dbmodel.Model{}).Where("owner_identity_name = ?", owner.Name).Count(&count).Error; err != nil {
+		return 0, errors.E(op, dbError(err))
+	}
+	return int(count), nil
+}
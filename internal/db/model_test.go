@@ -788,3 +788,37 @@ func (s *dbSuite) TestCountModelsByController(c *qt.C) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(count, qt.Equals, 3)
 }
+
+func (s *dbSuite) TestControllerLoads(c *qt.C) {
+	ctx := context.Background()
+	err := s.Database.Migrate(ctx, true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testCountModelsByControllerEnv)
+	env.PopulateDB(c, *s.Database)
+	ctl := env.Controllers[0].DBObject(c, *s.Database)
+
+	err = s.Database.DB.Model(&dbmodel.Model{}).Where("controller_id = ?", ctl.ID).Updates(map[string]interface{}{"units": 2, "machines": 1}).Error
+	c.Assert(err, qt.IsNil)
+
+	loads, err := s.Database.ControllerLoads(ctx, []uint{ctl.ID, ctl.ID + 1})
+	c.Assert(err, qt.IsNil)
+	c.Check(loads, qt.DeepEquals, map[uint]db.ControllerLoad{
+		ctl.ID: {Models: 3, Units: 6, Machines: 3},
+	})
+}
+
+func (s *dbSuite) TestCountModelsByOwner(c *qt.C) {
+	err := s.Database.Migrate(context.Background(), true)
+	c.Assert(err, qt.Equals, nil)
+
+	env := jimmtest.ParseEnvironment(c, testCountModelsByControllerEnv)
+	env.PopulateDB(c, *s.Database)
+	count, err := s.Database.CountModelsByOwner(context.Background(), &dbmodel.Identity{Name: "bob@canonical.com"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 2)
+
+	count, err = s.Database.CountModelsByOwner(context.Background(), &dbmodel.Identity{Name: "charlie@canonical.com"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 0)
+}
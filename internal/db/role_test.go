@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical.
+
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+func TestAddRoleUnconfiguredDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	var d db.Database
+	_, err := d.AddRole(context.Background(), "test-role")
+	c.Check(err, qt.ErrorMatches, `database not configured`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeServerConfiguration)
+}
+
+func (s *dbSuite) TestAddRole(c *qt.C) {
+	ctx := context.Background()
+
+	uuid := uuid.NewString()
+	c.Patch(db.NewUUID, func() string {
+		return uuid
+	})
+
+	_, err := s.Database.AddRole(ctx, "test-role")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	roleEntry, err := s.Database.AddRole(ctx, "test-role")
+	c.Assert(err, qt.IsNil)
+	c.Assert(roleEntry.UUID, qt.Equals, uuid)
+
+	_, err = s.Database.AddRole(ctx, "test-role")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	re := dbmodel.RoleEntry{
+		Name: "test-role",
+	}
+	err = s.Database.GetRole(ctx, &re)
+	c.Assert(err, qt.IsNil)
+	c.Assert(re.ID, qt.Equals, uint(1))
+	c.Assert(re.UUID, qt.Equals, uuid)
+}
+
+func (s *dbSuite) TestCountRoles(c *qt.C) {
+	err := s.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+
+	addNRoles := 10
+	for i := range addNRoles {
+		_, err := s.Database.AddRole(context.Background(), fmt.Sprintf("test-role-%d", i))
+		c.Assert(err, qt.IsNil)
+	}
+	count, err := s.Database.CountRoles(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, addNRoles)
+}
+
+func (s *dbSuite) TestGetRole(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.GetRole(ctx, &dbmodel.RoleEntry{Name: "test-role"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUpgradeInProgress)
+
+	err = s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.GetRole(ctx, &dbmodel.RoleEntry{Name: "test-role"})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	added, err := s.Database.AddRole(ctx, "test-role")
+	c.Assert(err, qt.IsNil)
+
+	byUUID := dbmodel.RoleEntry{UUID: added.UUID}
+	err = s.Database.GetRole(ctx, &byUUID)
+	c.Assert(err, qt.IsNil)
+	c.Check(byUUID.Name, qt.Equals, "test-role")
+}
+
+func (s *dbSuite) TestForEachRole(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	for i := range 10 {
+		_, err := s.Database.AddRole(ctx, fmt.Sprintf("test-role-%d", i))
+		c.Assert(err, qt.IsNil)
+	}
+	var names []string
+	err = s.Database.ForEachRole(ctx, 5, 5, func(re *dbmodel.RoleEntry) error {
+		names = append(names, re.Name)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(names, qt.DeepEquals, []string{"test-role-5", "test-role-6", "test-role-7", "test-role-8", "test-role-9"})
+}
+
+func (s *dbSuite) TestUpdateAndRemoveRole(c *qt.C) {
+	ctx := context.Background()
+
+	err := s.Database.UpdateRole(ctx, &dbmodel.RoleEntry{})
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	err = s.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	re, err := s.Database.AddRole(ctx, "test-role")
+	c.Assert(err, qt.IsNil)
+
+	re.Name = "renamed-role"
+	err = s.Database.UpdateRole(ctx, re)
+	c.Assert(err, qt.IsNil)
+
+	re2 := dbmodel.RoleEntry{UUID: re.UUID}
+	err = s.Database.GetRole(ctx, &re2)
+	c.Assert(err, qt.IsNil)
+	c.Check(re2.Name, qt.Equals, "renamed-role")
+
+	err = s.Database.RemoveRole(ctx, re)
+	c.Assert(err, qt.IsNil)
+
+	err = s.Database.GetRole(ctx, &re2)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+}
@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/juju/names/v5"
+
 	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
 )
 
@@ -87,3 +89,44 @@ func (e AuditLogEntry) ToAPIAuditEvent() apiparams.AuditEvent {
 	}
 	return ale
 }
+
+// A CorrelatedAuditLogEntry is a request entry in the audit log together
+// with its response entry.
+type CorrelatedAuditLogEntry struct {
+	// Request is the audit log entry of the request.
+	Request AuditLogEntry
+
+	// Response is the audit log entry of the response to the request,
+	// this is nil if no response has been recorded.
+	Response *AuditLogEntry
+}
+
+// Latency returns the time taken to respond to the request. If there is
+// no response the returned value is 0.
+func (e CorrelatedAuditLogEntry) Latency() time.Duration {
+	if e.Response == nil {
+		return 0
+	}
+	return e.Response.Time.Sub(e.Request.Time)
+}
+
+// ToAPIAuditEvent converts a CorrelatedAuditLogEntry to a JIMM API
+// AuditEvent containing the parameters of the request and the errors,
+// time and latency of the response.
+func (e CorrelatedAuditLogEntry) ToAPIAuditEvent() apiparams.AuditEvent {
+	ale := e.Request.ToAPIAuditEvent()
+	if e.Response == nil {
+		return ale
+	}
+	resp := e.Response.ToAPIAuditEvent()
+	if ale.UserTag == "" || ale.UserTag == (names.UserTag{}).String() {
+		// Requests made before logging in are recorded without a
+		// user, the response holds the authenticated user.
+		ale.UserTag = resp.UserTag
+	}
+	ale.Errors = resp.Errors
+	responseTime := e.Response.Time
+	ale.ResponseTime = &responseTime
+	ale.Latency = e.Latency().String()
+	return ale
+}
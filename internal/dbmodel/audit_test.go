@@ -39,6 +39,10 @@ func TestAuditLogEntry(t *testing.T) {
 
 	var ale2 dbmodel.AuditLogEntry
 	c.Assert(db.First(&ale2).Error, qt.IsNil)
+	// Params are stored as JSONB so the formatting of the returned
+	// document may differ from the original.
+	c.Check([]byte(ale2.Params), qt.JSONEquals, params)
+	ale2.Params = ale.Params
 	c.Check(ale2, qt.DeepEquals, ale)
 }
 
@@ -89,3 +93,62 @@ func TestToAPIAuditEvent(t *testing.T) {
 	expectedEvent.Errors = map[string]any{}
 	c.Check(event, qt.DeepEquals, expectedEvent)
 }
+
+func TestCorrelatedAuditLogEntryToAPIAuditEvent(t *testing.T) {
+	c := qt.New(t)
+
+	now := time.Now().Truncate(time.Second)
+	request := dbmodel.AuditLogEntry{
+		Time:           now,
+		ConversationId: "1234",
+		MessageId:      1,
+		FacadeName:     "Admin",
+		FacadeMethod:   "LoginWithSessionToken",
+		FacadeVersion:  4,
+		IdentityTag:    names.UserTag{}.String(),
+		Params:         dbmodel.JSON(`{"session-token":"redacted"}`),
+	}
+	entry := dbmodel.CorrelatedAuditLogEntry{
+		Request: request,
+	}
+	c.Check(entry.Latency(), qt.Equals, time.Duration(0))
+	c.Check(entry.ToAPIAuditEvent(), qt.DeepEquals, request.ToAPIAuditEvent())
+
+	response := dbmodel.AuditLogEntry{
+		Time:           now.Add(1500 * time.Millisecond),
+		ConversationId: "1234",
+		MessageId:      1,
+		FacadeName:     "Admin",
+		FacadeMethod:   "LoginWithSessionToken",
+		FacadeVersion:  4,
+		IdentityTag:    names.NewUserTag("bob@canonical.com").String(),
+		IsResponse:     true,
+		Errors:         dbmodel.JSON(`{"results":[{"error":{"message":"unauthorized","code":"unauthorized access"}}]}`),
+	}
+	entry.Response = &response
+	c.Check(entry.Latency(), qt.Equals, 1500*time.Millisecond)
+
+	responseTime := response.Time
+	c.Check(entry.ToAPIAuditEvent(), qt.DeepEquals, apiparams.AuditEvent{
+		Time:           now,
+		ConversationId: "1234",
+		MessageId:      1,
+		FacadeName:     "Admin",
+		FacadeMethod:   "LoginWithSessionToken",
+		FacadeVersion:  4,
+		UserTag:        names.NewUserTag("bob@canonical.com").String(),
+		Params:         map[string]any{"session-token": "redacted"},
+		Errors: map[string]any{
+			"results": []any{
+				map[string]any{
+					"error": map[string]any{
+						"message": "unauthorized",
+						"code":    "unauthorized access",
+					},
+				},
+			},
+		},
+		ResponseTime: &responseTime,
+		Latency:      "1.5s",
+	})
+}
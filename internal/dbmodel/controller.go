@@ -66,6 +66,10 @@ type Controller struct {
 	// therefore no new models or clouds will be added to the controller.
 	Deprecated bool `gorm:"not null;default:FALSE"`
 
+	// MaxModels is the maximum number of models that JIMM will place on
+	// this controller. A value of 0 means there is no limit.
+	MaxModels uint `gorm:"not null;default:0"`
+
 	// AgentVersion holds the string representation of the controller's
 	// agent version.
 	AgentVersion string
@@ -85,6 +89,10 @@ type Controller struct {
 	// Models contains all the models that are running on this controller.
 	Models []Model
 
+	// Health holds the most recently observed health of the
+	// controller, if it has been recorded.
+	Health *ControllerHealth
+
 	// TODO(mhilton) Save controller statistics?
 }
 
@@ -124,12 +132,22 @@ func (c Controller) ToAPIControllerInfo() apiparams.ControllerInfo {
 	ci.CloudRegion = c.CloudRegion
 	ci.Username = c.AdminIdentityName
 	ci.AgentVersion = c.AgentVersion
+	if c.Health != nil {
+		health := c.Health.ToAPIControllerHealth()
+		ci.Health = &health
+	}
 	switch {
 	case c.UnavailableSince.Valid:
 		ci.Status = jujuparams.EntityStatus{
 			Status: "unavailable",
 			Since:  &c.UnavailableSince.Time,
 		}
+	case c.Health != nil && c.Health.DegradedSince.Valid:
+		ci.Status = jujuparams.EntityStatus{
+			Status: "degraded",
+			Info:   c.Health.LastError,
+			Since:  &c.Health.DegradedSince.Time,
+		}
 	case c.Deprecated:
 		ci.Status = jujuparams.EntityStatus{
 			Status: "deprecated",
@@ -5,6 +5,7 @@ package dbmodel_test
 import (
 	"database/sql"
 	"testing"
+	"time"
 
 	qt "github.com/frankban/quicktest"
 	jujuparams "github.com/juju/juju/rpc/params"
@@ -190,6 +191,49 @@ func TestToAPIControllerInfo(t *testing.T) {
 	})
 }
 
+func TestToAPIControllerInfoDegraded(t *testing.T) {
+	c := qt.New(t)
+
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	updated := since.Add(time.Minute)
+	ctl := dbmodel.Controller{
+		Name:      "test-controller",
+		UUID:      "00000000-0000-0000-0000-0000-0000000000001",
+		CloudName: "test-cloud",
+		Health: &dbmodel.ControllerHealth{
+			UpdatedAt:     updated,
+			WatcherState:  dbmodel.WatcherStateWatching,
+			AgentVersion:  "3.5.0",
+			DialLatency:   250 * time.Millisecond,
+			ErrorStreak:   2,
+			DegradedSince: sql.NullTime{Time: since, Valid: true},
+			LastError:     "ping timeout",
+		},
+	}
+
+	ci := ctl.ToAPIControllerInfo()
+	c.Check(ci.Status, qt.DeepEquals, jujuparams.EntityStatus{
+		Status: "degraded",
+		Info:   "ping timeout",
+		Since:  &since,
+	})
+	c.Check(ci.Health, qt.DeepEquals, &apiparams.ControllerHealth{
+		WatcherState:  dbmodel.WatcherStateWatching,
+		AgentVersion:  "3.5.0",
+		DialLatencyMS: 250,
+		ErrorStreak:   2,
+		DegradedSince: &since,
+		LastError:     "ping timeout",
+		UpdatedAt:     updated,
+	})
+
+	// A controller that cannot be reached is reported as unavailable
+	// rather than degraded.
+	ctl.UnavailableSince = sql.NullTime{Time: updated, Valid: true}
+	ci = ctl.ToAPIControllerInfo()
+	c.Check(string(ci.Status.Status), qt.Equals, "unavailable")
+}
+
 func TestToJujuRedirectInfoResult(t *testing.T) {
 	c := qt.New(t)
 	db := gormDB(c)
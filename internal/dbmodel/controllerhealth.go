@@ -0,0 +1,137 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const (
+	// WatcherStateConnecting is the state of a controller watcher that
+	// is connecting to its controller.
+	WatcherStateConnecting = "connecting"
+
+	// WatcherStateWatching is the state of a controller watcher that is
+	// receiving changes from its controller.
+	WatcherStateWatching = "watching"
+
+	// WatcherStateStopped is the state of a controller watcher that has
+	// stopped, it will be restarted the next time JIMM polls for
+	// controllers.
+	WatcherStateStopped = "stopped"
+)
+
+// ControllerHealth holds the most recently observed health of a
+// controller. It is maintained by the controller watcher.
+type ControllerHealth struct {
+	// ControllerID is the ID of the controller.
+	ControllerID uint `gorm:"primarykey;autoIncrement:false"`
+
+	UpdatedAt time.Time
+
+	// WatcherState is the state of JIMM's watcher for the controller.
+	WatcherState string
+
+	// AgentVersion is the agent version reported by the controller the
+	// last time JIMM connected to it.
+	AgentVersion string
+
+	// DialLatency is the time taken to make the most recent successful
+	// connection to the controller.
+	DialLatency time.Duration
+
+	// LastDial is the time of the most recent successful connection to
+	// the controller.
+	LastDial sql.NullTime
+
+	// PingLatency is the round-trip time of the most recent successful
+	// ping.
+	PingLatency time.Duration
+
+	// LastPing is the time of the most recent successful ping.
+	LastPing sql.NullTime
+
+	// ErrorStreak is the number of consecutive failed connections,
+	// pings or watcher errors.
+	ErrorStreak int
+
+	// DegradedSince is the time of the first failure in the current
+	// error streak.
+	DegradedSince sql.NullTime
+
+	// LastError is the most recent error.
+	LastError string
+}
+
+// TableName overrides the table name gorm will use to find
+// ControllerHealth records.
+func (ControllerHealth) TableName() string {
+	return "controller_health"
+}
+
+// Healthy reports whether the controller is considered healthy, that is
+// it is being watched and the most recent contact succeeded.
+func (h ControllerHealth) Healthy() bool {
+	return h.WatcherState == WatcherStateWatching && h.ErrorStreak == 0
+}
+
+// ToAPIControllerHealth converts a controller health entry to a JIMM API
+// ControllerHealth.
+func (h ControllerHealth) ToAPIControllerHealth() apiparams.ControllerHealth {
+	var ch apiparams.ControllerHealth
+	ch.Healthy = h.Healthy()
+	ch.WatcherState = h.WatcherState
+	ch.AgentVersion = h.AgentVersion
+	ch.DialLatencyMS = h.DialLatency.Milliseconds()
+	if h.LastDial.Valid {
+		lastDial := h.LastDial.Time
+		ch.LastDial = &lastDial
+	}
+	ch.PingLatencyMS = h.PingLatency.Milliseconds()
+	if h.LastPing.Valid {
+		lastPing := h.LastPing.Time
+		ch.LastPing = &lastPing
+	}
+	ch.ErrorStreak = h.ErrorStreak
+	if h.DegradedSince.Valid {
+		degradedSince := h.DegradedSince.Time
+		ch.DegradedSince = &degradedSince
+	}
+	ch.LastError = h.LastError
+	ch.UpdatedAt = h.UpdatedAt
+	return ch
+}
+
+// A ControllerHealthEvent records a change in the health of a controller.
+type ControllerHealthEvent struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+
+	// ControllerID is the ID of the controller.
+	ControllerID uint
+
+	// WatcherState is the state of JIMM's watcher for the controller
+	// after the change.
+	WatcherState string
+
+	// Healthy records whether the controller was healthy after the
+	// change.
+	Healthy bool
+
+	// Error is the error that caused the change, if any.
+	Error string
+}
+
+// ToAPIControllerHealthEvent converts a controller health event to a JIMM
+// API ControllerHealthEvent.
+func (e ControllerHealthEvent) ToAPIControllerHealthEvent() apiparams.ControllerHealthEvent {
+	return apiparams.ControllerHealthEvent{
+		Time:         e.CreatedAt,
+		WatcherState: e.WatcherState,
+		Healthy:      e.Healthy,
+		Error:        e.Error,
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// DrainStateRunning is the state of a drain that is migrating models.
+	DrainStateRunning = "running"
+
+	// DrainStatePaused is the state of a drain that will not start any
+	// new model migrations until it is resumed.
+	DrainStatePaused = "paused"
+
+	// DrainStateAborted is the state of a drain that has been aborted.
+	DrainStateAborted = "aborted"
+
+	// DrainStateDone is the state of a drain that has attempted to
+	// migrate all of its models.
+	DrainStateDone = "done"
+)
+
+const (
+	// DrainModelPending is the status of a model that is waiting to be
+	// migrated.
+	DrainModelPending = "pending"
+
+	// DrainModelMigrating is the status of a model that is being
+	// migrated.
+	DrainModelMigrating = "migrating"
+
+	// DrainModelDone is the status of a model that has been migrated.
+	DrainModelDone = "done"
+
+	// DrainModelFailed is the status of a model whose migration failed.
+	DrainModelFailed = "failed"
+
+	// DrainModelSkipped is the status of a model that will not be
+	// migrated.
+	DrainModelSkipped = "skipped"
+)
+
+// A ControllerDrain is a record of migrating every model off a
+// controller.
+type ControllerDrain struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Controller is the controller being drained.
+	ControllerID uint
+	Controller   Controller `gorm:"constraint:OnDelete:CASCADE"`
+
+	// State is the state of the drain, see the DrainState constants. A
+	// controller can only have one drain that is running or paused.
+	State string
+
+	// Concurrency is the maximum number of models that are migrating at
+	// any one time.
+	Concurrency int
+
+	// InitiatedBy is the name of the identity that started the drain.
+	// Model migrations are initiated as this identity.
+	InitiatedBy string
+
+	// Models holds the progress of each model on the controller.
+	Models []ControllerDrainModel `gorm:"foreignKey:DrainID"`
+}
+
+// TableName overrides the table name gorm will use to find
+// ControllerDrain records.
+func (ControllerDrain) TableName() string {
+	return "controller_drains"
+}
+
+// A ControllerDrainModel records the progress of migrating a single model
+// as part of a controller drain.
+type ControllerDrainModel struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// DrainID is the ID of the drain the model belongs to.
+	DrainID uint
+
+	// ModelUUID is the UUID of the model.
+	ModelUUID string
+
+	// ModelName is the name of the model.
+	ModelName string
+
+	// TargetController is the name of the controller the model is
+	// migrated to.
+	TargetController string
+
+	// Status is the status of the model's migration, see the DrainModel
+	// constants.
+	Status string
+
+	// Error holds the reason a model was skipped or failed.
+	Error string
+
+	// MigrationID is the ID of the model migration record created when
+	// the drain initiated the model's migration.
+	MigrationID sql.NullInt64
+}
+
+// TableName overrides the table name gorm will use to find
+// ControllerDrainModel records.
+func (ControllerDrainModel) TableName() string {
+	return "controller_drain_models"
+}
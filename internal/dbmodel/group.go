@@ -23,6 +23,11 @@ type GroupEntry struct {
 
 	// UUID holds the uuid of the group.
 	UUID string `gotm:"index;column:uuid"`
+
+	// ExternallyManaged is true if the membership of the group is
+	// managed by the identity provider. Such groups are created, and
+	// their members updated, when identities log in.
+	ExternallyManaged bool
 }
 
 // ToAPIGroup converts a group entry to a JIMM API
@@ -33,6 +38,7 @@ func (g GroupEntry) ToAPIGroupEntry() apiparams.Group {
 	group.Name = g.Name
 	group.CreatedAt = g.CreatedAt.Format(time.RFC3339)
 	group.UpdatedAt = g.UpdatedAt.Format(time.RFC3339)
+	group.ExternallyManaged = g.ExternallyManaged
 	return group
 }
 
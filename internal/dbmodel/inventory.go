@@ -0,0 +1,202 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// A ModelApplication is an application deployed in a model, as most
+// recently reported by the model's controller.
+type ModelApplication struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Model is the model the application is deployed in.
+	ModelID uint
+	Model   Model `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Name is the name of the application.
+	Name string
+
+	// CharmURL is the URL of the application's charm.
+	CharmURL string
+
+	// CharmName is the name of the application's charm, taken from the
+	// charm URL.
+	CharmName string
+
+	// CharmRevision is the revision of the application's charm, taken
+	// from the charm URL. It is -1 if the URL has no revision.
+	CharmRevision int
+
+	// Life is the life of the application.
+	Life string
+
+	// Status is the current status of the application.
+	Status string
+
+	// WorkloadVersion is the version of the application's workload.
+	WorkloadVersion string
+
+	// Exposed is whether the application is exposed.
+	Exposed bool
+
+	// Subordinate is whether the application is a subordinate.
+	Subordinate bool
+}
+
+// TableName overrides the table name gorm will use to find
+// ModelApplication records.
+func (ModelApplication) TableName() string {
+	return "model_applications"
+}
+
+// ToAPIApplicationInfo converts a ModelApplication to a JIMM API
+// ApplicationInfo.
+func (a ModelApplication) ToAPIApplicationInfo() apiparams.ApplicationInfo {
+	return apiparams.ApplicationInfo{
+		ModelTag:        a.Model.ResourceTag().String(),
+		ModelName:       a.Model.Name,
+		Name:            a.Name,
+		CharmURL:        a.CharmURL,
+		CharmName:       a.CharmName,
+		CharmRevision:   a.CharmRevision,
+		Life:            a.Life,
+		Status:          a.Status,
+		WorkloadVersion: a.WorkloadVersion,
+		Exposed:         a.Exposed,
+		Subordinate:     a.Subordinate,
+		UpdatedAt:       a.UpdatedAt,
+	}
+}
+
+// A ModelUnit is a unit in a model, as most recently reported by the
+// model's controller.
+type ModelUnit struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Model is the model the unit is in.
+	ModelID uint
+	Model   Model `gorm:"constraint:OnDelete:CASCADE"`
+
+	// Name is the name of the unit.
+	Name string
+
+	// Application is the name of the unit's application.
+	Application string
+
+	// CharmURL is the URL of the charm the unit is running.
+	CharmURL string
+
+	// MachineID is the ID of the machine hosting the unit, if any.
+	MachineID string
+
+	// Principal is the name of the unit's principal unit, if this is a
+	// subordinate unit.
+	Principal string
+
+	// Life is the life of the unit.
+	Life string
+
+	// WorkloadStatus is the current workload status of the unit.
+	WorkloadStatus string
+
+	// WorkloadMessage is the message associated with the workload
+	// status.
+	WorkloadMessage string
+
+	// AgentStatus is the current status of the unit's agent.
+	AgentStatus string
+}
+
+// TableName overrides the table name gorm will use to find ModelUnit
+// records.
+func (ModelUnit) TableName() string {
+	return "model_units"
+}
+
+// ToAPIUnitInfo converts a ModelUnit to a JIMM API UnitInfo.
+func (u ModelUnit) ToAPIUnitInfo() apiparams.UnitInfo {
+	return apiparams.UnitInfo{
+		ModelTag:        u.Model.ResourceTag().String(),
+		ModelName:       u.Model.Name,
+		Name:            u.Name,
+		Application:     u.Application,
+		CharmURL:        u.CharmURL,
+		MachineID:       u.MachineID,
+		Principal:       u.Principal,
+		Life:            u.Life,
+		WorkloadStatus:  u.WorkloadStatus,
+		WorkloadMessage: u.WorkloadMessage,
+		AgentStatus:     u.AgentStatus,
+		UpdatedAt:       u.UpdatedAt,
+	}
+}
+
+// A ModelMachine is a machine in a model, as most recently reported by
+// the model's controller.
+type ModelMachine struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Model is the model the machine is in.
+	ModelID uint
+	Model   Model `gorm:"constraint:OnDelete:CASCADE"`
+
+	// MachineID is the ID of the machine within the model.
+	MachineID string
+
+	// Base is the OS base of the machine, for example "ubuntu@22.04".
+	Base string
+
+	// InstanceID is the provider's ID for the machine instance.
+	InstanceID string
+
+	// Hostname is the hostname of the machine.
+	Hostname string
+
+	// Cores is the number of CPU cores the machine has.
+	Cores int64
+
+	// Life is the life of the machine.
+	Life string
+
+	// AgentStatus is the current status of the machine's agent.
+	AgentStatus string
+
+	// InstanceStatus is the current status of the machine instance.
+	InstanceStatus string
+}
+
+// TableName overrides the table name gorm will use to find ModelMachine
+// records.
+func (ModelMachine) TableName() string {
+	return "model_machines"
+}
+
+// ToAPIMachineInfo converts a ModelMachine to a JIMM API MachineInfo.
+func (m ModelMachine) ToAPIMachineInfo() apiparams.MachineInfo {
+	return apiparams.MachineInfo{
+		ModelTag:       m.Model.ResourceTag().String(),
+		ModelName:      m.Model.Name,
+		ID:             m.MachineID,
+		Base:           m.Base,
+		InstanceID:     m.InstanceID,
+		Hostname:       m.Hostname,
+		Cores:          m.Cores,
+		Life:           m.Life,
+		AgentStatus:    m.AgentStatus,
+		InstanceStatus: m.InstanceStatus,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
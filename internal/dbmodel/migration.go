@@ -0,0 +1,104 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"database/sql"
+	"time"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const (
+	// MigrationPhaseRunning is the phase of a migration that has been
+	// initiated and has not yet finished.
+	MigrationPhaseRunning = "running"
+
+	// MigrationPhaseDone is the phase of a migration that completed
+	// successfully.
+	MigrationPhaseDone = "done"
+
+	// MigrationPhaseAborted is the phase of a migration that was aborted
+	// by the source controller. The model remains on the source
+	// controller.
+	MigrationPhaseAborted = "aborted"
+
+	// MigrationPhaseFailed is the phase of a migration whose outcome
+	// could not be determined, for example because the model can no
+	// longer be found on either controller.
+	MigrationPhaseFailed = "failed"
+)
+
+// A ModelMigration is a record of the migration of a model between two
+// controllers known to JIMM.
+type ModelMigration struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Model is the model being migrated.
+	ModelID uint
+	Model   Model `gorm:"constraint:OnDelete:CASCADE"`
+
+	// MigrationID is the ID of the migration returned by the source
+	// controller.
+	MigrationID string
+
+	// SourceController is the controller the model is migrating from.
+	SourceControllerID uint
+	SourceController   Controller `gorm:"constraint:OnDelete:CASCADE"`
+
+	// TargetController is the controller the model is migrating to.
+	TargetControllerID uint
+	TargetController   Controller `gorm:"constraint:OnDelete:CASCADE"`
+
+	// InitiatedBy is the name of the identity that initiated the
+	// migration.
+	InitiatedBy string
+
+	// Phase is the phase of the migration as tracked by JIMM, see the
+	// MigrationPhase constants.
+	Phase string
+
+	// Status is the latest migration status message reported by the
+	// source controller.
+	Status string
+
+	// StartedAt is the time the migration was initiated.
+	StartedAt time.Time
+
+	// FinishedAt is the time JIMM observed that the migration finished.
+	FinishedAt sql.NullTime
+
+	// Error holds the reason a migration did not complete successfully.
+	Error string
+}
+
+// TableName overrides the table name gorm will use to find
+// ModelMigration records.
+func (ModelMigration) TableName() string {
+	return "model_migrations"
+}
+
+// ToAPIMigrationInfo converts a ModelMigration to a JIMM API
+// MigrationInfo.
+func (m ModelMigration) ToAPIMigrationInfo() apiparams.MigrationInfo {
+	var mi apiparams.MigrationInfo
+	mi.ID = m.ID
+	mi.MigrationID = m.MigrationID
+	mi.ModelTag = m.Model.ResourceTag().String()
+	mi.ModelName = m.Model.Name
+	mi.SourceController = m.SourceController.Name
+	mi.TargetController = m.TargetController.Name
+	mi.InitiatedBy = m.InitiatedBy
+	mi.Phase = m.Phase
+	mi.Status = m.Status
+	mi.StartedAt = m.StartedAt
+	if m.FinishedAt.Valid {
+		finishedAt := m.FinishedAt.Time
+		mi.FinishedAt = &finishedAt
+	}
+	mi.Error = m.Error
+	return mi
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 Canonical.
+
+package dbmodel
+
+import (
+	"time"
+
+	"github.com/juju/names/v5"
+
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// A RoleEntry holds information about a role. A role is a named bundle
+// of entitlements that can be assigned to identities and groups.
+type RoleEntry struct {
+	// Note this doesn't use the standard gorm.Model to avoid soft-deletes.
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Name holds the name of the role.
+	Name string `gorm:"index;column:name"`
+
+	// UUID holds the uuid of the role.
+	UUID string `gorm:"index;column:uuid"`
+}
+
+// ToAPIRoleEntry converts a role entry to a JIMM API
+// Role.
+func (r RoleEntry) ToAPIRoleEntry() apiparams.Role {
+	var role apiparams.Role
+	role.UUID = r.UUID
+	role.Name = r.Name
+	role.CreatedAt = r.CreatedAt.Format(time.RFC3339)
+	role.UpdatedAt = r.UpdatedAt.Format(time.RFC3339)
+	return role
+}
+
+// TableName overrides the table name gorm will use to find
+// RoleEntry records.
+func (RoleEntry) TableName() string {
+	return "roles"
+}
+
+// Tag implements the names.Tag interface.
+func (r *RoleEntry) Tag() names.Tag {
+	return r.ResourceTag()
+}
+
+// ResourceTag returns a tag for this role. This method
+// is intended to be used in places where we expect to see
+// a concrete type names.RoleTag instead of the
+// names.Tag interface.
+func (r *RoleEntry) ResourceTag() jimmnames.RoleTag {
+	return jimmnames.NewRoleTag(r.UUID)
+}
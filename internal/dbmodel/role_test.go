@@ -0,0 +1,35 @@
+// Copyright 2024 Canonical.
+
+package dbmodel_test
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func TestRoleEntry(t *testing.T) {
+	c := qt.New(t)
+	db := gormDB(t)
+
+	re := dbmodel.RoleEntry{
+		Name: "test-role-1",
+		UUID: uuid.NewString(),
+	}
+	c.Assert(db.Create(&re).Error, qt.IsNil)
+	c.Assert(re.ID, qt.Equals, uint(1))
+
+	re1 := dbmodel.RoleEntry{
+		Name: "test-role-1",
+		UUID: uuid.NewString(),
+	}
+	c.Assert(db.Create(&re1).Error, qt.ErrorMatches, `.*violates unique constraint "roles_name_key".*`)
+
+	var re2 dbmodel.RoleEntry
+	c.Assert(db.First(&re2).Error, qt.IsNil)
+	c.Check(re2, qt.DeepEquals, re)
+	c.Check(re2.ResourceTag().String(), qt.Equals, "role-"+re.UUID)
+}
@@ -0,0 +1,4 @@
+-- 1_13.sql is a migration that adds a max_models column to the controller table.
+ALTER TABLE controllers ADD COLUMN max_models INTEGER NOT NULL DEFAULT 0;
+
+UPDATE versions SET major=1, minor=13 WHERE component='jimmdb';
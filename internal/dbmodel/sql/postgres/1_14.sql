@@ -0,0 +1,11 @@
+-- 1_14.sql is a migration that adds a roles table.
+CREATE TABLE IF NOT EXISTS roles (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	name TEXT NOT NULL UNIQUE,
+	uuid TEXT NOT NULL UNIQUE
+);
+CREATE INDEX IF NOT EXISTS idx_role_name ON roles (name);
+
+UPDATE versions SET major=1, minor=14 WHERE component='jimmdb';
@@ -0,0 +1,32 @@
+-- 1_15.sql is a migration that converts the audit log params and errors
+-- to JSONB and adds indexes to support richer audit log filtering.
+--
+-- Converting the columns rewrites the audit_log table, and every
+-- migration runs in a single transaction, so audit_log is locked against
+-- both reads and writes until the migration completes. API requests that
+-- write to the audit log stall for the duration; expect this to take
+-- roughly a minute per few million audit log entries. Operators with a
+-- large audit log should purge old entries, for example by lowering the
+-- retention period, before upgrading.
+--
+-- JSONB cannot store the NUL character so any \u0000 escapes in existing
+-- entries are replaced with U+FFFD, the Unicode replacement character,
+-- rather than failing the conversion. An escaped backslash followed by
+-- "u0000" is left alone.
+CREATE FUNCTION pg_temp.audit_log_jsonb(j JSON) RETURNS JSONB AS $$
+	SELECT CASE
+		WHEN strpos(j::TEXT, '\u0000') = 0 THEN j::JSONB
+		ELSE regexp_replace(j::TEXT, '(?<!\\)((?:\\\\)*)\\u0000', '\1\\ufffd', 'g')::JSONB
+	END
+$$ LANGUAGE SQL IMMUTABLE;
+
+ALTER TABLE audit_log ALTER COLUMN params TYPE JSONB USING pg_temp.audit_log_jsonb(params);
+ALTER TABLE audit_log ALTER COLUMN errors TYPE JSONB USING pg_temp.audit_log_jsonb(errors);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_facade_name ON audit_log (facade_name);
+CREATE INDEX IF NOT EXISTS idx_audit_log_conversation_id ON audit_log (conversation_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_params ON audit_log USING GIN (params jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_audit_log_params_search ON audit_log USING GIN (jsonb_to_tsvector('simple', params, '["string"]'));
+CREATE INDEX IF NOT EXISTS idx_audit_log_errors ON audit_log (time) WHERE is_response AND jsonb_path_exists(errors, '$.results[*].error.message ? (@ != "")');
+
+UPDATE versions SET major=1, minor=15 WHERE component='jimmdb';
@@ -0,0 +1,20 @@
+-- 1_16.sql is a migration that adds a table tracking model migrations.
+CREATE TABLE IF NOT EXISTS model_migrations (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	migration_id TEXT NOT NULL DEFAULT '',
+	source_controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	target_controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	initiated_by TEXT NOT NULL DEFAULT '',
+	phase TEXT NOT NULL,
+	status TEXT NOT NULL DEFAULT '',
+	started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+	finished_at TIMESTAMP WITH TIME ZONE,
+	error TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_model_migrations_model_id ON model_migrations (model_id);
+CREATE INDEX IF NOT EXISTS idx_model_migrations_active ON model_migrations (started_at) WHERE finished_at IS NULL;
+
+UPDATE versions SET major=1, minor=16 WHERE component='jimmdb';
@@ -0,0 +1,55 @@
+-- 1_17.sql is a migration that adds tables holding an inventory of the
+-- applications, units and machines in each model.
+CREATE TABLE IF NOT EXISTS model_applications (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	charm_url TEXT NOT NULL DEFAULT '',
+	charm_name TEXT NOT NULL DEFAULT '',
+	charm_revision INTEGER NOT NULL DEFAULT -1,
+	life TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT '',
+	workload_version TEXT NOT NULL DEFAULT '',
+	exposed BOOLEAN NOT NULL DEFAULT false,
+	subordinate BOOLEAN NOT NULL DEFAULT false,
+	UNIQUE(model_id, name)
+);
+CREATE INDEX IF NOT EXISTS idx_model_applications_charm ON model_applications (charm_name, charm_revision);
+
+CREATE TABLE IF NOT EXISTS model_units (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	name TEXT NOT NULL,
+	application TEXT NOT NULL DEFAULT '',
+	charm_url TEXT NOT NULL DEFAULT '',
+	machine_id TEXT NOT NULL DEFAULT '',
+	principal TEXT NOT NULL DEFAULT '',
+	life TEXT NOT NULL DEFAULT '',
+	workload_status TEXT NOT NULL DEFAULT '',
+	workload_message TEXT NOT NULL DEFAULT '',
+	agent_status TEXT NOT NULL DEFAULT '',
+	UNIQUE(model_id, name)
+);
+CREATE INDEX IF NOT EXISTS idx_model_units_application ON model_units (model_id, application);
+
+CREATE TABLE IF NOT EXISTS model_machines (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	model_id BIGINT NOT NULL REFERENCES models (id) ON DELETE CASCADE,
+	machine_id TEXT NOT NULL,
+	base TEXT NOT NULL DEFAULT '',
+	instance_id TEXT NOT NULL DEFAULT '',
+	hostname TEXT NOT NULL DEFAULT '',
+	cores BIGINT NOT NULL DEFAULT 0,
+	life TEXT NOT NULL DEFAULT '',
+	agent_status TEXT NOT NULL DEFAULT '',
+	instance_status TEXT NOT NULL DEFAULT '',
+	UNIQUE(model_id, machine_id)
+);
+
+UPDATE versions SET major=1, minor=17 WHERE component='jimmdb';
@@ -0,0 +1,27 @@
+-- 1_18.sql is a migration that adds tables recording the health of
+-- controllers.
+CREATE TABLE IF NOT EXISTS controller_health (
+	controller_id INTEGER PRIMARY KEY REFERENCES controllers (id) ON DELETE CASCADE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	watcher_state TEXT NOT NULL DEFAULT '',
+	agent_version TEXT NOT NULL DEFAULT '',
+	dial_latency BIGINT NOT NULL DEFAULT 0,
+	last_dial TIMESTAMP WITH TIME ZONE,
+	ping_latency BIGINT NOT NULL DEFAULT 0,
+	last_ping TIMESTAMP WITH TIME ZONE,
+	error_streak INTEGER NOT NULL DEFAULT 0,
+	degraded_since TIMESTAMP WITH TIME ZONE,
+	last_error TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS controller_health_events (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	watcher_state TEXT NOT NULL DEFAULT '',
+	healthy BOOLEAN NOT NULL,
+	error TEXT NOT NULL DEFAULT ''
+);
+CREATE INDEX IF NOT EXISTS idx_controller_health_events_controller_id ON controller_health_events (controller_id, created_at);
+
+UPDATE versions SET major=1, minor=18 WHERE component='jimmdb';
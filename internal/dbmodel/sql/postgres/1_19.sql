@@ -0,0 +1,5 @@
+-- 1_19.sql is a migration that marks groups whose membership is managed
+-- by the identity provider.
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS externally_managed BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE versions SET major=1, minor=19 WHERE component='jimmdb';
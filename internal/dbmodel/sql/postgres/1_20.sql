@@ -0,0 +1,28 @@
+-- 1_20.sql is a migration that adds tables recording controller drains
+-- and the progress of each model being drained.
+CREATE TABLE IF NOT EXISTS controller_drains (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	controller_id INTEGER NOT NULL REFERENCES controllers (id) ON DELETE CASCADE,
+	state TEXT NOT NULL,
+	concurrency INTEGER NOT NULL DEFAULT 1,
+	initiated_by TEXT NOT NULL DEFAULT ''
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_controller_drains_active ON controller_drains (controller_id) WHERE state IN ('running', 'paused');
+
+CREATE TABLE IF NOT EXISTS controller_drain_models (
+	id BIGSERIAL PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE,
+	updated_at TIMESTAMP WITH TIME ZONE,
+	drain_id BIGINT NOT NULL REFERENCES controller_drains (id) ON DELETE CASCADE,
+	model_uuid TEXT NOT NULL,
+	model_name TEXT NOT NULL,
+	target_controller TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	migration_id BIGINT REFERENCES model_migrations (id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_controller_drain_models_drain_id ON controller_drain_models (drain_id);
+
+UPDATE versions SET major=1, minor=20 WHERE component='jimmdb';
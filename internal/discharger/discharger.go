@@ -62,6 +62,7 @@ func NewMacaroonDischarger(cfg MacaroonDischargerConfig, db *db.Database, ofgaCl
 	)
 
 	return &MacaroonDischarger{
+		db:         db,
 		ofgaClient: ofgaClient,
 		bakery:     b,
 		kp:         kp,
@@ -69,6 +70,7 @@ func NewMacaroonDischarger(cfg MacaroonDischargerConfig, db *db.Database, ofgaCl
 }
 
 type MacaroonDischarger struct {
+	db         *db.Database
 	ofgaClient *openfga.OFGAClient
 	bakery     *bakery.Bakery
 	kp         bakery.KeyPair
@@ -125,6 +127,17 @@ func (md *MacaroonDischarger) CheckThirdPartyCaveat(ctx context.Context, req *ht
 	if err != nil {
 		return nil, err
 	}
+	// Use FetchIdentity so that checking the disabled flag does not
+	// create identities for every user named in a caveat. An identity
+	// that is not yet known cannot have been disabled.
+	if err := md.db.FetchIdentity(ctx, i); err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		zapctx.Error(ctx, "failed to get caveat identity", zap.Error(err))
+		return nil, errors.E(err)
+	}
+	if i.Disabled {
+		zapctx.Debug(ctx, "macaroon discharge denied to disabled identity", zap.String("user", i.Name))
+		return nil, httpbakery.ErrPermissionDenied
+	}
 	user := openfga.NewUser(
 		i,
 		md.ofgaClient,
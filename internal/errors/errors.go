@@ -120,6 +120,7 @@ const (
 	CodeRedirect                     Code = jujuparams.CodeRedirect
 	CodeServerConfiguration          Code = "server configuration"
 	CodeStillAlive                   Code = apiparams.CodeStillAlive
+	CodeStopped                      Code = jujuparams.CodeStopped
 	CodeUnauthorized                 Code = jujuparams.CodeUnauthorized
 	CodeSessionTokenInvalid          Code = jujuparams.CodeSessionTokenInvalid
 	CodeUpgradeInProgress            Code = jujuparams.CodeUpgradeInProgress
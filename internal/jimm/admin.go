@@ -13,10 +13,11 @@ import (
 	"github.com/canonical/jimm/v3/pkg/names"
 )
 
-// LoginDevice starts the device login flow.
-func (j *JIMM) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+// LoginDevice starts the device login flow with the given identity
+// provider. If provider is empty the default identity provider is used.
+func (j *JIMM) LoginDevice(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error) {
 	const op = errors.Op("jimm.LoginDevice")
-	resp, err := j.OAuthAuthenticator.Device(ctx)
+	resp, err := j.OAuthAuthenticator.Device(ctx, provider)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
@@ -29,20 +30,21 @@ func (j *JIMM) AuthenticateBrowserSession(ctx context.Context, w http.ResponseWr
 }
 
 // GetDeviceSessionToken polls an OIDC server while a user logs in and returns a session token scoped to the user's identity.
-func (j *JIMM) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
+// The provider must be the identity provider the device login flow was started with.
+func (j *JIMM) GetDeviceSessionToken(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
 	const op = errors.Op("jimm.GetDeviceSessionToken")
 
-	token, err := j.OAuthAuthenticator.DeviceAccessToken(ctx, deviceOAuthResponse)
+	token, err := j.OAuthAuthenticator.DeviceAccessToken(ctx, provider, deviceOAuthResponse)
 	if err != nil {
 		return "", errors.E(op, err)
 	}
 
-	idToken, err := j.OAuthAuthenticator.ExtractAndVerifyIDToken(ctx, token)
+	idToken, err := j.OAuthAuthenticator.ExtractAndVerifyIDToken(ctx, provider, token)
 	if err != nil {
 		return "", errors.E(op, err)
 	}
 
-	email, err := j.OAuthAuthenticator.Email(idToken)
+	email, err := j.OAuthAuthenticator.Email(provider, idToken)
 	if err != nil {
 		return "", errors.E(op, err)
 	}
@@ -24,7 +24,7 @@ func TestLoginDevice(t *testing.T) {
 	jimm := jimm.JIMM{
 		OAuthAuthenticator: &mockAuthenticator,
 	}
-	resp, err := jimm.LoginDevice(context.Background())
+	resp, err := jimm.LoginDevice(context.Background(), "")
 	c.Assert(err, qt.IsNil)
 	c.Assert(*resp, qt.CmpEquals(cmpopts.IgnoreTypes(time.Time{})), oauth2.DeviceAuthResponse{
 		DeviceCode:              "test-device-code",
@@ -43,7 +43,7 @@ func TestGetDeviceSessionToken(t *testing.T) {
 		OAuthAuthenticator: &mockAuthenticator,
 	}
 	pollingChan <- "user-foo"
-	token, err := jimm.GetDeviceSessionToken(context.Background(), nil)
+	token, err := jimm.GetDeviceSessionToken(context.Background(), "", nil)
 	c.Assert(err, qt.IsNil)
 	c.Assert(token, qt.Not(qt.Equals), "")
 	decodedToken, err := base64.StdEncoding.DecodeString(token)
@@ -25,6 +25,14 @@ type AuditLoggerBackend interface {
 	AddAuditLogEntry(*dbmodel.AuditLogEntry)
 }
 
+// unauditedFacades contains the facades whose requests are not recorded
+// in the audit log. Calls to the AuditEventWatcher facade are not
+// recorded, otherwise every watcher would report the audit log entries
+// for its own Next calls and never stop returning events.
+var unauditedFacades = map[string]bool{
+	"AuditEventWatcher": true,
+}
+
 type DbAuditLogger struct {
 	backend        AuditLoggerBackend
 	conversationId string
@@ -53,6 +61,9 @@ func (r DbAuditLogger) newAuditLogEntry(header *rpc.Header) dbmodel.AuditLogEntr
 
 // LogRequest creates an audit log entry from a client request.
 func (r DbAuditLogger) LogRequest(header *rpc.Header, body interface{}) error {
+	if unauditedFacades[header.Request.Type] {
+		return nil
+	}
 	ale := r.newAuditLogEntry(header)
 	ale.ObjectId = header.Request.Id
 	ale.FacadeName = header.Request.Type
@@ -72,6 +83,9 @@ func (r DbAuditLogger) LogRequest(header *rpc.Header, body interface{}) error {
 
 // LogResponse creates an audit log entry from a controller response.
 func (o DbAuditLogger) LogResponse(r rpc.Request, header *rpc.Header, body interface{}) error {
+	if unauditedFacades[r.Type] {
+		return nil
+	}
 	var allErrors params.ErrorResults
 	bulkError, ok := body.(params.ErrorResults)
 	if ok {
@@ -153,10 +167,10 @@ func NewAuditLogCleanupService(db db.Database, auditLogRetentionPeriodInDays int
 	}
 }
 
-// Start starts a routine which checks daily for any logs
-// needed to be cleaned up.
-func (a *auditLogCleanupService) Start(ctx context.Context) {
-	go a.poll(ctx)
+// Run checks daily for any logs needed to be cleaned up. Run does not
+// return until the given context is done.
+func (a *auditLogCleanupService) Run(ctx context.Context) {
+	a.poll(ctx)
 }
 
 // poll is designed to be run in a routine where it can be cancelled safely
@@ -13,6 +13,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
 
@@ -59,7 +60,9 @@ func TestAuditLogCleanupServicePurgesLogs(t *testing.T) {
 	jimm.PollDuration.Minutes = now.Minute()
 	jimm.PollDuration.Seconds = now.Second() + 2
 	svc := jimm.NewAuditLogCleanupService(db, 1)
-	svc.Start(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go svc.Run(ctx)
 
 	// Check 2 were purged
 	logs = make([]dbmodel.AuditLogEntry, 0)
@@ -81,3 +84,37 @@ func TestCalculateNextPollDuration(t *testing.T) {
 	d = jimm.CalculateNextPollDuration(startingTime)
 	c.Assert(d, qt.Equals, time.Hour*2)
 }
+
+type recordingSink struct {
+	entries []dbmodel.AuditLogEntry
+	err     error
+}
+
+func (s *recordingSink) Write(_ context.Context, ale *dbmodel.AuditLogEntry) error {
+	s.entries = append(s.entries, *ale)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	return nil
+}
+
+func TestAddAuditLogEntrySendsToSinks(t *testing.T) {
+	c := qt.New(t)
+
+	sink1 := &recordingSink{err: errors.E("test error")}
+	sink2 := &recordingSink{}
+	j := &jimm.JIMM{
+		AuditSinks: []auditsink.Sink{sink1, sink2},
+	}
+	// The entry is sent to every sink, even when the database and other
+	// sinks fail.
+	j.AddAuditLogEntry(&dbmodel.AuditLogEntry{
+		FacadeName:   "Admin",
+		FacadeMethod: "Login",
+		Params:       dbmodel.JSON(`{"password":"secret"}`),
+	})
+	c.Assert(sink1.entries, qt.HasLen, 1)
+	c.Assert(sink2.entries, qt.HasLen, 1)
+	c.Check(string(sink2.entries[0].Params), qt.Equals, `{"params":"redacted"}`)
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Canonical.
+
+package auditsink
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// A JSONLinesSink writes each audit log entry as a single line of JSON.
+type JSONLinesSink struct {
+	mu sync.Mutex
+	w  io.WriteCloser
+}
+
+// NewJSONLinesSink returns a sink that writes audit log entries to the
+// given writer as JSON lines. The writer is closed when the sink is
+// closed.
+func NewJSONLinesSink(w io.WriteCloser) *JSONLinesSink {
+	return &JSONLinesSink{w: w}
+}
+
+// Write implements Sink.
+func (s *JSONLinesSink) Write(_ context.Context, ale *dbmodel.AuditLogEntry) (err error) {
+	const op = errors.Op("auditsink.JSONLinesSink.Write")
+	defer servermon.ErrorCounter(servermon.AuditSinkErrorCount, &err, "jsonlines")
+
+	buf, err := marshalEntry(ale)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	buf = append(buf, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(buf); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *JSONLinesSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Close()
+}
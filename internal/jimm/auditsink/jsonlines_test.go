@@ -0,0 +1,33 @@
+// Copyright 2024 Canonical.
+
+package auditsink_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
+)
+
+func TestJSONLinesSink(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	path := filepath.Join(c.TempDir(), "audit.log")
+	sink, err := auditsink.New(ctx, "file://"+path)
+	c.Assert(err, qt.IsNil)
+
+	c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+	c.Assert(sink.Write(ctx, testEntry(2)), qt.IsNil)
+	c.Assert(sink.Close(), qt.IsNil)
+
+	buf, err := os.ReadFile(path)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(buf), qt.Equals, ``+
+		`{"time":"2024-01-02T03:04:05Z","conversation-id":"conversation-1","message-id":1,"facade-name":"ModelManager","facade-method":"CreateModel","facade-version":9,"user-tag":"user-alice@canonical.com","is-response":false,"params":{"name":"test-model"}}`+"\n"+
+		`{"time":"2024-01-02T03:04:05Z","conversation-id":"conversation-1","message-id":2,"facade-name":"ModelManager","facade-method":"CreateModel","facade-version":9,"user-tag":"user-alice@canonical.com","is-response":false,"params":{"name":"test-model"}}`+"\n")
+}
@@ -0,0 +1,174 @@
+// Copyright 2024 Canonical.
+
+// Package auditsink provides destinations, other than the database, that
+// audit log entries can be streamed to. Sinks are intended for feeding
+// external systems such as a SIEM directly, without having to poll the
+// audit log.
+package auditsink
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// A Sink is a destination for audit log entries.
+type Sink interface {
+	// Write sends the audit log entry to the sink. Write is called while
+	// handling API requests, sinks that queue entries apply backpressure
+	// by blocking, for a limited time, while their queue is full unless
+	// they are configured to drop entries instead. Write must be safe for
+	// concurrent use.
+	Write(ctx context.Context, ale *dbmodel.AuditLogEntry) error
+
+	// Close flushes any buffered entries and releases the resources
+	// held by the sink.
+	Close() error
+}
+
+// New creates a new Sink from the given URL. The following URLs are
+// supported:
+//
+//	stdout                        JSON lines written to standard output.
+//	file:///path/to/audit.log     JSON lines appended to the given file.
+//	syslog+tcp://host:port        RFC5424 syslog messages sent over TCP.
+//	syslog+udp://host:port        RFC5424 syslog messages sent over UDP.
+//	http(s)://host/path           Batches of entries POSTed to a webhook.
+//
+// The syslog and webhook sinks queue entries. By default Write blocks for
+// up to 10 seconds while the queue is full, the query parameters
+// "queue-full=drop" and "block-timeout=<duration>" make the sink drop
+// entries instead or change the time Write blocks for. These parameters
+// are not sent to a webhook.
+func New(ctx context.Context, sinkURL string) (Sink, error) {
+	const op = errors.Op("auditsink.New")
+
+	if sinkURL == "stdout" {
+		return NewJSONLinesSink(nopCloser{os.Stdout}), nil
+	}
+	u, err := url.Parse(sinkURL)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeBadRequest, err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return nil, errors.E(op, errors.CodeBadRequest, "file audit sink requires a path")
+		}
+		f, err := os.OpenFile(u.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return NewJSONLinesSink(f), nil
+	case "syslog+tcp", "syslog+udp":
+		if u.Host == "" {
+			return nil, errors.E(op, errors.CodeBadRequest, "syslog audit sink requires a host")
+		}
+		qp, err := parseQueueParams(u)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return NewSyslogSink(ctx, SyslogSinkParams{
+			Network:      u.Scheme[len("syslog+"):],
+			Address:      u.Host,
+			DropWhenFull: qp.dropWhenFull,
+			BlockTimeout: qp.blockTimeout,
+		}), nil
+	case "http", "https":
+		qp, err := parseQueueParams(u)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		return NewWebhookSink(ctx, WebhookSinkParams{
+			URL:          u.String(),
+			DropWhenFull: qp.dropWhenFull,
+			BlockTimeout: qp.blockTimeout,
+		}), nil
+	default:
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("unsupported audit sink %q", sinkURL))
+	}
+}
+
+// defaultBlockTimeout is the default maximum time Write waits for space in
+// a full queue.
+const defaultBlockTimeout = 10 * time.Second
+
+// queueParams holds the settings of a queueing sink parsed from its URL.
+type queueParams struct {
+	dropWhenFull bool
+	blockTimeout time.Duration
+}
+
+// parseQueueParams parses and removes the queue-full and block-timeout
+// query parameters of the given sink URL.
+func parseQueueParams(u *url.URL) (queueParams, error) {
+	var qp queueParams
+	q := u.Query()
+	switch v := q.Get("queue-full"); v {
+	case "", "block":
+	case "drop":
+		qp.dropWhenFull = true
+	default:
+		return qp, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid queue-full value %q", v))
+	}
+	if v := q.Get("block-timeout"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d <= 0 {
+			return qp, errors.E(errors.CodeBadRequest, fmt.Sprintf("invalid block-timeout value %q", v))
+		}
+		qp.blockTimeout = d
+	}
+	if q.Has("queue-full") || q.Has("block-timeout") {
+		q.Del("queue-full")
+		q.Del("block-timeout")
+		u.RawQuery = q.Encode()
+	}
+	return qp, nil
+}
+
+// enqueue adds v to the given queue. If the queue is full enqueue returns
+// an error straight away when drop is set, otherwise it waits up to
+// timeout for space in the queue.
+func enqueue[T any](ctx context.Context, queue chan<- T, v T, drop bool, timeout time.Duration, closing <-chan struct{}) error {
+	select {
+	case queue <- v:
+		return nil
+	default:
+	}
+	if !drop {
+		t := time.NewTimer(timeout)
+		defer t.Stop()
+		select {
+		case queue <- v:
+			return nil
+		case <-t.C:
+		case <-ctx.Done():
+		case <-closing:
+			return errors.E("audit sink closed")
+		}
+	}
+	return errors.E("audit sink queue full")
+}
+
+// marshalEntry returns the JSON encoding of the audit log entry. Entries
+// are encoded in the same format returned by the FindAuditEvents API.
+func marshalEntry(ale *dbmodel.AuditLogEntry) ([]byte, error) {
+	return json.Marshal(ale.ToAPIAuditEvent())
+}
+
+// nopCloser wraps a writer that should not be closed by the sink, such as
+// standard output.
+type nopCloser struct {
+	*os.File
+}
+
+// Close implements io.Closer.
+func (nopCloser) Close() error {
+	return nil
+}
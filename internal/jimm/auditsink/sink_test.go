@@ -0,0 +1,97 @@
+// Copyright 2024 Canonical.
+
+package auditsink_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
+)
+
+func testEntry(messageID uint64) *dbmodel.AuditLogEntry {
+	return &dbmodel.AuditLogEntry{
+		Time:           time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		ConversationId: "conversation-1",
+		MessageId:      messageID,
+		FacadeName:     "ModelManager",
+		FacadeMethod:   "CreateModel",
+		FacadeVersion:  9,
+		IdentityTag:    "user-alice@canonical.com",
+		Params:         dbmodel.JSON(`{"name":"test-model"}`),
+	}
+}
+
+func TestNew(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about         string
+		url           string
+		expectedType  string
+		expectedError string
+	}{{
+		about:        "stdout",
+		url:          "stdout",
+		expectedType: "*auditsink.JSONLinesSink",
+	}, {
+		about:        "file",
+		url:          "file://" + filepath.Join(c.TempDir(), "audit.log"),
+		expectedType: "*auditsink.JSONLinesSink",
+	}, {
+		about:         "file without path",
+		url:           "file://",
+		expectedError: "file audit sink requires a path",
+	}, {
+		about:        "syslog over tcp",
+		url:          "syslog+tcp://localhost:514",
+		expectedType: "*auditsink.SyslogSink",
+	}, {
+		about:        "syslog over udp",
+		url:          "syslog+udp://localhost:514",
+		expectedType: "*auditsink.SyslogSink",
+	}, {
+		about:         "syslog without host",
+		url:           "syslog+udp:///",
+		expectedError: "syslog audit sink requires a host",
+	}, {
+		about:        "webhook",
+		url:          "https://siem.example.com/audit",
+		expectedType: "*auditsink.WebhookSink",
+	}, {
+		about:        "webhook dropping entries when full",
+		url:          "https://siem.example.com/audit?queue-full=drop&block-timeout=1s",
+		expectedType: "*auditsink.WebhookSink",
+	}, {
+		about:         "invalid queue-full",
+		url:           "syslog+tcp://localhost:514?queue-full=spill",
+		expectedError: `invalid queue-full value "spill"`,
+	}, {
+		about:         "invalid block-timeout",
+		url:           "https://siem.example.com/audit?block-timeout=soon",
+		expectedError: `invalid block-timeout value "soon"`,
+	}, {
+		about:         "unsupported",
+		url:           "ftp://example.com",
+		expectedError: `unsupported audit sink "ftp://example.com"`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			sink, err := auditsink.New(context.Background(), test.url)
+			if test.expectedError != "" {
+				c.Assert(err, qt.ErrorMatches, test.expectedError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			defer sink.Close()
+			c.Assert(fmt.Sprintf("%T", sink), qt.Equals, test.expectedType)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Canonical.
+
+package auditsink
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+const (
+	// facilityLocal0 is the syslog facility used for audit messages
+	// unless another is specified.
+	facilityLocal0 = 16
+	// severityInfo is the syslog severity of all audit messages.
+	severityInfo = 6
+	// nilValue is the RFC5424 NILVALUE.
+	nilValue = "-"
+)
+
+// SyslogSinkParams holds the parameters for a SyslogSink.
+type SyslogSinkParams struct {
+	// Network is the network used to reach the syslog server, either
+	// "tcp" or "udp".
+	Network string
+
+	// Address is the host:port of the syslog server.
+	Address string
+
+	// Facility is the syslog facility code used for messages. Defaults
+	// to local0.
+	Facility int
+
+	// Hostname is the HOSTNAME sent in each message. Defaults to the
+	// hostname of the machine.
+	Hostname string
+
+	// AppName is the APP-NAME sent in each message. Defaults to "jimm".
+	AppName string
+
+	// DialTimeout is the timeout used when connecting to the syslog
+	// server. Defaults to 10 seconds.
+	DialTimeout time.Duration
+
+	// QueueSize is the maximum number of messages waiting to be sent.
+	// Defaults to 10000.
+	QueueSize int
+
+	// DropWhenFull decides whether Write drops entries straight away
+	// when the queue is full instead of waiting for space.
+	DropWhenFull bool
+
+	// BlockTimeout is the maximum time Write waits for space in a full
+	// queue before the entry is dropped. Defaults to 10 seconds.
+	BlockTimeout time.Duration
+}
+
+// A SyslogSink sends audit log entries to a syslog server as RFC5424
+// messages. Messages sent over TCP use octet-counting framing as
+// described in RFC6587. Messages are queued and sent in the background.
+type SyslogSink struct {
+	p     SyslogSinkParams
+	pid   string
+	queue chan []byte
+
+	// conn is only used by the run goroutine.
+	conn net.Conn
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	done      chan struct{}
+}
+
+// NewSyslogSink returns a new sink that sends audit log entries to a
+// syslog server. The connection to the server is established when the
+// first message is sent and re-established if it fails. The sink sends
+// messages until it is closed or the given context is done.
+func NewSyslogSink(ctx context.Context, p SyslogSinkParams) *SyslogSink {
+	if p.Facility == 0 {
+		p.Facility = facilityLocal0
+	}
+	if p.Hostname == "" {
+		p.Hostname, _ = os.Hostname()
+	}
+	if p.Hostname == "" {
+		p.Hostname = nilValue
+	}
+	if p.AppName == "" {
+		p.AppName = "jimm"
+	}
+	if p.DialTimeout == 0 {
+		p.DialTimeout = 10 * time.Second
+	}
+	if p.QueueSize <= 0 {
+		p.QueueSize = 10000
+	}
+	if p.BlockTimeout <= 0 {
+		p.BlockTimeout = defaultBlockTimeout
+	}
+	s := &SyslogSink{
+		p:       p,
+		pid:     strconv.Itoa(os.Getpid()),
+		queue:   make(chan []byte, p.QueueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run(ctx)
+	return s
+}
+
+// Write implements Sink. If the queue is full Write waits for space up to
+// the sink's block timeout, or drops the entry straight away if the sink
+// drops entries when full.
+func (s *SyslogSink) Write(ctx context.Context, ale *dbmodel.AuditLogEntry) (err error) {
+	const op = errors.Op("auditsink.SyslogSink.Write")
+
+	select {
+	case <-s.closing:
+		return errors.E(op, "audit sink closed")
+	default:
+	}
+	body, err := marshalEntry(ale)
+	if err != nil {
+		servermon.AuditSinkErrorCount.WithLabelValues("syslog").Inc()
+		return errors.E(op, err)
+	}
+	if err := enqueue(ctx, s.queue, s.format(ale.Time, body), s.p.DropWhenFull, s.p.BlockTimeout, s.closing); err != nil {
+		servermon.AuditSinkDroppedCount.WithLabelValues("syslog").Inc()
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Close implements Sink. Close sends any queued messages before
+// returning.
+func (s *SyslogSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+	})
+	<-s.done
+	return nil
+}
+
+// run sends queued messages to the syslog server.
+func (s *SyslogSink) run(ctx context.Context) {
+	defer close(s.done)
+	defer func() {
+		if s.conn != nil {
+			s.conn.Close()
+			s.conn = nil
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-s.queue:
+			s.send(ctx, msg)
+		case <-s.closing:
+			// Drain whatever is left in the queue.
+			for {
+				select {
+				case msg := <-s.queue:
+					s.send(ctx, msg)
+				default:
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// send sends a single message to the syslog server. If the message cannot
+// be sent it is dropped.
+func (s *SyslogSink) send(ctx context.Context, msg []byte) {
+	var err error
+	// If the connection has been dropped since the last write, try once
+	// more with a new connection.
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			var d net.Dialer
+			dctx, cancel := context.WithTimeout(ctx, s.p.DialTimeout)
+			s.conn, err = d.DialContext(dctx, s.p.Network, s.p.Address)
+			cancel()
+			if err != nil {
+				s.conn = nil
+				break
+			}
+		}
+		if err = s.conn.SetWriteDeadline(time.Now().Add(s.p.DialTimeout)); err == nil {
+			_, err = s.conn.Write(msg)
+		}
+		if err == nil {
+			return
+		}
+		s.conn.Close()
+		s.conn = nil
+	}
+	zapctx.Error(ctx, "dropping audit event", zap.String("sink", "syslog"), zap.Error(err))
+	servermon.AuditSinkErrorCount.WithLabelValues("syslog").Inc()
+	servermon.AuditSinkDroppedCount.WithLabelValues("syslog").Inc()
+}
+
+// format returns the RFC5424 message for the given body.
+func (s *SyslogSink) format(t time.Time, body []byte) []byte {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %s audit %s ",
+		s.p.Facility*8+severityInfo,
+		t.UTC().Format("2006-01-02T15:04:05.000Z"),
+		s.p.Hostname,
+		s.p.AppName,
+		s.pid,
+		nilValue,
+	)
+	msg := append([]byte(header), body...)
+	if s.p.Network == "udp" {
+		return msg
+	}
+	return append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+}
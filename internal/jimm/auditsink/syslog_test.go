@@ -0,0 +1,89 @@
+// Copyright 2024 Canonical.
+
+package auditsink_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
+)
+
+const expectedSyslogBody = `{"time":"2024-01-02T03:04:05Z","conversation-id":"conversation-1","message-id":1,"facade-name":"ModelManager","facade-method":"CreateModel","facade-version":9,"user-tag":"user-alice@canonical.com","is-response":false,"params":{"name":"test-model"}}`
+
+func TestSyslogSinkUDP(t *testing.T) {
+	c := qt.New(t)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer conn.Close()
+
+	sink := auditsink.NewSyslogSink(context.Background(), auditsink.SyslogSinkParams{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Hostname: "jimm-0",
+	})
+	defer sink.Close()
+
+	err = sink.Write(context.Background(), testEntry(1))
+	c.Assert(err, qt.IsNil)
+
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	c.Assert(err, qt.IsNil)
+	c.Assert(string(buf[:n]), qt.Equals, fmt.Sprintf("<134>1 2024-01-02T03:04:05.000Z jimm-0 jimm %d audit - %s", os.Getpid(), expectedSyslogBody))
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	c := qt.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, qt.IsNil)
+	defer l.Close()
+
+	msgs := make(chan string)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		for {
+			// Messages are framed with their length in octets.
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := r.Read(msg); err != nil {
+				return
+			}
+			msgs <- string(msg)
+		}
+	}()
+
+	sink := auditsink.NewSyslogSink(context.Background(), auditsink.SyslogSinkParams{
+		Network:  "tcp",
+		Address:  l.Addr().String(),
+		Hostname: "jimm-0",
+		Facility: 10,
+	})
+	defer sink.Close()
+
+	err = sink.Write(context.Background(), testEntry(1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(<-msgs, qt.Equals, fmt.Sprintf("<86>1 2024-01-02T03:04:05.000Z jimm-0 jimm %d audit - %s", os.Getpid(), expectedSyslogBody))
+}
@@ -0,0 +1,235 @@
+// Copyright 2024 Canonical.
+
+package auditsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// WebhookSinkParams holds the parameters for a WebhookSink.
+type WebhookSinkParams struct {
+	// URL is the URL batches of audit events are POSTed to. If the URL
+	// contains user information it is sent as HTTP basic authentication.
+	URL string
+
+	// Client is the HTTP client used to send requests. Defaults to a
+	// client with a 30 second timeout.
+	Client *http.Client
+
+	// BatchSize is the maximum number of events sent in a single
+	// request. Defaults to 100.
+	BatchSize int
+
+	// FlushInterval is the maximum time an event is buffered before it
+	// is sent. Defaults to 5 seconds.
+	FlushInterval time.Duration
+
+	// QueueSize is the maximum number of events waiting to be sent.
+	// Defaults to 10000.
+	QueueSize int
+
+	// DropWhenFull decides whether Write drops entries straight away
+	// when the queue is full instead of waiting for space.
+	DropWhenFull bool
+
+	// BlockTimeout is the maximum time Write waits for space in a full
+	// queue before the entry is dropped. Defaults to 10 seconds.
+	BlockTimeout time.Duration
+
+	// MaxRetries is the number of times a failed request is retried
+	// before the batch is dropped. Defaults to 5, a negative value
+	// disables retries.
+	MaxRetries int
+
+	// RetryBackoff is the time waited before the first retry, the wait
+	// doubles on each subsequent retry. Defaults to 1 second.
+	RetryBackoff time.Duration
+}
+
+// A WebhookSink POSTs batches of audit events, encoded as
+// apiparams.AuditEvents, to an HTTP endpoint. Events are queued and sent
+// in the background, failed requests are retried with exponential
+// backoff.
+type WebhookSink struct {
+	p     WebhookSinkParams
+	queue chan apiparams.AuditEvent
+
+	closeOnce sync.Once
+	closing   chan struct{}
+	done      chan struct{}
+}
+
+// NewWebhookSink returns a new sink that sends audit events to a webhook.
+// The sink sends events until it is closed or the given context is done.
+func NewWebhookSink(ctx context.Context, p WebhookSinkParams) *WebhookSink {
+	if p.Client == nil {
+		p.Client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if p.BatchSize <= 0 {
+		p.BatchSize = 100
+	}
+	if p.FlushInterval <= 0 {
+		p.FlushInterval = 5 * time.Second
+	}
+	if p.QueueSize <= 0 {
+		p.QueueSize = 10000
+	}
+	if p.BlockTimeout <= 0 {
+		p.BlockTimeout = defaultBlockTimeout
+	}
+	if p.MaxRetries < 0 {
+		p.MaxRetries = 0
+	} else if p.MaxRetries == 0 {
+		p.MaxRetries = 5
+	}
+	if p.RetryBackoff <= 0 {
+		p.RetryBackoff = time.Second
+	}
+	s := &WebhookSink{
+		p:       p,
+		queue:   make(chan apiparams.AuditEvent, p.QueueSize),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go s.run(ctx)
+	return s
+}
+
+// Write implements Sink. If the queue is full Write waits for space up to
+// the sink's block timeout, or drops the event straight away if the sink
+// drops events when full.
+func (s *WebhookSink) Write(ctx context.Context, ale *dbmodel.AuditLogEntry) error {
+	const op = errors.Op("auditsink.WebhookSink.Write")
+
+	select {
+	case <-s.closing:
+		return errors.E(op, "audit sink closed")
+	default:
+	}
+	if err := enqueue(ctx, s.queue, ale.ToAPIAuditEvent(), s.p.DropWhenFull, s.p.BlockTimeout, s.closing); err != nil {
+		servermon.AuditSinkDroppedCount.WithLabelValues("webhook").Inc()
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// Close implements Sink. Close sends any queued events before returning.
+func (s *WebhookSink) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.closing)
+	})
+	<-s.done
+	return nil
+}
+
+// run batches queued events and sends them to the webhook.
+func (s *WebhookSink) run(ctx context.Context) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.p.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]apiparams.AuditEvent, 0, s.p.BatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		s.send(ctx, batch)
+		batch = make([]apiparams.AuditEvent, 0, s.p.BatchSize)
+	}
+	for {
+		select {
+		case ev := <-s.queue:
+			batch = append(batch, ev)
+			if len(batch) >= s.p.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.closing:
+			// Drain whatever is left in the queue.
+			for {
+				select {
+				case ev := <-s.queue:
+					batch = append(batch, ev)
+					if len(batch) >= s.p.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// send sends a batch of events, retrying failed requests. If the batch
+// still cannot be sent once the retries are exhausted it is dropped.
+func (s *WebhookSink) send(ctx context.Context, batch []apiparams.AuditEvent) {
+	body, err := json.Marshal(apiparams.AuditEvents{Events: batch})
+	if err != nil {
+		zapctx.Error(ctx, "cannot marshal audit events", zap.Error(err))
+		servermon.AuditSinkDroppedCount.WithLabelValues("webhook").Add(float64(len(batch)))
+		return
+	}
+	backoff := s.p.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, body)
+		if err == nil {
+			return
+		}
+		servermon.AuditSinkErrorCount.WithLabelValues("webhook").Inc()
+		if !retry || attempt >= s.p.MaxRetries {
+			zapctx.Error(ctx, "dropping audit events", zap.Int("count", len(batch)), zap.Error(err))
+			servermon.AuditSinkDroppedCount.WithLabelValues("webhook").Add(float64(len(batch)))
+			return
+		}
+		zapctx.Warn(ctx, "failed to send audit events, retrying", zap.Duration("backoff", backoff), zap.Error(err))
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			servermon.AuditSinkDroppedCount.WithLabelValues("webhook").Add(float64(len(batch)))
+			return
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single request to the webhook. The returned bool reports
+// whether a failed request may be retried.
+func (s *WebhookSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.p.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.p.Client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return retry, errors.E(fmt.Sprintf("unexpected status %s", resp.Status))
+}
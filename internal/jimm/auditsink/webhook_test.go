@@ -0,0 +1,232 @@
+// Copyright 2024 Canonical.
+
+package auditsink_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// webhookServer records the batches of events it receives, failing the
+// first failures requests.
+type webhookServer struct {
+	mu       sync.Mutex
+	failures int
+	status   int
+	requests int
+	batches  [][]apiparams.AuditEvent
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(s.status)
+		return
+	}
+	var events apiparams.AuditEvents
+	if err := json.NewDecoder(req.Body).Decode(&events); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.batches = append(s.batches, events.Events)
+}
+
+func (s *webhookServer) messageIDs() [][]uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ids [][]uint64
+	for _, batch := range s.batches {
+		var batchIDs []uint64
+		for _, ev := range batch {
+			batchIDs = append(batchIDs, ev.MessageId)
+		}
+		ids = append(ids, batchIDs)
+	}
+	return ids
+}
+
+func TestWebhookSinkBatches(t *testing.T) {
+	c := qt.New(t)
+
+	ws := &webhookServer{}
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+		URL:           srv.URL,
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+	})
+	for i := uint64(1); i <= 5; i++ {
+		c.Assert(sink.Write(ctx, testEntry(i)), qt.IsNil)
+	}
+	// Closing the sink sends the partial batch.
+	c.Assert(sink.Close(), qt.IsNil)
+	c.Assert(ws.messageIDs(), qt.DeepEquals, [][]uint64{{1, 2}, {3, 4}, {5}})
+
+	err := sink.Write(ctx, testEntry(6))
+	c.Assert(err, qt.ErrorMatches, "audit sink closed")
+}
+
+func TestWebhookSinkFlushInterval(t *testing.T) {
+	c := qt.New(t)
+
+	ws := &webhookServer{}
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+		URL:           srv.URL,
+		FlushInterval: 10 * time.Millisecond,
+	})
+	defer sink.Close()
+	c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(ws.messageIDs()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(ws.messageIDs(), qt.DeepEquals, [][]uint64{{1}})
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	c := qt.New(t)
+
+	ws := &webhookServer{
+		failures: 2,
+		status:   http.StatusServiceUnavailable,
+	}
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+		URL:          srv.URL,
+		RetryBackoff: time.Millisecond,
+	})
+	c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+	c.Assert(sink.Close(), qt.IsNil)
+	c.Assert(ws.requests, qt.Equals, 3)
+	c.Assert(ws.messageIDs(), qt.DeepEquals, [][]uint64{{1}})
+}
+
+func TestWebhookSinkDoesNotRetryClientErrors(t *testing.T) {
+	c := qt.New(t)
+
+	ws := &webhookServer{
+		failures: 1,
+		status:   http.StatusUnauthorized,
+	}
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+		URL:          srv.URL,
+		RetryBackoff: time.Millisecond,
+	})
+	c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+	c.Assert(sink.Close(), qt.IsNil)
+	c.Assert(ws.requests, qt.Equals, 1)
+	c.Assert(ws.messageIDs(), qt.HasLen, 0)
+}
+
+func TestWebhookSinkQueueFull(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about        string
+		dropWhenFull bool
+		minWait      time.Duration
+	}{{
+		about:   "block",
+		minWait: 50 * time.Millisecond,
+	}, {
+		about:        "drop",
+		dropWhenFull: true,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			received := make(chan struct{}, 1)
+			block := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				received <- struct{}{}
+				<-block
+			}))
+			defer srv.Close()
+			defer close(block)
+
+			ctx := context.Background()
+			sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+				URL:          srv.URL,
+				BatchSize:    1,
+				QueueSize:    1,
+				DropWhenFull: test.dropWhenFull,
+				BlockTimeout: 50 * time.Millisecond,
+			})
+			// Once the first entry is being sent, the second fills the queue.
+			c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+			<-received
+			c.Assert(sink.Write(ctx, testEntry(2)), qt.IsNil)
+
+			start := time.Now()
+			err := sink.Write(ctx, testEntry(3))
+			c.Assert(err, qt.ErrorMatches, "audit sink queue full")
+			c.Assert(time.Since(start) >= test.minWait, qt.IsTrue)
+		})
+	}
+}
+
+func TestWebhookSinkBlocksUntilQueueHasSpace(t *testing.T) {
+	c := qt.New(t)
+
+	ws := &webhookServer{}
+	srv := httptest.NewServer(ws)
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink := auditsink.NewWebhookSink(ctx, auditsink.WebhookSinkParams{
+		URL:          srv.URL,
+		BatchSize:    1,
+		QueueSize:    1,
+		BlockTimeout: time.Minute,
+	})
+	for i := uint64(1); i <= 10; i++ {
+		c.Assert(sink.Write(ctx, testEntry(i)), qt.IsNil)
+	}
+	c.Assert(sink.Close(), qt.IsNil)
+	c.Assert(ws.messageIDs(), qt.DeepEquals, [][]uint64{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {8}, {9}, {10}})
+}
+
+func TestNewWebhookSinkRemovesQueueParameters(t *testing.T) {
+	c := qt.New(t)
+
+	queries := make(chan string, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		queries <- req.URL.RawQuery
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	sink, err := auditsink.New(ctx, srv.URL+"/audit?token=secret&queue-full=drop&block-timeout=1s")
+	c.Assert(err, qt.IsNil)
+	c.Assert(sink.Write(ctx, testEntry(1)), qt.IsNil)
+	c.Assert(sink.Close(), qt.IsNil)
+	c.Assert(<-queries, qt.Equals, "token=secret")
+}
@@ -0,0 +1,247 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/juju/charm/v12"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// charmOriginLocal is the charm origin reported for locally deployed
+// charms, whose revisions are not comparable with any other deployment.
+const charmOriginLocal = "local"
+
+// CharmReportArgs holds the arguments to CharmReport.
+type CharmReportArgs struct {
+	// Charm, if not empty, restricts the report to the charm with the
+	// given name.
+	Charm string
+
+	// DeprecatedChannels holds channels that applications should no
+	// longer be deployed from. An entry matches either a complete
+	// channel, such as "14/edge", or every risk in a track, such as "14".
+	DeprecatedChannels []string
+}
+
+// charmReportStatus holds the parts of a formatted model status used to
+// produce a charm report.
+type charmReportStatus struct {
+	Machines     map[string]charmReportMachine `json:"machines"`
+	Applications map[string]struct {
+		CharmName    string `json:"charm-name"`
+		CharmOrigin  string `json:"charm-origin"`
+		CharmRev     int    `json:"charm-rev"`
+		CharmChannel string `json:"charm-channel"`
+		Base         *struct {
+			Name    string `json:"name"`
+			Channel string `json:"channel"`
+		} `json:"base"`
+		Units map[string]struct {
+			Machine string `json:"machine"`
+		} `json:"units"`
+	} `json:"applications"`
+}
+
+// charmReportMachine holds the parts of a formatted machine status used
+// to find the architecture of the applications deployed to it.
+type charmReportMachine struct {
+	Hardware   string                        `json:"hardware"`
+	Containers map[string]charmReportMachine `json:"containers"`
+}
+
+// CharmReport reports every application deployed on every controller,
+// grouped by charm. Applications running an older revision of their
+// charm than the newest deployed anywhere in the fleet from the same
+// channel, base and architecture, or deployed from a deprecated channel,
+// are flagged. Only JIMM administrators can produce
+// a charm report.
+func (j *JIMM) CharmReport(ctx context.Context, user *openfga.User, args CharmReportArgs) (params.CharmReport, error) {
+	const op = errors.Op("jimm.CharmReport")
+
+	if !user.JimmAdmin {
+		return params.CharmReport{}, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var models []dbmodel.Model
+	err := j.Database.ForEachModel(ctx, func(m *dbmodel.Model) error {
+		models = append(models, *m)
+		return nil
+	})
+	if err != nil {
+		return params.CharmReport{}, errors.E(op, err)
+	}
+
+	sem := make(chan struct{}, queryModelsConcurrency)
+	controllerSems := controllerSemaphores(models)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	charms := make(map[string]*params.CharmReportCharm)
+	errs := make(map[string]string)
+	for _, model := range models {
+		wg.Add(1)
+		go func(model dbmodel.Model) {
+			defer wg.Done()
+			apps, err := j.charmReportApplications(ctx, model, args.Charm, controllerSems[model.ControllerID], sem)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[model.ResourceTag().String()] = err.Error()
+				return
+			}
+			for name, app := range apps {
+				charm := charms[name]
+				if charm == nil {
+					charm = &params.CharmReportCharm{Name: name}
+					charms[name] = charm
+				}
+				charm.Applications = append(charm.Applications, app...)
+			}
+		}(model)
+	}
+	wg.Wait()
+
+	report := params.CharmReport{
+		Charms: make([]params.CharmReportCharm, 0, len(charms)),
+	}
+	if len(errs) > 0 {
+		report.Errors = errs
+	}
+	for _, charm := range charms {
+		latest := make(map[string]int)
+		for _, app := range charm.Applications {
+			if app.Origin == charmOriginLocal {
+				continue
+			}
+			key := charmRevisionKey(app)
+			if app.Revision > latest[key] {
+				latest[key] = app.Revision
+			}
+		}
+		for i := range charm.Applications {
+			app := &charm.Applications[i]
+			if app.Origin != charmOriginLocal {
+				app.LatestRevision = latest[charmRevisionKey(*app)]
+				app.Outdated = app.Revision < app.LatestRevision
+			}
+			app.DeprecatedChannel = deprecatedChannel(app.Channel, args.DeprecatedChannels)
+		}
+		sort.Slice(charm.Applications, func(i, j int) bool {
+			a, b := charm.Applications[i], charm.Applications[j]
+			if a.Controller != b.Controller {
+				return a.Controller < b.Controller
+			}
+			if a.ModelName != b.ModelName {
+				return a.ModelName < b.ModelName
+			}
+			return a.Application < b.Application
+		})
+		report.Charms = append(report.Charms, *charm)
+	}
+	sort.Slice(report.Charms, func(i, j int) bool {
+		return report.Charms[i].Name < report.Charms[j].Name
+	})
+	return report, nil
+}
+
+// charmReportApplications returns the applications in the given model,
+// keyed by charm name. If charmName is not empty only applications
+// running that charm are returned.
+func (j *JIMM) charmReportApplications(ctx context.Context, model dbmodel.Model, charmName string, controllerSem, sem chan struct{}) (map[string][]params.CharmReportApplication, error) {
+	fb, err := j.cachedModelStatus(ctx, model, controllerSem, sem)
+	if err != nil {
+		return nil, err
+	}
+	var status charmReportStatus
+	if err := json.Unmarshal(fb, &status); err != nil {
+		return nil, err
+	}
+
+	apps := make(map[string][]params.CharmReportApplication)
+	for name, app := range status.Applications {
+		if charmName != "" && app.CharmName != charmName {
+			continue
+		}
+		reportApp := params.CharmReportApplication{
+			Controller:  model.Controller.Name,
+			ModelTag:    model.ResourceTag().String(),
+			ModelName:   model.Name,
+			Application: name,
+			Origin:      app.CharmOrigin,
+			Channel:     app.CharmChannel,
+			Revision:    app.CharmRev,
+		}
+		if app.Base != nil {
+			reportApp.Base = app.Base.Name + "@" + app.Base.Channel
+		}
+		units := make([]string, 0, len(app.Units))
+		for unit := range app.Units {
+			units = append(units, unit)
+		}
+		sort.Strings(units)
+		for _, unit := range units {
+			if arch := machineArch(status.Machines, app.Units[unit].Machine); arch != "" {
+				reportApp.Architecture = arch
+				break
+			}
+		}
+		apps[app.CharmName] = append(apps[app.CharmName], reportApp)
+	}
+	return apps, nil
+}
+
+// charmRevisionKey returns the key used to group applications whose
+// charm revisions can be compared. Charmhub numbers revisions
+// independently for each channel, base and architecture.
+func charmRevisionKey(app params.CharmReportApplication) string {
+	channel := app.Channel
+	if ch, err := charm.ParseChannelNormalize(channel); err == nil {
+		channel = ch.String()
+	}
+	return channel + " " + app.Base + " " + app.Architecture
+}
+
+// machineArch returns the architecture of the machine, or container,
+// with the given ID, or an empty string if it is not known.
+func machineArch(machines map[string]charmReportMachine, id string) string {
+	for machineID, m := range machines {
+		if machineID == id {
+			for _, field := range strings.Fields(m.Hardware) {
+				if arch, ok := strings.CutPrefix(field, "arch="); ok {
+					return arch
+				}
+			}
+			return ""
+		}
+		if arch := machineArch(m.Containers, id); arch != "" {
+			return arch
+		}
+	}
+	return ""
+}
+
+// deprecatedChannel reports whether the given channel matches any of the
+// deprecated channels, either completely or by track.
+func deprecatedChannel(channel string, deprecated []string) bool {
+	if channel == "" {
+		return false
+	}
+	track, _, _ := strings.Cut(channel, "/")
+	for _, d := range deprecated {
+		if d == channel || d == track {
+			return true
+		}
+	}
+	return false
+}
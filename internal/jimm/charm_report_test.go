@@ -0,0 +1,270 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const charmReportEnv = `
+clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 10000000-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 10000000-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  type: iaas
+  uuid: 20000000-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  type: iaas
+  uuid: 20000000-0000-0000-0000-000000000002
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  type: iaas
+  uuid: 20000000-0000-0000-0000-000000000003
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+`
+
+func charmReportAPI(applications map[string]jujuparams.ApplicationStatus, machines map[string]jujuparams.MachineStatus) *jimmtest.API {
+	status := getFullStatus("", applications, nil, nil)
+	if machines != nil {
+		status.Machines = machines
+	}
+	return &jimmtest.API{
+		Status_: func(context.Context, []string) (*jujuparams.FullStatus, error) {
+			return &status, nil
+		},
+		ListFilesystems_: func(context.Context, []string) ([]jujuparams.FilesystemDetailsListResult, error) {
+			return []jujuparams.FilesystemDetailsListResult{}, nil
+		},
+		ListVolumes_: func(context.Context, []string) ([]jujuparams.VolumeDetailsListResult, error) {
+			return []jujuparams.VolumeDetailsListResult{}, nil
+		},
+		ListStorageDetails_: func(context.Context) ([]jujuparams.StorageDetails, error) {
+			return []jujuparams.StorageDetails{}, nil
+		},
+	}
+}
+
+func TestCharmReport(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		Dialer: jimmtest.ModelDialerMap{
+			"20000000-0000-0000-0000-000000000001": &jimmtest.Dialer{
+				API: charmReportAPI(map[string]jujuparams.ApplicationStatus{
+					"db": {
+						Charm:        "ch:amd64/jammy/postgresql-42",
+						CharmChannel: "14/stable",
+						Base:         jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+						Units: map[string]jujuparams.UnitStatus{
+							"db/0": {Machine: "0"},
+						},
+					},
+					"db-edge": {
+						Charm:        "ch:amd64/jammy/postgresql-60",
+						CharmChannel: "14/edge",
+						Base:         jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+						Units: map[string]jujuparams.UnitStatus{
+							"db-edge/0": {Machine: "0/lxd/0"},
+						},
+					},
+					"app": {
+						Charm:        "ch:amd64/jammy/wordpress-7",
+						CharmChannel: "latest/stable",
+						Base:         jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+					},
+				}, map[string]jujuparams.MachineStatus{
+					"0": {
+						Hardware: "arch=amd64 cores=2",
+						Containers: map[string]jujuparams.MachineStatus{
+							"0/lxd/0": {Hardware: "arch=amd64"},
+						},
+					},
+				}),
+			},
+			"20000000-0000-0000-0000-000000000002": &jimmtest.Dialer{
+				API: charmReportAPI(map[string]jujuparams.ApplicationStatus{
+					"postgresql": {
+						Charm:        "ch:amd64/jammy/postgresql-45",
+						CharmChannel: "14/stable",
+						Base:         jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+						Units: map[string]jujuparams.UnitStatus{
+							"postgresql/0": {Machine: "0"},
+						},
+					},
+					"postgresql-arm": {
+						Charm:        "ch:arm64/jammy/postgresql-38",
+						CharmChannel: "14/stable",
+						Base:         jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+						Units: map[string]jujuparams.UnitStatus{
+							"postgresql-arm/0": {Machine: "1"},
+						},
+					},
+					"dev-db": {
+						Charm: "local:jammy/postgresql-99",
+						Base:  jujuparams.Base{Name: "ubuntu", Channel: "22.04"},
+					},
+				}, map[string]jujuparams.MachineStatus{
+					"0": {Hardware: "arch=amd64 cores=2"},
+					"1": {Hardware: "arch=arm64 cores=2"},
+				}),
+			},
+		},
+	}
+
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, charmReportEnv)
+	env.PopulateDB(c, j.Database)
+
+	user := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	user.JimmAdmin = true
+
+	report, err := j.CharmReport(ctx, user, jimm.CharmReportArgs{
+		DeprecatedChannels: []string{"14"},
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(report.Errors, qt.HasLen, 1)
+	c.Check(report.Errors["model-20000000-0000-0000-0000-000000000003"], qt.Not(qt.Equals), "")
+	c.Check(report.Charms, qt.DeepEquals, []params.CharmReportCharm{{
+		Name: "postgresql",
+		Applications: []params.CharmReportApplication{{
+			Controller:        "controller-1",
+			ModelTag:          "model-20000000-0000-0000-0000-000000000001",
+			ModelName:         "model-1",
+			Application:       "db",
+			Origin:            "charmhub",
+			Channel:           "14/stable",
+			Revision:          42,
+			Base:              "ubuntu@22.04",
+			Architecture:      "amd64",
+			LatestRevision:    45,
+			Outdated:          true,
+			DeprecatedChannel: true,
+		}, {
+			Controller:        "controller-1",
+			ModelTag:          "model-20000000-0000-0000-0000-000000000001",
+			ModelName:         "model-1",
+			Application:       "db-edge",
+			Origin:            "charmhub",
+			Channel:           "14/edge",
+			Revision:          60,
+			Base:              "ubuntu@22.04",
+			Architecture:      "amd64",
+			LatestRevision:    60,
+			DeprecatedChannel: true,
+		}, {
+			Controller:  "controller-2",
+			ModelTag:    "model-20000000-0000-0000-0000-000000000002",
+			ModelName:   "model-2",
+			Application: "dev-db",
+			Origin:      "local",
+			Revision:    99,
+			Base:        "ubuntu@22.04",
+		}, {
+			Controller:        "controller-2",
+			ModelTag:          "model-20000000-0000-0000-0000-000000000002",
+			ModelName:         "model-2",
+			Application:       "postgresql",
+			Origin:            "charmhub",
+			Channel:           "14/stable",
+			Revision:          45,
+			Base:              "ubuntu@22.04",
+			Architecture:      "amd64",
+			LatestRevision:    45,
+			DeprecatedChannel: true,
+		}, {
+			Controller:        "controller-2",
+			ModelTag:          "model-20000000-0000-0000-0000-000000000002",
+			ModelName:         "model-2",
+			Application:       "postgresql-arm",
+			Origin:            "charmhub",
+			Channel:           "14/stable",
+			Revision:          38,
+			Base:              "ubuntu@22.04",
+			Architecture:      "arm64",
+			LatestRevision:    38,
+			DeprecatedChannel: true,
+		}},
+	}, {
+		Name: "wordpress",
+		Applications: []params.CharmReportApplication{{
+			Controller:     "controller-1",
+			ModelTag:       "model-20000000-0000-0000-0000-000000000001",
+			ModelName:      "model-1",
+			Application:    "app",
+			Origin:         "charmhub",
+			Channel:        "latest/stable",
+			Revision:       7,
+			Base:           "ubuntu@22.04",
+			LatestRevision: 7,
+		}},
+	}})
+
+	report, err = j.CharmReport(ctx, user, jimm.CharmReportArgs{Charm: "wordpress"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(report.Charms, qt.HasLen, 1)
+	c.Check(report.Charms[0].Name, qt.Equals, "wordpress")
+}
+
+func TestCharmReportUnauthorized(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{}
+	user := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil)
+
+	_, err := j.CharmReport(context.Background(), user, jimm.CharmReportArgs{})
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+}
@@ -554,11 +554,15 @@ func (j *JIMM) handleModelDeltas(ctx context.Context, controller *dbmodel.Contro
 	w := &Watcher{
 		Database: j.Database,
 	}
+	inventory := make(inventoryBatch)
 	for _, d := range deltas {
-		if err := w.handleDelta(ctx, modelIDf, d); err != nil {
+		if err := w.handleDelta(ctx, modelIDf, inventory, d); err != nil {
 			return errors.E(op, err)
 		}
 	}
+	if err := w.updateInventory(ctx, inventory); err != nil {
+		return errors.E(op, err)
+	}
 	return nil
 }
 
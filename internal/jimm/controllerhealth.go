@@ -0,0 +1,279 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// controllerPingInterval is the interval at which the controller watcher
+// pings the controller it is watching.
+var controllerPingInterval = time.Minute
+
+// A controllerHealthRecorder records the health of a single controller as
+// seen by the controller watcher. Errors storing the health are logged
+// rather than interrupting the watcher.
+type controllerHealthRecorder struct {
+	database db.Database
+	name     string
+
+	mu     sync.Mutex
+	health dbmodel.ControllerHealth
+}
+
+// newControllerHealthRecorder returns a controllerHealthRecorder for the
+// given controller, starting from any health previously recorded.
+func newControllerHealthRecorder(ctx context.Context, database db.Database, ctl *dbmodel.Controller) *controllerHealthRecorder {
+	r := &controllerHealthRecorder{
+		database: database,
+		name:     ctl.Name,
+		health: dbmodel.ControllerHealth{
+			ControllerID: ctl.ID,
+		},
+	}
+	if err := database.GetControllerHealth(ctx, &r.health); err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		zapctx.Error(ctx, "cannot get controller health", zap.Error(err))
+	}
+	return r
+}
+
+// dialed records the result of an attempt to connect to the controller.
+func (r *controllerHealthRecorder) dialed(ctx context.Context, latency time.Duration, agentVersion string, err error) {
+	r.update(ctx, func(h *dbmodel.ControllerHealth) {
+		if err != nil {
+			h.WatcherState = dbmodel.WatcherStateStopped
+			recordControllerError(h, err)
+			return
+		}
+		h.WatcherState = dbmodel.WatcherStateConnecting
+		h.AgentVersion = agentVersion
+		h.DialLatency = latency
+		h.LastDial = db.Now()
+		recordControllerSuccess(h)
+	})
+}
+
+// watching records that the watcher has started watching the controller.
+func (r *controllerHealthRecorder) watching(ctx context.Context) {
+	r.update(ctx, func(h *dbmodel.ControllerHealth) {
+		h.WatcherState = dbmodel.WatcherStateWatching
+	})
+}
+
+// pinged records the result of a ping of the controller.
+func (r *controllerHealthRecorder) pinged(ctx context.Context, latency time.Duration, err error) {
+	r.update(ctx, func(h *dbmodel.ControllerHealth) {
+		if err != nil {
+			recordControllerError(h, err)
+			return
+		}
+		h.PingLatency = latency
+		h.LastPing = db.Now()
+		recordControllerSuccess(h)
+	})
+}
+
+// stopped records that the watcher has stopped, with the given error.
+func (r *controllerHealthRecorder) stopped(ctx context.Context, err error) {
+	r.update(ctx, func(h *dbmodel.ControllerHealth) {
+		h.WatcherState = dbmodel.WatcherStateStopped
+		if err != nil && ctx.Err() == nil {
+			recordControllerError(h, err)
+		}
+	})
+}
+
+// update applies f to the recorded health and stores the result. If the
+// watcher state or the health of the controller changes an event is also
+// recorded.
+func (r *controllerHealthRecorder) update(ctx context.Context, f func(*dbmodel.ControllerHealth)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, healthy := r.health.WatcherState, r.health.Healthy()
+	f(&r.health)
+	r.health.UpdatedAt = db.Now().Time
+
+	// The health is stored even when the watcher is being shut down.
+	ctx = context.WithoutCancel(ctx)
+	if err := r.database.UpsertControllerHealth(ctx, &r.health); err != nil {
+		if r.removed(ctx) {
+			// The controller has been removed, don't bring back the
+			// metrics that were deleted with it.
+			deleteControllerMetrics(r.name)
+			return
+		}
+		zapctx.Error(ctx, "cannot update controller health", zap.Error(err))
+	}
+	if r.health.WatcherState != state || r.health.Healthy() != healthy {
+		event := dbmodel.ControllerHealthEvent{
+			ControllerID: r.health.ControllerID,
+			WatcherState: r.health.WatcherState,
+			Healthy:      r.health.Healthy(),
+		}
+		if r.health.ErrorStreak > 0 {
+			event.Error = r.health.LastError
+		}
+		if err := r.database.AddControllerHealthEvent(ctx, &event); err != nil {
+			zapctx.Error(ctx, "cannot add controller health event", zap.Error(err))
+		}
+	}
+
+	if r.health.Healthy() {
+		servermon.ControllerHealthy.WithLabelValues(r.name).Set(1)
+	} else {
+		servermon.ControllerHealthy.WithLabelValues(r.name).Set(0)
+	}
+	servermon.ControllerErrorStreak.WithLabelValues(r.name).Set(float64(r.health.ErrorStreak))
+	servermon.ControllerDialLatency.WithLabelValues(r.name).Set(r.health.DialLatency.Seconds())
+	if r.health.LastPing.Valid {
+		servermon.ControllerLastPing.WithLabelValues(r.name).Set(float64(r.health.LastPing.Time.Unix()))
+	}
+}
+
+// removed reports whether the controller has been removed from the
+// database.
+func (r *controllerHealthRecorder) removed(ctx context.Context) bool {
+	ctl := dbmodel.Controller{Name: r.name}
+	return errors.ErrorCode(r.database.GetController(ctx, &ctl)) == errors.CodeNotFound
+}
+
+// deleteControllerMetrics deletes the health metrics recorded for the
+// named controller.
+func deleteControllerMetrics(name string) {
+	servermon.ControllerHealthy.DeleteLabelValues(name)
+	servermon.ControllerErrorStreak.DeleteLabelValues(name)
+	servermon.ControllerDialLatency.DeleteLabelValues(name)
+	servermon.ControllerLastPing.DeleteLabelValues(name)
+}
+
+// recordControllerError updates the given health with a failure to
+// contact the controller.
+func recordControllerError(h *dbmodel.ControllerHealth, err error) {
+	h.ErrorStreak++
+	if !h.DegradedSince.Valid {
+		h.DegradedSince = db.Now()
+	}
+	h.LastError = err.Error()
+}
+
+// recordControllerSuccess updates the given health with a successful
+// contact with the controller, ending any error streak.
+func recordControllerSuccess(h *dbmodel.ControllerHealth) {
+	h.ErrorStreak = 0
+	h.DegradedSince = sql.NullTime{}
+}
+
+// pingController pings the controller using the given API connection at
+// controllerPingInterval until the given context is canceled.
+func pingController(ctx context.Context, api API, r *controllerHealthRecorder) {
+	ticker := time.NewTicker(controllerPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		start := time.Now()
+		err := api.Ping(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		r.pinged(ctx, time.Since(start), err)
+	}
+}
+
+// ControllerHealthReports returns the health of every controller along
+// with, at most, the given number of its most recent health events.
+func (j *JIMM) ControllerHealthReports(ctx context.Context, history int) ([]params.ControllerHealthReport, error) {
+	const op = errors.Op("jimm.ControllerHealthReports")
+
+	healthByID, err := j.controllerHealthByID(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var controllers []dbmodel.Controller
+	err = j.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+		if h, ok := healthByID[ctl.ID]; ok {
+			ctl.Health = &h
+		}
+		controllers = append(controllers, *ctl)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	historyByID := make(map[uint][]params.ControllerHealthEvent)
+	if history > 0 {
+		events, err := j.Database.FindRecentControllerHealthEvents(ctx, history)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		for _, e := range events {
+			historyByID[e.ControllerID] = append(historyByID[e.ControllerID], e.ToAPIControllerHealthEvent())
+		}
+	}
+
+	reports := make([]params.ControllerHealthReport, 0, len(controllers))
+	for _, ctl := range controllers {
+		info := ctl.ToAPIControllerInfo()
+		report := params.ControllerHealthReport{
+			Name:    ctl.Name,
+			UUID:    ctl.UUID,
+			Status:  string(info.Status.Status),
+			Health:  info.Health,
+			History: historyByID[ctl.ID],
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// PruneControllerHealthEvents deletes the controller health events
+// recorded more than the given retention period ago. The events are
+// pruned once a day, at the same time as the audit log is cleaned up,
+// until the given context is done.
+func (j *JIMM) PruneControllerHealthEvents(ctx context.Context, retention time.Duration) {
+	for {
+		select {
+		case <-time.After(calculateNextPollDuration(time.Now().UTC())):
+			deleted, err := j.Database.DeleteControllerHealthEventsBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				zapctx.Error(ctx, "failed to prune controller health events", zap.Error(err))
+				continue
+			}
+			zapctx.Debug(ctx, "controller health events pruned", zap.Int64("count", deleted))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// controllerHealthByID returns the recorded health of every controller
+// keyed by controller ID.
+func (j *JIMM) controllerHealthByID(ctx context.Context) (map[uint]dbmodel.ControllerHealth, error) {
+	health, err := j.Database.ListControllerHealth(ctx)
+	if err != nil {
+		return nil, err
+	}
+	healthByID := make(map[uint]dbmodel.ControllerHealth, len(health))
+	for _, h := range health {
+		healthByID[h.ControllerID] = h
+	}
+	return healthByID, nil
+}
@@ -0,0 +1,530 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const (
+	// DrainStatePlanned is the state of a drain that has only been
+	// planned, see DrainControllerArgs.DryRun.
+	DrainStatePlanned = "planned"
+
+	// DrainStateRunning is the state of a drain that is migrating models.
+	DrainStateRunning = dbmodel.DrainStateRunning
+
+	// DrainStatePaused is the state of a drain that will not start any
+	// new model migrations until it is resumed.
+	DrainStatePaused = dbmodel.DrainStatePaused
+
+	// DrainStateAborted is the state of a drain that has been aborted.
+	// Migrations that were in progress when the drain was aborted are
+	// not stopped.
+	DrainStateAborted = dbmodel.DrainStateAborted
+
+	// DrainStateDone is the state of a drain that has attempted to
+	// migrate all of its models.
+	DrainStateDone = dbmodel.DrainStateDone
+)
+
+const (
+	// DrainModelPending is the status of a model that is waiting to be
+	// migrated.
+	DrainModelPending = dbmodel.DrainModelPending
+
+	// DrainModelMigrating is the status of a model that is being
+	// migrated.
+	DrainModelMigrating = dbmodel.DrainModelMigrating
+
+	// DrainModelDone is the status of a model that has been migrated.
+	DrainModelDone = dbmodel.DrainModelDone
+
+	// DrainModelFailed is the status of a model whose migration failed.
+	DrainModelFailed = dbmodel.DrainModelFailed
+
+	// DrainModelSkipped is the status of a model that will not be
+	// migrated, for example because there is no suitable target
+	// controller.
+	DrainModelSkipped = dbmodel.DrainModelSkipped
+)
+
+var (
+	// drainPollInterval is the interval at which a drain checks the
+	// progress of the model migrations it has started. It is a variable
+	// so it can be replaced in tests.
+	drainPollInterval = 10 * time.Second
+)
+
+// DrainControllerArgs contains parameters used to drain a controller.
+type DrainControllerArgs struct {
+	// Concurrency is the maximum number of models that will be migrating
+	// at any one time. Values less than 1 are treated as 1.
+	Concurrency int
+
+	// DryRun, if true, only plans where each model would be migrated to
+	// without changing anything.
+	DryRun bool
+}
+
+// A ControllerDrain reports the progress of draining all models off a
+// controller.
+type ControllerDrain struct {
+	// Controller is the name of the controller being drained.
+	Controller string
+
+	// State is the state of the drain, see the DrainState constants.
+	State string
+
+	// Models holds the progress of each model on the controller.
+	Models []DrainModel
+}
+
+// A DrainModel reports the progress of migrating a single model as part
+// of draining a controller.
+type DrainModel struct {
+	// ModelTag is the tag of the model.
+	ModelTag names.ModelTag
+
+	// ModelName is the name of the model.
+	ModelName string
+
+	// TargetController is the name of the controller the model is
+	// migrated to.
+	TargetController string
+
+	// Status is the status of the model's migration, see the
+	// DrainModel constants.
+	Status string
+
+	// Error holds the reason a model was skipped or failed.
+	Error string
+}
+
+// DrainController migrates every model off the named controller. A target
+// controller is first selected for each model using the same cloud-region
+// selection as AddModel, then the controller is marked as deprecated so
+// that no new models are placed on it. The drain is recorded in the
+// database and the models are migrated by RunControllerDrains, which runs
+// on the leader JIMM replica, with at most args.Concurrency migrations in
+// progress at any one time. The returned ControllerDrain reports the
+// initial plan, use ControllerDrainStatus to follow the progress of the
+// drain. Migrations are tracked to completion by Watcher.WatchMigrations.
+// Only JIMM administrators can drain a controller.
+func (j *JIMM) DrainController(ctx context.Context, user *openfga.User, controllerName string, args DrainControllerArgs) (*ControllerDrain, error) {
+	const op = errors.Op("jimm.DrainController")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	ctl := dbmodel.Controller{
+		Name: controllerName,
+	}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	if !args.DryRun {
+		d := dbmodel.ControllerDrain{ControllerID: ctl.ID}
+		err := j.Database.GetControllerDrain(ctx, &d)
+		if err == nil && drainActive(&d) {
+			return nil, errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("controller %q is already being drained", controllerName))
+		}
+		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+			return nil, errors.E(op, err)
+		}
+	}
+
+	models, err := j.planDrain(ctx, ctl)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	if args.DryRun {
+		return &ControllerDrain{
+			Controller: controllerName,
+			State:      DrainStatePlanned,
+			Models:     models,
+		}, nil
+	}
+
+	// Only deprecate the controller once the drain has been planned so
+	// that a failure to plan leaves the controller unchanged.
+	if err := j.SetControllerDeprecated(ctx, user, controllerName, true); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	concurrency := args.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	d := dbmodel.ControllerDrain{
+		ControllerID: ctl.ID,
+		Controller:   ctl,
+		State:        DrainStateRunning,
+		Concurrency:  concurrency,
+		InitiatedBy:  user.Name,
+		Models:       make([]dbmodel.ControllerDrainModel, len(models)),
+	}
+	for i, m := range models {
+		d.Models[i] = dbmodel.ControllerDrainModel{
+			ModelUUID:        m.ModelTag.Id(),
+			ModelName:        m.ModelName,
+			TargetController: m.TargetController,
+			Status:           m.Status,
+			Error:            m.Error,
+		}
+	}
+	if err := j.Database.AddControllerDrain(ctx, &d); err != nil {
+		if errors.ErrorCode(err) == errors.CodeAlreadyExists {
+			return nil, errors.E(op, errors.CodeAlreadyExists, fmt.Sprintf("controller %q is already being drained", controllerName))
+		}
+		return nil, errors.E(op, err)
+	}
+	return toControllerDrain(&d), nil
+}
+
+// ControllerDrainStatus returns the progress of the most recent drain of
+// the named controller. Only JIMM administrators can view the progress of
+// a drain.
+func (j *JIMM) ControllerDrainStatus(ctx context.Context, user *openfga.User, controllerName string) (*ControllerDrain, error) {
+	const op = errors.Op("jimm.ControllerDrainStatus")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	d, err := j.getControllerDrain(ctx, controllerName)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return toControllerDrain(d), nil
+}
+
+// SetControllerDrainState pauses, resumes or aborts the drain of the
+// named controller. The state must be one of DrainStatePaused,
+// DrainStateRunning or DrainStateAborted. The leader JIMM replica acts on
+// the new state the next time it polls the drain. Only JIMM
+// administrators can change the state of a drain.
+func (j *JIMM) SetControllerDrainState(ctx context.Context, user *openfga.User, controllerName string, state string) (*ControllerDrain, error) {
+	const op = errors.Op("jimm.SetControllerDrainState")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	switch state {
+	case DrainStatePaused, DrainStateRunning, DrainStateAborted:
+	default:
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid drain state %q", state))
+	}
+
+	d, err := j.getControllerDrain(ctx, controllerName)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := j.Database.UpdateControllerDrainState(ctx, d, state, DrainStateRunning, DrainStatePaused); err != nil {
+		if errors.ErrorCode(err) != errors.CodeNotFound {
+			return nil, errors.E(op, err)
+		}
+		// The drain has finished, possibly since it was fetched.
+		if err := j.Database.GetControllerDrain(ctx, d); err != nil {
+			return nil, errors.E(op, err)
+		}
+		return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("drain is %s", d.State))
+	}
+	return toControllerDrain(d), nil
+}
+
+// getControllerDrain returns the most recent drain of the named
+// controller.
+func (j *JIMM) getControllerDrain(ctx context.Context, controllerName string) (*dbmodel.ControllerDrain, error) {
+	ctl := dbmodel.Controller{
+		Name: controllerName,
+	}
+	if err := j.Database.GetController(ctx, &ctl); err != nil {
+		return nil, err
+	}
+	d := dbmodel.ControllerDrain{ControllerID: ctl.ID}
+	if err := j.Database.GetControllerDrain(ctx, &d); err != nil {
+		if errors.ErrorCode(err) == errors.CodeNotFound {
+			return nil, errors.E(errors.CodeNotFound, fmt.Sprintf("controller %q is not being drained", controllerName))
+		}
+		return nil, err
+	}
+	return &d, nil
+}
+
+// planDrain selects the target controller for every model hosted on the
+// given controller.
+func (j *JIMM) planDrain(ctx context.Context, ctl dbmodel.Controller) ([]DrainModel, error) {
+	models, err := j.Database.GetModelsByController(ctx, ctl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the clouds as most models on a controller will share a
+	// small number of clouds.
+	clouds := make(map[string]*dbmodel.Cloud)
+	plan := make([]DrainModel, len(models))
+	for i := range models {
+		m := models[i]
+		// GetModelsByController does not load the model's
+		// associations.
+		if err := j.Database.GetModel(ctx, &m); err != nil {
+			return nil, err
+		}
+		plan[i] = DrainModel{
+			ModelTag:  m.ResourceTag(),
+			ModelName: m.Name,
+			Status:    DrainModelPending,
+		}
+		if m.MigrationControllerID.Valid {
+			plan[i].Status = DrainModelSkipped
+			plan[i].Error = "model is already migrating"
+			continue
+		}
+
+		cloudName := m.CloudRegion.Cloud.Name
+		cloud, ok := clouds[cloudName]
+		if !ok {
+			cloud = &dbmodel.Cloud{Name: cloudName}
+			if err := j.Database.GetCloud(ctx, cloud); err != nil {
+				return nil, err
+			}
+			clouds[cloudName] = cloud
+		}
+		target, err := j.selectDrainTarget(ctx, cloud, m.CloudRegionID, ctl.ID)
+		if err != nil {
+			plan[i].Status = DrainModelSkipped
+			plan[i].Error = err.Error()
+			continue
+		}
+		plan[i].TargetController = target.Name
+	}
+	return plan, nil
+}
+
+// selectDrainTarget selects the controller, other than the one being
+// drained, that should host a model in the given cloud region.
+func (j *JIMM) selectDrainTarget(ctx context.Context, cloud *dbmodel.Cloud, cloudRegionID, drainingControllerID uint) (*dbmodel.Controller, error) {
+	for _, r := range cloud.Regions {
+		if r.ID != cloudRegionID {
+			continue
+		}
+		var controllers []dbmodel.CloudRegionControllerPriority
+		for _, rc := range r.Controllers {
+			if rc.ControllerID != drainingControllerID {
+				controllers = append(controllers, rc)
+			}
+		}
+		if len(controllers) == 0 {
+			return nil, errors.E(errors.CodeNotFound, fmt.Sprintf("no other controller hosts cloud region %s/%s", cloud.Name, r.Name))
+		}
+		rc, err := j.selectRegionController(ctx, controllers)
+		if err != nil {
+			return nil, err
+		}
+		return &rc.Controller, nil
+	}
+	return nil, errors.E(errors.CodeNotFound, "cloudregion not found")
+}
+
+// RunControllerDrains progresses every controller drain that is running
+// or paused, checking each drain every poll interval. It must only run on
+// the leader JIMM replica. RunControllerDrains finishes when the given
+// context is canceled.
+func (j *JIMM) RunControllerDrains(ctx context.Context) {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		var drains []dbmodel.ControllerDrain
+		err := j.Database.ForEachControllerDrain(ctx, []string{DrainStateRunning, DrainStatePaused}, func(d *dbmodel.ControllerDrain) error {
+			drains = append(drains, *d)
+			return nil
+		})
+		if err != nil {
+			zapctx.Error(ctx, "cannot list controller drains", zap.Error(err))
+		}
+		for i := range drains {
+			if err := j.stepDrain(ctx, &drains[i]); err != nil {
+				zapctx.Error(ctx, "cannot progress controller drain", zap.String("controller", drains[i].Controller.Name), zap.Error(err))
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// stepDrain updates the progress of the models that the given drain is
+// migrating and, if the drain is running, starts migrating pending models
+// up to the drain's concurrency. A running drain with no models left to
+// migrate is marked as done.
+func (j *JIMM) stepDrain(ctx context.Context, d *dbmodel.ControllerDrain) error {
+	ctx = zapctx.WithFields(ctx, zap.String("controller", d.Controller.Name))
+	if err := j.Database.GetControllerDrain(ctx, d); err != nil {
+		return err
+	}
+	if !drainActive(d) {
+		return nil
+	}
+
+	migrating := 0
+	for i := range d.Models {
+		m := &d.Models[i]
+		if m.Status != DrainModelMigrating {
+			continue
+		}
+		if err := j.checkDrainModel(ctx, m); err != nil {
+			return err
+		}
+		if m.Status == DrainModelMigrating {
+			migrating++
+		}
+	}
+
+	if d.State != DrainStateRunning {
+		return nil
+	}
+	var user *openfga.User
+	pending := 0
+	for i := range d.Models {
+		m := &d.Models[i]
+		if m.Status != DrainModelPending {
+			continue
+		}
+		if migrating >= d.Concurrency {
+			pending++
+			continue
+		}
+		if user == nil {
+			var err error
+			if user, err = j.drainUser(ctx, d); err != nil {
+				return err
+			}
+		}
+		if err := j.startDrainModel(ctx, user, m); err != nil {
+			return err
+		}
+		if m.Status == DrainModelMigrating {
+			migrating++
+		}
+	}
+	if migrating > 0 || pending > 0 {
+		return nil
+	}
+	err := j.Database.UpdateControllerDrainState(ctx, d, DrainStateDone, DrainStateRunning)
+	if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return err
+	}
+	if err == nil {
+		zapctx.Info(ctx, "controller drain finished")
+	}
+	return nil
+}
+
+// drainUser returns the identity that started the given drain, model
+// migrations are initiated as this identity.
+func (j *JIMM) drainUser(ctx context.Context, d *dbmodel.ControllerDrain) (*openfga.User, error) {
+	identity, err := dbmodel.NewIdentity(d.InitiatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := j.Database.FetchIdentity(ctx, identity); err != nil {
+		return nil, err
+	}
+	if identity.Disabled {
+		return nil, errors.E(errors.CodeUnauthorized, fmt.Sprintf("identity %q disabled", identity.Name))
+	}
+	return openfga.NewUser(identity, j.OpenFGAClient), nil
+}
+
+// startDrainModel initiates the migration of the given model and records
+// the ID of the migration record created for it.
+func (j *JIMM) startDrainModel(ctx context.Context, user *openfga.User, m *dbmodel.ControllerDrainModel) error {
+	ctx = zapctx.WithFields(ctx, zap.String("model-uuid", m.ModelUUID))
+	_, migration, err := j.initiateInternalMigration(ctx, user, names.NewModelTag(m.ModelUUID), m.TargetController)
+	switch {
+	case err != nil:
+		zapctx.Warn(ctx, "cannot migrate model", zap.Error(err))
+		m.Status = DrainModelFailed
+		m.Error = err.Error()
+	case migration.ID == 0:
+		m.Status = DrainModelFailed
+		m.Error = "migration started but could not be recorded"
+	default:
+		m.Status = DrainModelMigrating
+		//nolint:gosec // Migration IDs are expected to fit into int64.
+		m.MigrationID = sql.NullInt64{Int64: int64(migration.ID), Valid: true}
+	}
+	return j.Database.UpdateControllerDrainModel(ctx, m)
+}
+
+// checkDrainModel updates the status of a migrating model from the
+// migration record the drain created for it.
+func (j *JIMM) checkDrainModel(ctx context.Context, m *dbmodel.ControllerDrainModel) error {
+	var migration *dbmodel.ModelMigration
+	if m.MigrationID.Valid {
+		//nolint:gosec // Migration IDs are never negative.
+		err := j.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ID: uint(m.MigrationID.Int64)}, func(mm *dbmodel.ModelMigration) error {
+			migration = mm
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	switch {
+	case migration == nil:
+		m.Status = DrainModelFailed
+		m.Error = "migration record not found"
+	case migration.Phase == dbmodel.MigrationPhaseRunning:
+		return nil
+	case migration.Phase == dbmodel.MigrationPhaseDone:
+		m.Status = DrainModelDone
+	default:
+		m.Status = DrainModelFailed
+		m.Error = fmt.Sprintf("migration %s: %s", migration.Phase, migration.Error)
+	}
+	return j.Database.UpdateControllerDrainModel(ctx, m)
+}
+
+// drainActive returns whether the given drain is running or paused.
+func drainActive(d *dbmodel.ControllerDrain) bool {
+	return d.State == DrainStateRunning || d.State == DrainStatePaused
+}
+
+// toControllerDrain converts a stored drain to a ControllerDrain.
+func toControllerDrain(d *dbmodel.ControllerDrain) *ControllerDrain {
+	cd := ControllerDrain{
+		Controller: d.Controller.Name,
+		State:      d.State,
+		Models:     make([]DrainModel, len(d.Models)),
+	}
+	for i, m := range d.Models {
+		cd.Models[i] = DrainModel{
+			ModelTag:         names.NewModelTag(m.ModelUUID),
+			ModelName:        m.ModelName,
+			TargetController: m.TargetController,
+			Status:           m.Status,
+			Error:            m.Error,
+		}
+	}
+	return &cd
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func TestToControllerDrain(t *testing.T) {
+	c := qt.New(t)
+
+	d := dbmodel.ControllerDrain{
+		Controller: dbmodel.Controller{Name: "controller-1"},
+		State:      DrainStatePaused,
+		Models: []dbmodel.ControllerDrainModel{{
+			ModelUUID:        "00000002-0000-0000-0000-000000000001",
+			ModelName:        "model-1",
+			TargetController: "controller-2",
+			Status:           DrainModelMigrating,
+		}, {
+			ModelUUID: "00000002-0000-0000-0000-000000000002",
+			ModelName: "model-2",
+			Status:    DrainModelSkipped,
+			Error:     "model is already migrating",
+		}},
+	}
+	c.Check(drainActive(&d), qt.IsTrue)
+	c.Check(toControllerDrain(&d), qt.CmpEquals(cmp.Comparer(func(a, b names.ModelTag) bool { return a == b })), &ControllerDrain{
+		Controller: "controller-1",
+		State:      DrainStatePaused,
+		Models: []DrainModel{{
+			ModelTag:         names.NewModelTag("00000002-0000-0000-0000-000000000001"),
+			ModelName:        "model-1",
+			TargetController: "controller-2",
+			Status:           DrainModelMigrating,
+		}, {
+			ModelTag:  names.NewModelTag("00000002-0000-0000-0000-000000000002"),
+			ModelName: "model-2",
+			Status:    DrainModelSkipped,
+			Error:     "model is already migrating",
+		}},
+	})
+
+	for _, state := range []string{DrainStateAborted, DrainStateDone} {
+		d.State = state
+		c.Check(drainActive(&d), qt.IsFalse)
+	}
+}
@@ -0,0 +1,324 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/uuid"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+const drainControllerTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+  - name: other-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-cloud-region
+    priority: 1
+  - cloud: test-cloud
+    region: other-region
+    priority: 1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-cloud-region
+    priority: 2
+- name: controller-3
+  uuid: 00000001-0000-0000-0000-000000000003
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-regions:
+  - cloud: test-cloud
+    region: test-cloud-region
+    priority: 1
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-2
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-1
+  cloud: test-cloud
+  region: other-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-3
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  migration-controller: controller-3
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+- name: model-4
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000004
+  controller: controller-2
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+users:
+- username: alice@canonical.com
+  controller-access: superuser
+`
+
+// drainModelsEqual compares drain models, including their model tags.
+var drainModelsEqual = qt.CmpEquals(cmp.Comparer(func(a, b names.ModelTag) bool { return a == b }))
+
+func TestDrainController(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	c.Patch(jimm.DrainPollInterval, time.Millisecond)
+	c.Patch(jimm.InitiateMigration, func(ctx context.Context, j *jimm.JIMM, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error) {
+		return jujuparams.InitiateMigrationResult{ModelTag: spec.ModelTag}, nil
+	})
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	for _, name := range []string{"controller-2", "controller-3"} {
+		err := store.PutControllerCredentials(ctx, name, "admin", "test-secret")
+		c.Assert(err, qt.IsNil)
+	}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		CredentialStore: store,
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env := jimmtest.ParseEnvironment(c, drainControllerTestEnv)
+	env.PopulateDB(c, j.Database)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, nil)
+	user.JimmAdmin = true
+
+	expectPlan := map[string]jimm.DrainModel{
+		"model-1": {
+			ModelName:        "model-1",
+			TargetController: "controller-2",
+			Status:           jimm.DrainModelPending,
+		},
+		"model-2": {
+			ModelName: "model-2",
+			Status:    jimm.DrainModelSkipped,
+			Error:     "no other controller hosts cloud region test-cloud/other-region",
+		},
+		"model-3": {
+			ModelName: "model-3",
+			Status:    jimm.DrainModelSkipped,
+			Error:     "model is already migrating",
+		},
+	}
+	modelsByName := func(models []jimm.DrainModel) map[string]jimm.DrainModel {
+		m := make(map[string]jimm.DrainModel)
+		for _, dm := range models {
+			dm.ModelTag = env.Model("alice@canonical.com", dm.ModelName).DBObject(c, j.Database).ResourceTag()
+			m[dm.ModelName] = dm
+		}
+		return m
+	}
+	for name, dm := range expectPlan {
+		dm.ModelTag = env.Model("alice@canonical.com", name).DBObject(c, j.Database).ResourceTag()
+		expectPlan[name] = dm
+	}
+
+	// A dry run doesn't change anything.
+	plan, err := j.DrainController(ctx, user, "controller-1", jimm.DrainControllerArgs{DryRun: true})
+	c.Assert(err, qt.IsNil)
+	c.Check(plan.State, qt.Equals, jimm.DrainStatePlanned)
+	c.Check(modelsByName(plan.Models), drainModelsEqual, expectPlan)
+	ctl := dbmodel.Controller{Name: "controller-1"}
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.Deprecated, qt.IsFalse)
+	_, err = j.ControllerDrainStatus(ctx, user, "controller-1")
+	c.Check(err, qt.ErrorMatches, `controller "controller-1" is not being drained`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	// A finished migration record that the drain didn't create, which
+	// is the most recent for the model, doesn't affect the drain.
+	model1 := env.Model("alice@canonical.com", "model-1").DBObject(c, j.Database)
+	ctl2 := dbmodel.Controller{Name: "controller-2"}
+	err = j.Database.GetController(ctx, &ctl2)
+	c.Assert(err, qt.IsNil)
+	err = j.Database.AddModelMigration(ctx, &dbmodel.ModelMigration{
+		ModelID:            model1.ID,
+		SourceControllerID: ctl.ID,
+		TargetControllerID: ctl2.ID,
+		Phase:              dbmodel.MigrationPhaseDone,
+		StartedAt:          time.Now().Add(time.Hour),
+		FinishedAt:         sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+	})
+	c.Assert(err, qt.IsNil)
+
+	drain, err := j.DrainController(ctx, user, "controller-1", jimm.DrainControllerArgs{Concurrency: 2})
+	c.Assert(err, qt.IsNil)
+	c.Check(drain.State, qt.Equals, jimm.DrainStateRunning)
+	c.Check(modelsByName(drain.Models), drainModelsEqual, expectPlan)
+	err = j.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+	c.Check(ctl.Deprecated, qt.IsTrue)
+
+	_, err = j.DrainController(ctx, user, "controller-1", jimm.DrainControllerArgs{})
+	c.Check(err, qt.ErrorMatches, `controller "controller-1" is already being drained`)
+
+	// The drain is stored in the database, so another JIMM replica
+	// sharing it can report and control the drain.
+	other := &jimm.JIMM{
+		UUID:     j.UUID,
+		Database: j.Database,
+	}
+	drain, err = other.SetControllerDrainState(ctx, user, "controller-1", jimm.DrainStatePaused)
+	c.Assert(err, qt.IsNil)
+	c.Check(drain.State, qt.Equals, jimm.DrainStatePaused)
+
+	// A paused drain doesn't start any migrations.
+	workerCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go j.RunControllerDrains(workerCtx)
+	time.Sleep(50 * time.Millisecond)
+	var migrations []*dbmodel.ModelMigration
+	err = j.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model1.ID, Active: true}, func(m *dbmodel.ModelMigration) error {
+		migrations = append(migrations, m)
+		return nil
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(migrations, qt.HasLen, 0)
+
+	_, err = other.SetControllerDrainState(ctx, user, "controller-1", jimm.DrainStateRunning)
+	c.Assert(err, qt.IsNil)
+
+	// Wait for the migration to be recorded and then complete it, as
+	// the migration watcher would.
+	var migration *dbmodel.ModelMigration
+	for i := 0; migration == nil && i < 500; i++ {
+		time.Sleep(10 * time.Millisecond)
+		err = j.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model1.ID, Active: true}, func(m *dbmodel.ModelMigration) error {
+			migration = m
+			return nil
+		})
+		c.Assert(err, qt.IsNil)
+	}
+	c.Assert(migration, qt.Not(qt.IsNil))
+	c.Check(migration.TargetController.Name, qt.Equals, "controller-2")
+
+	drain, err = other.ControllerDrainStatus(ctx, user, "controller-1")
+	c.Assert(err, qt.IsNil)
+	c.Check(drain.State, qt.Equals, jimm.DrainStateRunning)
+	c.Check(modelsByName(drain.Models)["model-1"].Status, qt.Equals, jimm.DrainModelMigrating)
+
+	migration.Phase = dbmodel.MigrationPhaseDone
+	migration.FinishedAt = sql.NullTime{Time: time.Now(), Valid: true}
+	err = j.Database.UpdateModelMigration(ctx, migration)
+	c.Assert(err, qt.IsNil)
+
+	for i := 0; drain.State == jimm.DrainStateRunning && i < 500; i++ {
+		time.Sleep(10 * time.Millisecond)
+		drain, err = other.ControllerDrainStatus(ctx, user, "controller-1")
+		c.Assert(err, qt.IsNil)
+	}
+	c.Check(drain.State, qt.Equals, jimm.DrainStateDone)
+	dm := expectPlan["model-1"]
+	dm.Status = jimm.DrainModelDone
+	expectPlan["model-1"] = dm
+	c.Check(modelsByName(drain.Models), drainModelsEqual, expectPlan)
+
+	_, err = j.SetControllerDrainState(ctx, user, "controller-1", jimm.DrainStatePaused)
+	c.Check(err, qt.ErrorMatches, `drain is done`)
+	_, err = j.SetControllerDrainState(ctx, user, "controller-1", "bad")
+	c.Check(err, qt.ErrorMatches, `invalid drain state "bad"`)
+}
+
+func TestDrainControllerAbort(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		CredentialStore: jimmtest.NewInMemoryCredentialStore(),
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env := jimmtest.ParseEnvironment(c, drainControllerTestEnv)
+	env.PopulateDB(c, j.Database)
+
+	dbUser := env.User("alice@canonical.com").DBObject(c, j.Database)
+	user := openfga.NewUser(&dbUser, nil)
+	user.JimmAdmin = true
+
+	_, err = j.DrainController(ctx, user, "controller-1", jimm.DrainControllerArgs{})
+	c.Assert(err, qt.IsNil)
+	drain, err := j.SetControllerDrainState(ctx, user, "controller-1", jimm.DrainStateAborted)
+	c.Assert(err, qt.IsNil)
+	c.Check(drain.State, qt.Equals, jimm.DrainStateAborted)
+
+	_, err = j.SetControllerDrainState(ctx, user, "controller-1", jimm.DrainStateRunning)
+	c.Check(err, qt.ErrorMatches, `drain is aborted`)
+
+	// An aborted drain doesn't prevent the controller being drained
+	// again.
+	drain, err = j.DrainController(ctx, user, "controller-1", jimm.DrainControllerArgs{})
+	c.Assert(err, qt.IsNil)
+	c.Check(drain.State, qt.Equals, jimm.DrainStateRunning)
+}
+
+func TestDrainControllerUnauthorized(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{}
+	user := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil)
+
+	_, err := j.DrainController(context.Background(), user, "controller-1", jimm.DrainControllerArgs{})
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	_, err = j.ControllerDrainStatus(context.Background(), user, "controller-1")
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+	_, err = j.SetControllerDrainState(context.Background(), user, "controller-1", jimm.DrainStateAborted)
+	c.Check(err, qt.ErrorMatches, `unauthorized`)
+}
@@ -22,6 +22,13 @@ var (
 	FillMigrationTarget            = fillMigrationTarget
 	InitiateMigration              = &initiateMigration
 	ResolveTag                     = resolveTag
+	LookupPlacementPolicy          = lookupPlacementPolicy
+	SelectPlacementCandidate       = selectPlacementCandidate
+	DrainPollInterval              = &drainPollInterval
+	QueryModelTimeout              = &queryModelTimeout
+	ModelStatusCacheTTL            = &modelStatusCacheTTL
+	LookupQueryEngine              = lookupQueryEngine
+	ControllerPingInterval         = &controllerPingInterval
 )
 
 func WatchController(w *Watcher, ctx context.Context, ctl *dbmodel.Controller) error {
@@ -62,6 +69,11 @@ func (j *JIMM) UpdateUserLastLogin(ctx context.Context, identifier string) error
 	return j.updateUserLastLogin(ctx, identifier)
 }
 
+func (j *JIMM) PlacementPolicy(ctx context.Context) (string, error) {
+	name, _, err := j.placementPolicy(ctx)
+	return name, err
+}
+
 func (j *JIMM) EveryoneUser() *openfga.User {
 	return j.everyoneUser()
 }
@@ -0,0 +1,162 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	jimmnames "github.com/canonical/jimm/v3/pkg/names"
+)
+
+// SyncIdentityGroups reconciles the membership of the given identity in
+// the groups managed by the identity provider with the given group
+// names, as reported by the identity provider. Groups that do not yet
+// exist are created and marked as externally managed. The identity is
+// removed from any other externally managed group, membership of groups
+// managed within JIMM is left untouched.
+func (j *JIMM) SyncIdentityGroups(ctx context.Context, identityName string, groupNames []string) error {
+	const op = errors.Op("jimm.SyncIdentityGroups")
+
+	managed, err := j.Database.FindExternallyManagedGroups(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	managedByName := make(map[string]dbmodel.GroupEntry, len(managed))
+	managedByUUID := make(map[string]dbmodel.GroupEntry, len(managed))
+	for _, g := range managed {
+		managedByName[g.Name] = g
+		managedByUUID[g.UUID] = g
+	}
+
+	want := make(map[string]dbmodel.GroupEntry)
+	for _, name := range groupNames {
+		if g, ok := managedByName[name]; ok {
+			want[g.UUID] = g
+			continue
+		}
+		if !jimmnames.IsValidGroupName(name) {
+			zapctx.Warn(ctx, "ignoring invalid identity provider group name", zap.String("group", name))
+			continue
+		}
+		g, err := j.addExternallyManagedGroup(ctx, name)
+		if err != nil {
+			return errors.E(op, err)
+		}
+		if g == nil {
+			continue
+		}
+		managedByName[g.Name] = *g
+		want[g.UUID] = *g
+	}
+
+	identity := ofganames.ConvertTag(names.NewUserTag(identityName))
+	key := openfga.Tuple{
+		Object:   identity,
+		Relation: ofganames.MemberRelation,
+		Target:   &openfga.Tag{Kind: openfga.GroupType},
+	}
+	var remove []openfga.Tuple
+	var token string
+	for {
+		tuples, ct, err := j.OpenFGAClient.ReadRelatedObjects(ctx, key, 50, token)
+		if err != nil {
+			return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		for _, t := range tuples {
+			if _, ok := want[t.Target.ID]; ok {
+				// Already a member.
+				delete(want, t.Target.ID)
+				continue
+			}
+			if _, ok := managedByUUID[t.Target.ID]; ok {
+				remove = append(remove, t)
+			}
+		}
+		if ct == "" || ct == token {
+			break
+		}
+		token = ct
+	}
+
+	var add []openfga.Tuple
+	for _, g := range want {
+		add = append(add, openfga.Tuple{
+			Object:   identity,
+			Relation: ofganames.MemberRelation,
+			Target:   ofganames.ConvertTag(g.ResourceTag()),
+		})
+	}
+	if len(add) > 0 {
+		if err := j.OpenFGAClient.AddRelation(ctx, add...); err != nil {
+			return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+	}
+	if len(remove) > 0 {
+		if err := j.OpenFGAClient.RemoveRelation(ctx, remove...); err != nil {
+			return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+	}
+	return nil
+}
+
+// addExternallyManagedGroup creates an externally managed group with the
+// given name. If a group with the same name is already managed within
+// JIMM it is not taken over, a warning is logged and nil is returned.
+func (j *JIMM) addExternallyManagedGroup(ctx context.Context, name string) (*dbmodel.GroupEntry, error) {
+	g, err := j.Database.AddExternallyManagedGroup(ctx, name)
+	if err == nil {
+		return g, nil
+	}
+	if errors.ErrorCode(err) != errors.CodeAlreadyExists {
+		return nil, err
+	}
+	// The group was either created concurrently by another login, or
+	// is managed within JIMM.
+	g = &dbmodel.GroupEntry{Name: name}
+	if err := j.Database.GetGroup(ctx, g); err != nil {
+		return nil, err
+	}
+	if !g.ExternallyManaged {
+		zapctx.Warn(ctx, "identity provider group conflicts with a JIMM managed group", zap.String("group", name))
+		return nil, nil
+	}
+	return g, nil
+}
+
+// checkGroupNotExternallyManaged returns an error if the given group's
+// membership is managed by the identity provider.
+func checkGroupNotExternallyManaged(g *dbmodel.GroupEntry) error {
+	if g.ExternallyManaged {
+		return errors.E(errors.CodeForbidden, "group "+g.Name+" is managed by the identity provider")
+	}
+	return nil
+}
+
+// checkTuplesNotExternallyManaged returns an error if any of the given
+// tuples change the members of a group managed by the identity provider.
+func (j *JIMM) checkTuplesNotExternallyManaged(ctx context.Context, tuples []openfga.Tuple) error {
+	for _, t := range tuples {
+		if t.Relation != ofganames.MemberRelation || t.Target == nil || t.Target.Kind != openfga.GroupType {
+			continue
+		}
+		g := dbmodel.GroupEntry{UUID: t.Target.ID}
+		if err := j.Database.GetGroup(ctx, &g); err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				continue
+			}
+			return err
+		}
+		if err := checkGroupNotExternallyManaged(&g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
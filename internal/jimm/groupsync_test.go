@@ -0,0 +1,118 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func TestSyncIdentityGroups(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	identity, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	err = j.Database.GetIdentity(ctx, identity)
+	c.Assert(err, qt.IsNil)
+	user := openfga.NewUser(identity, ofgaClient)
+
+	// A group managed within JIMM is neither taken over nor changed.
+	manual, err := j.Database.AddGroup(ctx, "manual")
+	c.Assert(err, qt.IsNil)
+	err = ofgaClient.AddRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(identity.ResourceTag()),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(manual.ResourceTag()),
+	})
+	c.Assert(err, qt.IsNil)
+
+	isMember := func(name string) bool {
+		g := dbmodel.GroupEntry{Name: name}
+		err := j.Database.GetGroup(ctx, &g)
+		c.Assert(err, qt.IsNil)
+		ok, err := openfga.CheckRelation(ctx, user, g.ResourceTag(), ofganames.MemberRelation)
+		c.Assert(err, qt.IsNil)
+		return ok
+	}
+
+	err = j.SyncIdentityGroups(ctx, identity.Name, []string{"devs", "ops", "manual"})
+	c.Assert(err, qt.IsNil)
+	c.Check(isMember("devs"), qt.IsTrue)
+	c.Check(isMember("ops"), qt.IsTrue)
+	c.Check(isMember("manual"), qt.IsTrue)
+
+	g := dbmodel.GroupEntry{Name: "devs"}
+	err = j.Database.GetGroup(ctx, &g)
+	c.Assert(err, qt.IsNil)
+	c.Check(g.ExternallyManaged, qt.IsTrue)
+	g = dbmodel.GroupEntry{Name: "manual"}
+	err = j.Database.GetGroup(ctx, &g)
+	c.Assert(err, qt.IsNil)
+	c.Check(g.ExternallyManaged, qt.IsFalse)
+
+	err = j.SyncIdentityGroups(ctx, identity.Name, []string{"ops"})
+	c.Assert(err, qt.IsNil)
+	c.Check(isMember("devs"), qt.IsFalse)
+	c.Check(isMember("ops"), qt.IsTrue)
+	c.Check(isMember("manual"), qt.IsTrue)
+
+	// Externally managed groups cannot be edited within JIMM.
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	err = j.RenameGroup(ctx, admin, "ops", "operators")
+	c.Check(err, qt.ErrorMatches, `group ops is managed by the identity provider`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeForbidden)
+
+	err = j.RemoveGroup(ctx, admin, "ops")
+	c.Check(err, qt.ErrorMatches, `group ops is managed by the identity provider`)
+
+	err = j.AddRelation(ctx, admin, []apiparams.RelationshipTuple{{
+		Object:       "user-bob@canonical.com",
+		Relation:     "member",
+		TargetObject: "group-devs",
+	}})
+	c.Check(err, qt.ErrorMatches, `group devs is managed by the identity provider`)
+
+	err = j.RemoveRelation(ctx, admin, []apiparams.RelationshipTuple{{
+		Object:       "user-alice@canonical.com",
+		Relation:     "member",
+		TargetObject: "group-ops",
+	}})
+	c.Check(err, qt.ErrorMatches, `group ops is managed by the identity provider`)
+
+	err = j.AddRelation(ctx, admin, []apiparams.RelationshipTuple{{
+		Object:       "user-bob@canonical.com",
+		Relation:     "member",
+		TargetObject: "group-manual",
+	}})
+	c.Check(err, qt.IsNil)
+}
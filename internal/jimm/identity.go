@@ -5,7 +5,11 @@ package jimm
 import (
 	"context"
 
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
 	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
@@ -63,3 +67,97 @@ func (j *JIMM) CountIdentities(ctx context.Context, user *openfga.User) (int, er
 	}
 	return count, nil
 }
+
+// DisableIdentity disables the identity with the given name. Disabled
+// identities are refused on every login path and any connections they have
+// open to this JIMM instance are closed, other JIMM instances close theirs
+// in WatchDisabledIdentities. Only JIMM administrators may disable
+// identities.
+func (j *JIMM) DisableIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	const op = errors.Op("jimm.DisableIdentity")
+
+	identity, err := j.setIdentityDisabled(ctx, user, identityName, true)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	n := j.sessions.closeAll(identity.Name)
+	zapctx.Info(ctx, "identity disabled", zap.String("identity", identity.Name), zap.Int("closed-sessions", n))
+	return nil
+}
+
+// EnableIdentity re-enables the identity with the given name, allowing it
+// to log in again. Only JIMM administrators may enable identities.
+func (j *JIMM) EnableIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	const op = errors.Op("jimm.EnableIdentity")
+
+	if _, err := j.setIdentityDisabled(ctx, user, identityName, false); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// setIdentityDisabled sets the disabled status of an existing identity.
+func (j *JIMM) setIdentityDisabled(ctx context.Context, user *openfga.User, identityName string, disabled bool) (*dbmodel.Identity, error) {
+	if !user.JimmAdmin {
+		return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+
+	identity, err := dbmodel.NewIdentity(identityName)
+	if err != nil {
+		return nil, errors.E(errors.CodeBadRequest, err)
+	}
+	if disabled && identity.Name == user.Name {
+		return nil, errors.E(errors.CodeBadRequest, "cannot disable your own identity")
+	}
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		if err := tx.FetchIdentity(ctx, identity); err != nil {
+			return err
+		}
+		identity.Disabled = disabled
+		return tx.UpdateIdentity(ctx, identity)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+// RemoveIdentity removes the identity with the given name. The identity is
+// soft-deleted, so its name cannot be reused, all of its OpenFGA
+// relations are removed and any connections it has open to this JIMM
+// instance are closed. Only JIMM administrators may remove identities.
+func (j *JIMM) RemoveIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	const op = errors.Op("jimm.RemoveIdentity")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	identity, err := dbmodel.NewIdentity(identityName)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if identity.Name == user.Name {
+		return errors.E(op, errors.CodeBadRequest, "cannot remove your own identity")
+	}
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		if err := tx.FetchIdentity(ctx, identity); err != nil {
+			return err
+		}
+		identity.Disabled = true
+		if err := tx.UpdateIdentity(ctx, identity); err != nil {
+			return err
+		}
+		return tx.RemoveIdentity(ctx, identity)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	j.sessions.closeAll(identity.Name)
+
+	if err := j.OpenFGAClient.RemoveUser(ctx, identity.ResourceTag()); err != nil {
+		zapctx.Error(ctx, "failed to remove identity relations", zap.String("identity", identity.Name), zap.Error(err))
+		return errors.E(op, err)
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
 
@@ -160,3 +161,162 @@ func TestCountIdentities(t *testing.T) {
 	c.Assert(err, qt.IsNil)
 	c.Assert(count, qt.Equals, 4)
 }
+
+func TestDisableAndEnableIdentity(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	_, err = j.UserLogin(ctx, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	closed := 0
+	unregister := j.RegisterSession("bob@canonical.com", func() { closed++ })
+	defer unregister()
+
+	bob := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, ofgaClient)
+	err = j.DisableIdentity(ctx, bob, "alice@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	err = j.DisableIdentity(ctx, admin, "admin@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "cannot disable your own identity")
+
+	err = j.DisableIdentity(ctx, admin, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(closed, qt.Equals, 1)
+
+	_, err = j.UserLogin(ctx, "bob@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "identity disabled")
+
+	err = j.EnableIdentity(ctx, admin, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	u, err := j.UserLogin(ctx, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(u.Disabled, qt.IsFalse)
+
+	err = j.EnableIdentity(ctx, admin, "no-such-user@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "record not found")
+}
+
+func TestCloseDisabledSessions(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	// other is another JIMM instance sharing the same database.
+	other := &jimm.JIMM{
+		UUID:          j.UUID,
+		Database:      j.Database,
+		OpenFGAClient: ofgaClient,
+	}
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	for _, name := range []string{"alice@canonical.com", "bob@canonical.com"} {
+		_, err = j.UserLogin(ctx, name)
+		c.Assert(err, qt.IsNil)
+	}
+
+	var aliceClosed, bobClosed int
+	unregisterAlice := other.RegisterSession("alice@canonical.com", func() { aliceClosed++ })
+	defer unregisterAlice()
+	unregisterBob := other.RegisterSession("bob@canonical.com", func() { bobClosed++ })
+	defer unregisterBob()
+
+	err = j.DisableIdentity(ctx, admin, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(bobClosed, qt.Equals, 0)
+
+	err = other.CloseDisabledSessions(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(aliceClosed, qt.Equals, 0)
+	c.Check(bobClosed, qt.Equals, 1)
+
+	err = j.RemoveIdentity(ctx, admin, "alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	err = other.CloseDisabledSessions(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(aliceClosed, qt.Equals, 1)
+	c.Check(bobClosed, qt.Equals, 1)
+}
+
+func TestRemoveIdentity(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "admin@canonical.com"}, ofgaClient)
+	admin.JimmAdmin = true
+
+	bob, err := j.UserLogin(ctx, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	err = bob.SetControllerAccess(ctx, j.ResourceTag(), ofganames.AdministratorRelation)
+	c.Assert(err, qt.IsNil)
+
+	closed := 0
+	unregister := j.RegisterSession("bob@canonical.com", func() { closed++ })
+	defer unregister()
+
+	err = j.RemoveIdentity(ctx, bob, "admin@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	err = j.RemoveIdentity(ctx, admin, "admin@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "cannot remove your own identity")
+
+	err = j.RemoveIdentity(ctx, admin, "bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Check(closed, qt.Equals, 1)
+	c.Check(bob.GetControllerAccess(ctx, j.ResourceTag()), qt.Equals, ofganames.NoRelation)
+
+	_, err = j.UserLogin(ctx, "bob@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "identity has been removed")
+
+	err = j.RemoveIdentity(ctx, admin, "bob@canonical.com")
+	c.Assert(err, qt.ErrorMatches, "record not found")
+}
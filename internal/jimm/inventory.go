@@ -0,0 +1,267 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/charm/v12"
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+)
+
+// inventoryRetries is the number of times an inventory update is retried
+// before the watcher gives up and restarts, which resynchronises the
+// whole inventory.
+const inventoryRetries = 3
+
+// inventoryRetryDelay is the time to wait before retrying a failed
+// inventory update. It is a variable so it can be changed in tests.
+var inventoryRetryDelay = time.Second
+
+// An inventoryKey identifies an application, unit or machine in a model.
+type inventoryKey struct {
+	modelID uint
+	kind    string
+	id      string
+}
+
+// An inventoryBatch collects the inventory changes from a set of deltas
+// so they can be stored together. Only the most recent delta for each
+// application, unit or machine is kept.
+type inventoryBatch map[inventoryKey]jujuparams.Delta
+
+// add adds the given delta, for the model with the given ID, to the
+// batch. Deltas for anything other than an application, unit or machine
+// are ignored.
+func (b inventoryBatch) add(modelID uint, d jujuparams.Delta) {
+	eid := d.Entity.EntityId()
+	switch eid.Kind {
+	case "application", "unit", "machine":
+		b[inventoryKey{modelID: modelID, kind: eid.Kind, id: eid.Id}] = d
+	}
+}
+
+// update returns the database update that stores the batch.
+func (b inventoryBatch) update() *db.InventoryUpdate {
+	var u db.InventoryUpdate
+	for k, d := range b {
+		switch e := d.Entity.(type) {
+		case *jujuparams.ApplicationInfo:
+			if d.Removed {
+				u.RemovedApplications = append(u.RemovedApplications, dbmodel.ModelApplication{ModelID: k.modelID, Name: e.Name})
+				break
+			}
+			app := dbmodel.ModelApplication{
+				ModelID:         k.modelID,
+				Name:            e.Name,
+				CharmURL:        e.CharmURL,
+				CharmRevision:   -1,
+				Life:            string(e.Life),
+				Status:          string(e.Status.Current),
+				WorkloadVersion: e.WorkloadVersion,
+				Exposed:         e.Exposed,
+				Subordinate:     e.Subordinate,
+			}
+			if curl, err := charm.ParseURL(e.CharmURL); err == nil {
+				app.CharmName = curl.Name
+				app.CharmRevision = curl.Revision
+			}
+			u.Applications = append(u.Applications, app)
+		case *jujuparams.UnitInfo:
+			if d.Removed {
+				u.RemovedUnits = append(u.RemovedUnits, dbmodel.ModelUnit{ModelID: k.modelID, Name: e.Name})
+				break
+			}
+			u.Units = append(u.Units, dbmodel.ModelUnit{
+				ModelID:         k.modelID,
+				Name:            e.Name,
+				Application:     e.Application,
+				CharmURL:        e.CharmURL,
+				MachineID:       e.MachineId,
+				Principal:       e.Principal,
+				Life:            string(e.Life),
+				WorkloadStatus:  string(e.WorkloadStatus.Current),
+				WorkloadMessage: e.WorkloadStatus.Message,
+				AgentStatus:     string(e.AgentStatus.Current),
+			})
+		case *jujuparams.MachineInfo:
+			if d.Removed {
+				u.RemovedMachines = append(u.RemovedMachines, dbmodel.ModelMachine{ModelID: k.modelID, MachineID: e.Id})
+				break
+			}
+			machine := dbmodel.ModelMachine{
+				ModelID:        k.modelID,
+				MachineID:      e.Id,
+				Base:           e.Base,
+				InstanceID:     e.InstanceId,
+				Hostname:       e.Hostname,
+				Life:           string(e.Life),
+				AgentStatus:    string(e.AgentStatus.Current),
+				InstanceStatus: string(e.InstanceStatus.Current),
+			}
+			if e.HardwareCharacteristics != nil && e.HardwareCharacteristics.CpuCores != nil {
+				//nolint:gosec // We expect cpu cores to fit into int64.
+				machine.Cores = int64(*e.HardwareCharacteristics.CpuCores)
+			}
+			u.Machines = append(u.Machines, machine)
+		}
+	}
+	return &u
+}
+
+// updateInventory stores the inventory changes in the given batch in a
+// single transaction, retrying a failed update up to inventoryRetries
+// times. If the update still fails the error is returned, the watcher
+// then stops and is restarted, resynchronising the inventory from the
+// complete state of every model.
+func (w *Watcher) updateInventory(ctx context.Context, b inventoryBatch) error {
+	const op = errors.Op("jimm.updateInventory")
+
+	u := b.update()
+	if u.Empty() {
+		return nil
+	}
+	var err error
+	for i := 0; i < inventoryRetries; i++ {
+		if i > 0 {
+			zapctx.Warn(ctx, "cannot update model inventory, retrying", zap.Error(err))
+			select {
+			case <-ctx.Done():
+				return errors.E(op, ctx.Err())
+			case <-time.After(inventoryRetryDelay):
+			}
+		}
+		if err = w.Database.UpdateModelInventory(ctx, u); err == nil {
+			return nil
+		}
+	}
+	return errors.E(op, err)
+}
+
+// pruneInventory removes any stored inventory of the given models that
+// is not included in the given deltas. The deltas must be the first set
+// returned by an all watcher, which holds the complete state of every
+// model.
+func (w *Watcher) pruneInventory(ctx context.Context, modelStates map[string]*modelState, deltas []jujuparams.Delta) error {
+	const op = errors.Op("jimm.pruneInventory")
+
+	type inventory struct {
+		applications, units, machines []string
+	}
+	seen := make(map[string]*inventory)
+	for _, d := range deltas {
+		if d.Removed {
+			continue
+		}
+		eid := d.Entity.EntityId()
+		inv := seen[eid.ModelUUID]
+		if inv == nil {
+			inv = new(inventory)
+			seen[eid.ModelUUID] = inv
+		}
+		switch eid.Kind {
+		case "application":
+			inv.applications = append(inv.applications, eid.Id)
+		case "unit":
+			inv.units = append(inv.units, eid.Id)
+		case "machine":
+			inv.machines = append(inv.machines, eid.Id)
+		}
+	}
+	for uuid, state := range modelStates {
+		if state == nil {
+			continue
+		}
+		inv := seen[uuid]
+		if inv == nil {
+			inv = new(inventory)
+		}
+		if err := w.Database.PruneModelInventory(ctx, state.id, inv.applications, inv.units, inv.machines); err != nil {
+			return errors.E(op, err)
+		}
+	}
+	return nil
+}
+
+// restrictInventoryFilter restricts the given filter to the models the
+// given user can read. JIMM administrators can search every model.
+func restrictInventoryFilter(ctx context.Context, user *openfga.User, filter *db.InventoryFilter) error {
+	if user.JimmAdmin {
+		return nil
+	}
+	uuids, err := user.ListModels(ctx, ofganames.ReaderRelation)
+	if err != nil {
+		return errors.E(err, errors.CodeOpenFGARequestFailed)
+	}
+	if filter.ModelUUIDs == nil {
+		filter.ModelUUIDs = append([]string{}, uuids...)
+		return nil
+	}
+	readable := make(map[string]bool, len(uuids))
+	for _, uuid := range uuids {
+		readable[uuid] = true
+	}
+	selected := []string{}
+	for _, uuid := range filter.ModelUUIDs {
+		if readable[uuid] {
+			selected = append(selected, uuid)
+		}
+	}
+	filter.ModelUUIDs = selected
+	return nil
+}
+
+// FindApplications returns the applications in JIMM's inventory that
+// match the given filter. Only applications in models the user can read
+// are returned.
+func (j *JIMM) FindApplications(ctx context.Context, user *openfga.User, filter db.ModelApplicationFilter) ([]dbmodel.ModelApplication, error) {
+	const op = errors.Op("jimm.FindApplications")
+
+	if err := restrictInventoryFilter(ctx, user, &filter.InventoryFilter); err != nil {
+		return nil, errors.E(op, err)
+	}
+	apps, err := j.Database.FindModelApplications(ctx, filter)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return apps, nil
+}
+
+// FindUnits returns the units in JIMM's inventory that match the given
+// filter. Only units in models the user can read are returned.
+func (j *JIMM) FindUnits(ctx context.Context, user *openfga.User, filter db.ModelUnitFilter) ([]dbmodel.ModelUnit, error) {
+	const op = errors.Op("jimm.FindUnits")
+
+	if err := restrictInventoryFilter(ctx, user, &filter.InventoryFilter); err != nil {
+		return nil, errors.E(op, err)
+	}
+	units, err := j.Database.FindModelUnits(ctx, filter)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return units, nil
+}
+
+// FindMachines returns the machines in JIMM's inventory that match the
+// given filter. Only machines in models the user can read are returned.
+func (j *JIMM) FindMachines(ctx context.Context, user *openfga.User, filter db.ModelMachineFilter) ([]dbmodel.ModelMachine, error) {
+	const op = errors.Op("jimm.FindMachines")
+
+	if err := restrictInventoryFilter(ctx, user, &filter.InventoryFilter); err != nil {
+		return nil, errors.E(op, err)
+	}
+	machines, err := j.Database.FindModelMachines(ctx, filter)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return machines, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+)
+
+func TestInventoryBatch(t *testing.T) {
+	c := qt.New(t)
+
+	b := make(inventoryBatch)
+	b.add(1, jujuparams.Delta{Entity: &jujuparams.ApplicationInfo{Name: "app", CharmURL: "ch:amd64/app-3"}})
+	b.add(1, jujuparams.Delta{Entity: &jujuparams.ApplicationInfo{Name: "app", CharmURL: "ch:amd64/app-4"}})
+	b.add(1, jujuparams.Delta{Entity: &jujuparams.ApplicationInfo{Name: "local", CharmURL: "local:local"}})
+	b.add(1, jujuparams.Delta{Entity: &jujuparams.UnitInfo{Name: "app/0"}})
+	b.add(1, jujuparams.Delta{Removed: true, Entity: &jujuparams.UnitInfo{Name: "app/0"}})
+	b.add(2, jujuparams.Delta{Removed: true, Entity: &jujuparams.MachineInfo{Id: "0"}})
+	b.add(2, jujuparams.Delta{Entity: &jujuparams.MachineInfo{Id: "0"}})
+	b.add(2, jujuparams.Delta{Entity: &jujuparams.ModelUpdate{Name: "model"}})
+
+	// Only the most recent delta for each entity is stored.
+	u := b.update()
+	c.Check(u.Applications, qt.HasLen, 2)
+	for _, app := range u.Applications {
+		switch app.Name {
+		case "app":
+			c.Check(app.CharmRevision, qt.Equals, 4)
+		case "local":
+			c.Check(app.CharmRevision, qt.Equals, -1)
+		default:
+			c.Errorf("unexpected application %q", app.Name)
+		}
+	}
+	c.Check(u.Units, qt.HasLen, 0)
+	c.Check(u.RemovedUnits, qt.DeepEquals, []dbmodel.ModelUnit{{ModelID: 1, Name: "app/0"}})
+	c.Check(u.Machines, qt.DeepEquals, []dbmodel.ModelMachine{{ModelID: 2, MachineID: "0"}})
+	c.Check(u.RemovedMachines, qt.HasLen, 0)
+	c.Check(u.Empty(), qt.IsFalse)
+
+	c.Check(make(inventoryBatch).update().Empty(), qt.IsTrue)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm/auditsink"
 	"github.com/canonical/jimm/v3/internal/jimm/credentials"
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
 	"github.com/canonical/jimm/v3/internal/openfga"
@@ -86,6 +87,18 @@ type JIMM struct {
 	// OAuthAuthenticator is responsible for handling authentication
 	// via OAuth2.0 AND JWT access tokens to JIMM.
 	OAuthAuthenticator OAuthAuthenticator
+
+	// AuditSinks holds additional destinations that audit log entries
+	// are streamed to after they have been stored in the database.
+	AuditSinks []auditsink.Sink
+
+	// sessions holds the connections that are currently authenticated
+	// to this JIMM instance.
+	sessions sessionRegistry
+
+	// modelStatuses caches the model statuses used by cross-model
+	// queries.
+	modelStatuses modelStatusCache
 }
 
 // ResourceTag returns JIMM's controller tag stating its UUID.
@@ -125,20 +138,24 @@ type OAuthAuthenticator interface {
 	// into the uri.
 	//
 	// The interval, expiry and device code and used to poll the token endpoint for completion.
-	Device(ctx context.Context) (*oauth2.DeviceAuthResponse, error)
+	//
+	// The provider is the name of the identity provider to use, if it is empty the
+	// default identity provider is used.
+	Device(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error)
 
 	// DeviceAccessToken continues and collect an access token during the device login flow
 	// and is step TWO.
 	//
 	// See Device(...) godoc for more info pertaining to the flow.
-	DeviceAccessToken(ctx context.Context, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error)
+	DeviceAccessToken(ctx context.Context, provider string, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error)
 
 	// ExtractAndVerifyIDToken extracts the id token from the extras claims of an oauth2 token
 	// and performs signature verification of the token.
-	ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error)
+	ExtractAndVerifyIDToken(ctx context.Context, provider string, oauth2Token *oauth2.Token) (*oidc.IDToken, error)
 
-	// Email retrieves the users email from an id token via the email claim
-	Email(idToken *oidc.IDToken) (string, error)
+	// Email retrieves the users identity name from an id token issued by the
+	// given provider.
+	Email(provider string, idToken *oidc.IDToken) (string, error)
 
 	// MintSessionToken mints a session token to be used when logging into JIMM
 	// via an access token. The token only contains the user's email for authentication.
@@ -402,13 +419,19 @@ func (j *JIMM) forEachController(ctx context.Context, controllers []dbmodel.Cont
 	return eg.Wait()
 }
 
-// addAuditLogEntry causes an entry to be added the the audit log.
+// addAuditLogEntry causes an entry to be added the the audit log and
+// sent to any configured audit sinks.
 func (j *JIMM) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
 	ctx := context.Background()
 	redactSensitiveParams(ale)
 	if err := j.Database.AddAuditLogEntry(ctx, ale); err != nil {
 		zapctx.Error(ctx, "cannot store audit log entry", zap.Error(err), zap.Any("entry", *ale))
 	}
+	for _, sink := range j.AuditSinks {
+		if err := sink.Write(ctx, ale); err != nil {
+			zapctx.Error(ctx, "cannot send audit log entry to sink", zap.Error(err))
+		}
+	}
 }
 
 var sensitiveMethods = map[string]struct{}{
@@ -453,6 +476,28 @@ func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter d
 	return entries, nil
 }
 
+// FindCorrelatedAuditEvents returns audit-log requests that match the
+// given filter together with their responses.
+func (j *JIMM) FindCorrelatedAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.CorrelatedAuditLogEntry, error) {
+	const op = errors.Op("jimm.FindCorrelatedAuditEvents")
+
+	access := user.GetAuditLogViewerAccess(ctx, j.ResourceTag())
+	if access != ofganames.AuditLogViewerRelation {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var entries []dbmodel.CorrelatedAuditLogEntry
+	err := j.Database.ForEachCorrelatedAuditLogEntry(ctx, filter, func(entry *dbmodel.CorrelatedAuditLogEntry) error {
+		entries = append(entries, *entry)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	return entries, nil
+}
+
 // ControllerInfo returns info about a controller connected to JIMM.
 func (j *JIMM) ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error) {
 	const op = errors.Op("jimm.ListControllers")
@@ -473,8 +518,16 @@ func (j *JIMM) ListControllers(ctx context.Context, user *openfga.User) ([]dbmod
 		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
 	}
 
+	healthByID, err := j.controllerHealthByID(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
 	var controllers []dbmodel.Controller
-	err := j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+	err = j.Database.ForEachController(ctx, func(c *dbmodel.Controller) error {
+		if h, ok := healthByID[c.ID]; ok {
+			c.Health = &h
+		}
 		controllers = append(controllers, *c)
 		return nil
 	})
@@ -558,6 +611,7 @@ func (j *JIMM) RemoveController(ctx context.Context, user *openfga.User, control
 	if err != nil {
 		return errors.E(op, err)
 	}
+	deleteControllerMetrics(controllerName)
 
 	return nil
 }
@@ -635,9 +689,20 @@ func fillMigrationTarget(db db.Database, credStore credentials.CredentialStore,
 func (j *JIMM) InitiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error) {
 	const op = errors.Op("jimm.InitiateInternalMigration")
 
-	migrationTarget, _, err := fillMigrationTarget(j.Database, j.CredentialStore, targetController)
+	result, _, err := j.initiateInternalMigration(ctx, user, modelTag, targetController)
+	if err != nil {
+		return result, errors.E(op, err)
+	}
+	return result, nil
+}
+
+// initiateInternalMigration initiates a model migration between two
+// controllers within JIMM and records it. The returned migration record
+// has a zero ID if the migration was initiated but could not be recorded.
+func (j *JIMM) initiateInternalMigration(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, *dbmodel.ModelMigration, error) {
+	migrationTarget, targetControllerID, err := fillMigrationTarget(j.Database, j.CredentialStore, targetController)
 	if err != nil {
-		return jujuparams.InitiateMigrationResult{}, errors.E(op, err)
+		return jujuparams.InitiateMigrationResult{}, nil, err
 	}
 	// Check that the model exists
 	model := dbmodel.Model{
@@ -648,12 +713,41 @@ func (j *JIMM) InitiateInternalMigration(ctx context.Context, user *openfga.User
 	}
 	err = j.Database.GetModel(ctx, &model)
 	if err != nil {
-		return jujuparams.InitiateMigrationResult{}, errors.E(op, err)
+		return jujuparams.InitiateMigrationResult{}, nil, err
 	}
 	spec := jujuparams.MigrationSpec{ModelTag: modelTag.String(), TargetInfo: migrationTarget}
 	result, err := initiateMigration(ctx, j, user, spec)
 	if err != nil {
-		return result, errors.E(op, err)
+		return result, nil, err
 	}
-	return result, nil
+
+	// Record the migration so that its progress can be tracked, see
+	// Watcher.WatchMigrations.
+	migration := dbmodel.ModelMigration{
+		ModelID:            model.ID,
+		MigrationID:        result.MigrationId,
+		SourceControllerID: model.ControllerID,
+		TargetControllerID: targetControllerID,
+		InitiatedBy:        user.Name,
+		Phase:              dbmodel.MigrationPhaseRunning,
+		StartedAt:          time.Now().UTC(),
+	}
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		if err := tx.AddModelMigration(ctx, &migration); err != nil {
+			return err
+		}
+		model.MigrationControllerID = sql.NullInt32{
+			//nolint:gosec // Controller IDs are expected to fit into int32.
+			Int32: int32(targetControllerID),
+			Valid: true,
+		}
+		return tx.UpdateModel(ctx, &model)
+	})
+	if err != nil {
+		// The migration has already been started, so don't report
+		// an error to the caller.
+		zapctx.Error(ctx, "failed to record model migration", zap.String("model", modelTag.Id()), zap.Error(err))
+		migration.ID = 0
+	}
+	return result, &migration, nil
 }
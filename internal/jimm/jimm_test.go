@@ -20,6 +20,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/servermon"
 	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 	"github.com/canonical/jimm/v3/pkg/api/params"
 )
@@ -509,6 +510,9 @@ func TestRemoveController(t *testing.T) {
 				c.Assert(err, qt.Equals, nil)
 			}
 
+			servermon.ControllerHealthy.WithLabelValues("controller-1").Set(1)
+			defer servermon.ControllerHealthy.DeleteLabelValues("controller-1")
+
 			err = j.RemoveController(ctx, user, "controller-1", test.force)
 			if test.expectedError != "" {
 				c.Assert(err, qt.ErrorMatches, test.expectedError)
@@ -519,6 +523,8 @@ func TestRemoveController(t *testing.T) {
 				}
 				err = j.Database.GetController(ctx, &controller)
 				c.Assert(err, qt.ErrorMatches, "controller not found")
+				// The controller's health metrics are deleted with it.
+				c.Check(servermon.ControllerHealthy.DeleteLabelValues("controller-1"), qt.IsFalse)
 			}
 		})
 	}
@@ -921,6 +927,25 @@ func TestInitiateInternalMigration(t *testing.T) {
 			} else {
 				c.Assert(err, qt.IsNil)
 				c.Assert(res, qt.DeepEquals, jujuparams.InitiateMigrationResult{})
+
+				model := dbmodel.Model{}
+				model.SetTag(mt)
+				err = j.Database.GetModel(ctx, &model)
+				c.Assert(err, qt.IsNil)
+				c.Check(model.MigrationControllerID.Valid, qt.IsTrue)
+
+				var migrations []dbmodel.ModelMigration
+				err = j.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model.ID}, func(m *dbmodel.ModelMigration) error {
+					migrations = append(migrations, *m)
+					return nil
+				})
+				c.Assert(err, qt.IsNil)
+				c.Assert(migrations, qt.HasLen, 1)
+				c.Check(migrations[0].Phase, qt.Equals, dbmodel.MigrationPhaseRunning)
+				c.Check(migrations[0].InitiatedBy, qt.Equals, test.user)
+				c.Check(migrations[0].SourceControllerID, qt.Equals, model.ControllerID)
+				c.Check(int32(migrations[0].TargetControllerID), qt.Equals, model.MigrationControllerID.Int32)
+				c.Check(migrations[0].TargetController.Name, qt.Equals, test.migrateInfo.TargetController)
 			}
 		})
 	}
@@ -0,0 +1,29 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// RotateJWKS immediately replaces all the keys JIMM signs JWTs with,
+// including the published next and retired keys. It is intended for use
+// when a key may have been compromised. Only JIMM administrators can
+// perform this operation.
+func (j *JIMM) RotateJWKS(ctx context.Context, user *openfga.User) error {
+	const op = errors.Op("jimm.RotateJWKS")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if j.JWKService == nil {
+		return errors.E(op, errors.CodeNotSupported, "JWKS rotation not enabled")
+	}
+	if err := j.JWKService.RotateJWKS(ctx); err != nil {
+		return errors.E(op, err, "failed to rotate JWKS")
+	}
+	return nil
+}
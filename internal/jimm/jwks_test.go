@@ -0,0 +1,53 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jimmjwx"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestRotateJWKS(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	j := &jimm.JIMM{
+		CredentialStore: store,
+		JWKService:      jimmjwx.NewJWKSService(store, jwa.RS256),
+	}
+
+	user := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil)
+	err := j.RotateJWKS(ctx, user)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	admin := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	admin.JimmAdmin = true
+	err = j.RotateJWKS(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	before, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(before.Len(), qt.Equals, 2)
+
+	err = j.RotateJWKS(ctx, admin)
+	c.Assert(err, qt.IsNil)
+	after, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(after.Len(), qt.Equals, 2)
+	for i := 0; i < after.Len(); i++ {
+		key, _ := after.Key(i)
+		_, found := before.LookupKeyID(key.KeyID())
+		c.Check(found, qt.IsFalse)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/servermon"
+)
+
+// leaderLockKey is the key of the postgres advisory lock held by the
+// leader. Every JIMM replica sharing a database uses the same key.
+const leaderLockKey int64 = 0x6a696d6d6c656164 // "jimmlead"
+
+// defaultLeaderElectionInterval is the default interval at which a
+// LeaderElector tries to become the leader, and the leader checks that
+// it still holds the lock.
+const defaultLeaderElectionInterval = 10 * time.Second
+
+// A LeaderElector elects a single leader amongst the JIMM replicas
+// sharing a database, using a postgres advisory lock. If the leader
+// stops, or loses its database connection, the lock is released and
+// another replica takes over.
+type LeaderElector struct {
+	// Database is the database used to hold the leader lock.
+	Database db.Database
+
+	// Interval is the interval at which the elector tries to acquire
+	// the lock, and at which the leader checks it still holds the lock.
+	// If this is zero a default of 10 seconds is used.
+	Interval time.Duration
+
+	mu    sync.Mutex
+	since time.Time
+}
+
+// LeaderStatus describes the leadership of a JIMM replica.
+type LeaderStatus struct {
+	// Leader is true if this replica is the leader.
+	Leader bool `json:"leader"`
+
+	// Since is the time this replica became the leader.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// Status returns the current leadership status of this replica.
+func (e *LeaderElector) Status() LeaderStatus {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.since.IsZero() {
+		return LeaderStatus{}
+	}
+	since := e.since
+	return LeaderStatus{Leader: true, Since: &since}
+}
+
+// Run campaigns for leadership until the given context is canceled.
+// Whenever this replica becomes the leader, lead is called with a context
+// that is canceled if leadership is lost. If lead returns, leadership is
+// given up so that another replica may take over. Run always returns the
+// context's error.
+func (e *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context) error) error {
+	interval := e.Interval
+	if interval == 0 {
+		interval = defaultLeaderElectionInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		lock, err := e.Database.TryAdvisoryLock(ctx, leaderLockKey)
+		switch {
+		case err != nil:
+			zapctx.Warn(ctx, "cannot acquire leader lock", zap.Error(err))
+		case lock != nil:
+			e.runLeader(ctx, lock, ticker.C, lead)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runLeader runs lead while the given lock is held. It returns, releasing
+// the lock, when lead returns or the lock is lost.
+func (e *LeaderElector) runLeader(ctx context.Context, lock *db.AdvisoryLock, tick <-chan time.Time, lead func(ctx context.Context) error) {
+	zapctx.Info(ctx, "elected leader")
+	e.setLeader(true)
+
+	leadCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := lead(leadCtx); err != nil && leadCtx.Err() == nil {
+			zapctx.Error(ctx, "leader stopped", zap.Error(err))
+		}
+	}()
+
+	// Ensure that leadership is never held by two replicas: the
+	// leader's work is stopped before the lock is released.
+	defer func() {
+		cancel()
+		<-done
+		e.setLeader(false)
+		// The lock is released even if the elector is stopping.
+		if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+			zapctx.Warn(ctx, "cannot release leader lock", zap.Error(err))
+		}
+		zapctx.Info(ctx, "no longer leader")
+	}()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-tick:
+		}
+		if err := lock.Check(ctx); err != nil {
+			zapctx.Error(ctx, "leader lock lost", zap.Error(err))
+			return
+		}
+	}
+}
+
+func (e *LeaderElector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if leader {
+		e.since = time.Now().UTC()
+		servermon.Leader.Set(1)
+	} else {
+		e.since = time.Time{}
+		servermon.Leader.Set(0)
+	}
+}
+
+// RunLeaderWorkers runs the given workers concurrently until the given
+// context is canceled. Workers may return nil once they have started any
+// background work. If a worker returns an error the remaining workers are
+// stopped and the error is returned, so that leadership can be given up.
+func RunLeaderWorkers(ctx context.Context, workers ...func(ctx context.Context) error) error {
+	const op = errors.Op("jimm.RunLeaderWorkers")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errc := make(chan error, len(workers))
+	for _, w := range workers {
+		go func(w func(context.Context) error) {
+			errc <- w(ctx)
+		}(w)
+	}
+	var firstErr error
+	for range workers {
+		if err := <-errc; err != nil && firstErr == nil && ctx.Err() == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	if firstErr != nil {
+		return errors.E(op, firstErr)
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
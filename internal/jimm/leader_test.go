@@ -0,0 +1,116 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func TestLeaderElector(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	database := db.Database{
+		DB: jimmtest.PostgresDB(c, nil),
+	}
+	err := database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	e1 := &jimm.LeaderElector{Database: database, Interval: 10 * time.Millisecond}
+	e2 := &jimm.LeaderElector{Database: database, Interval: 10 * time.Millisecond}
+
+	type leading struct {
+		elector *jimm.LeaderElector
+		ctx     context.Context
+		resign  func()
+	}
+	leaders := make(chan leading)
+	lead := func(e *jimm.LeaderElector) func(context.Context) error {
+		return func(ctx context.Context) error {
+			ctx, resign := context.WithCancel(ctx)
+			leaders <- leading{elector: e, ctx: ctx, resign: resign}
+			<-ctx.Done()
+			return errors.E("resigned")
+		}
+	}
+	done := make(chan struct{}, 2)
+	for _, e := range []*jimm.LeaderElector{e1, e2} {
+		go func(e *jimm.LeaderElector) {
+			e.Run(ctx, lead(e))
+			done <- struct{}{}
+		}(e)
+	}
+
+	first := <-leaders
+	c.Check(first.elector.Status().Leader, qt.IsTrue)
+	other := e1
+	if first.elector == e1 {
+		other = e2
+	}
+	c.Check(other.Status().Leader, qt.IsFalse)
+
+	// When the leader gives up, the other replica takes over.
+	first.resign()
+	second := <-leaders
+	c.Check(second.elector, qt.Equals, other)
+	c.Check(first.elector.Status().Leader, qt.IsFalse)
+
+	cancel()
+	<-done
+	<-done
+	c.Check(e1.Status().Leader, qt.IsFalse)
+	c.Check(e2.Status().Leader, qt.IsFalse)
+}
+
+func TestRunLeaderWorkers(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Workers that return nil do not stop the others.
+	started := make(chan struct{})
+	errc := make(chan error)
+	go func() {
+		errc <- jimm.RunLeaderWorkers(ctx,
+			func(context.Context) error { return nil },
+			func(ctx context.Context) error {
+				close(started)
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		)
+	}()
+	<-started
+	select {
+	case err := <-errc:
+		c.Fatalf("workers stopped early: %v", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancel()
+	c.Check(<-errc, qt.Equals, context.Canceled)
+
+	// A worker returning an error stops the others.
+	stopped := make(chan struct{})
+	err := jimm.RunLeaderWorkers(context.Background(),
+		func(context.Context) error { return errors.E("test error") },
+		func(ctx context.Context) error {
+			<-ctx.Done()
+			close(stopped)
+			return nil
+		},
+	)
+	c.Check(err, qt.ErrorMatches, `test error`)
+	<-stopped
+}
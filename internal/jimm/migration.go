@@ -0,0 +1,233 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// ListMigrations returns the model migrations that match the given
+// filter, most recently started first. Only JIMM administrators can list
+// migrations.
+func (j *JIMM) ListMigrations(ctx context.Context, user *openfga.User, modelTag *names.ModelTag, active bool) ([]dbmodel.ModelMigration, error) {
+	const op = errors.Op("jimm.ListMigrations")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	filter := db.ModelMigrationFilter{
+		Active: active,
+	}
+	if modelTag != nil {
+		model := dbmodel.Model{}
+		model.SetTag(*modelTag)
+		if err := j.Database.GetModel(ctx, &model); err != nil {
+			return nil, errors.E(op, err)
+		}
+		filter.ModelID = model.ID
+	}
+
+	var migrations []dbmodel.ModelMigration
+	err := j.Database.ForEachModelMigration(ctx, filter, func(m *dbmodel.ModelMigration) error {
+		migrations = append(migrations, *m)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return migrations, nil
+}
+
+// MigrationStatus returns the most recent migration of the given model.
+// The user must be an administrator of the model.
+func (j *JIMM) MigrationStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag) (*dbmodel.ModelMigration, error) {
+	const op = errors.Op("jimm.MigrationStatus")
+
+	isAdministrator, err := openfga.IsAdministrator(ctx, user, modelTag)
+	if err != nil {
+		return nil, errors.E(op, err, errors.CodeOpenFGARequestFailed)
+	}
+	if !isAdministrator {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	model := dbmodel.Model{}
+	model.SetTag(modelTag)
+	if err := j.Database.GetModel(ctx, &model); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	var migration *dbmodel.ModelMigration
+	err = j.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model.ID, Limit: 1}, func(m *dbmodel.ModelMigration) error {
+		migration = m
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if migration == nil {
+		return nil, errors.E(op, errors.CodeNotFound, "migration not found")
+	}
+	return migration, nil
+}
+
+// WatchMigrations tracks the progress of all unfinished model migrations
+// recorded by JIMM. At the given interval the source controller of each
+// migration is asked for the status of the model. When the model has left
+// the source controller, and is known to the target controller, the model
+// is updated to be hosted on the target controller. WatchMigrations blocks
+// until either the given context is closed, or there is an error querying
+// the database.
+func (w *Watcher) WatchMigrations(ctx context.Context, interval time.Duration) error {
+	const op = errors.Op("jimm.WatchMigrations")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := w.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{Active: true}, func(m *dbmodel.ModelMigration) error {
+			ctx := zapctx.WithFields(ctx, zap.String("model-uuid", m.Model.UUID.String), zap.Uint("migration", m.ID))
+			if err := w.checkMigration(ctx, m); err != nil {
+				zapctx.Warn(ctx, "cannot check model migration", zap.Error(err))
+			}
+			return nil
+		})
+		if err != nil {
+			// Ignore temporary database errors.
+			if errors.ErrorCode(err) != errors.CodeDatabaseLocked {
+				return errors.E(op, err)
+			}
+			zapctx.Warn(ctx, "temporary error polling for migrations", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkMigration updates the given migration with the status reported
+// by the migration's source controller.
+func (w *Watcher) checkMigration(ctx context.Context, m *dbmodel.ModelMigration) error {
+	const op = errors.Op("jimm.checkMigration")
+
+	api, err := w.Dialer.Dial(ctx, &m.SourceController, names.ModelTag{}, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	mi := jujuparams.ModelInfo{
+		UUID: m.Model.UUID.String,
+	}
+	err = api.ModelInfo(ctx, &mi)
+	switch {
+	case err == nil:
+	case isModelGone(err):
+		// The model has left the source controller.
+		return w.completeMigration(ctx, m)
+	default:
+		return errors.E(op, err)
+	}
+
+	if mi.Migration == nil {
+		return nil
+	}
+	m.Status = mi.Migration.Status
+	if mi.Migration.End != nil {
+		// The migration has ended but the model is still hosted
+		// on the source controller, so the migration was aborted.
+		m.Phase = dbmodel.MigrationPhaseAborted
+		m.Error = mi.Migration.Status
+		m.FinishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+		return w.finishMigration(ctx, m, nil)
+	}
+	if err := w.Database.UpdateModelMigration(ctx, m); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// completeMigration checks that the migrated model is known to the target
+// controller and, if it is, moves the model to the target controller.
+func (w *Watcher) completeMigration(ctx context.Context, m *dbmodel.ModelMigration) error {
+	const op = errors.Op("jimm.completeMigration")
+
+	api, err := w.Dialer.Dial(ctx, &m.TargetController, names.ModelTag{}, nil)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	defer api.Close()
+
+	m.FinishedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	err = api.ModelInfo(ctx, &jujuparams.ModelInfo{UUID: m.Model.UUID.String})
+	switch {
+	case err == nil:
+		m.Phase = dbmodel.MigrationPhaseDone
+		target := m.TargetController
+		return w.finishMigration(ctx, m, &target)
+	case isModelGone(err):
+		m.Phase = dbmodel.MigrationPhaseFailed
+		m.Error = "model not found on source or target controller"
+		return w.finishMigration(ctx, m, nil)
+	default:
+		return errors.E(op, err)
+	}
+}
+
+// finishMigration stores the final state of the migration and clears the
+// model's migration target. If controller is not nil the model is updated
+// to be hosted on that controller.
+func (w *Watcher) finishMigration(ctx context.Context, m *dbmodel.ModelMigration, controller *dbmodel.Controller) error {
+	const op = errors.Op("jimm.finishMigration")
+
+	err := w.Database.Transaction(func(tx *db.Database) error {
+		model := dbmodel.Model{
+			ID: m.ModelID,
+		}
+		if err := tx.GetModel(ctx, &model); err != nil {
+			return err
+		}
+		if controller != nil {
+			model.Controller = *controller
+			model.ControllerID = controller.ID
+		}
+		model.MigrationControllerID = sql.NullInt32{}
+		if err := tx.UpdateModel(ctx, &model); err != nil {
+			return err
+		}
+		return tx.UpdateModelMigration(ctx, m)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	zapctx.Info(ctx, "model migration finished", zap.String("phase", m.Phase))
+	return nil
+}
+
+// isModelGone returns whether the given error, returned from a
+// ModelInfo call, indicates that the model is not hosted on the
+// controller. Unauthorized errors are not treated as the model being
+// gone, they are more likely caused by JIMM's credentials for the
+// controller having expired or been revoked.
+func isModelGone(err error) bool {
+	switch errors.ErrorCode(err) {
+	case errors.CodeNotFound, errors.CodeModelNotFound, errors.CodeRedirect:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	jujuparams "github.com/juju/juju/rpc/params"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+const testWatchMigrationsEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: test-cloud-region
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: test-cloud-region
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: test-cloud-region
+models:
+- name: model-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  default-series: warty
+  cloud: test-cloud
+  region: test-cloud-region
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+  life: alive
+`
+
+var watchMigrationsTests = []struct {
+	name                string
+	sourceModelInfo     func(context.Context, *jujuparams.ModelInfo) error
+	targetModelInfo     func(context.Context, *jujuparams.ModelInfo) error
+	expectPhase         string
+	expectStatus        string
+	expectError         string
+	expectController    string
+	expectMigrationDone bool
+}{{
+	name: "InProgress",
+	sourceModelInfo: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+		mi.Migration = &jujuparams.ModelMigrationStatus{
+			Status: "importing",
+			Start:  &time.Time{},
+		}
+		return nil
+	},
+	expectPhase:      dbmodel.MigrationPhaseRunning,
+	expectStatus:     "importing",
+	expectController: "controller-1",
+}, {
+	name: "Done",
+	sourceModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return errors.E(errors.CodeNotFound, "model not found")
+	},
+	targetModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return nil
+	},
+	expectPhase:         dbmodel.MigrationPhaseDone,
+	expectController:    "controller-2",
+	expectMigrationDone: true,
+}, {
+	name: "Aborted",
+	sourceModelInfo: func(_ context.Context, mi *jujuparams.ModelInfo) error {
+		end := time.Now()
+		mi.Migration = &jujuparams.ModelMigrationStatus{
+			Status: "aborted, removing model from target controller: prechecks failed",
+			Start:  &time.Time{},
+			End:    &end,
+		}
+		return nil
+	},
+	expectPhase:         dbmodel.MigrationPhaseAborted,
+	expectStatus:        "aborted, removing model from target controller: prechecks failed",
+	expectError:         "aborted, removing model from target controller: prechecks failed",
+	expectController:    "controller-1",
+	expectMigrationDone: true,
+}, {
+	name: "Failed",
+	sourceModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return errors.E(errors.CodeNotFound, "model not found")
+	},
+	targetModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return errors.E(errors.CodeNotFound, "model not found")
+	},
+	expectPhase:         dbmodel.MigrationPhaseFailed,
+	expectError:         "model not found on source or target controller",
+	expectController:    "controller-1",
+	expectMigrationDone: true,
+}, {
+	name: "SourceUnauthorized",
+	sourceModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return errors.E(errors.CodeUnauthorized, "permission denied")
+	},
+	targetModelInfo: func(context.Context, *jujuparams.ModelInfo) error {
+		return nil
+	},
+	expectPhase:      dbmodel.MigrationPhaseRunning,
+	expectController: "controller-1",
+}}
+
+func TestWatchMigrations(t *testing.T) {
+	c := qt.New(t)
+
+	for _, test := range watchMigrationsTests {
+		c.Run(test.name, func(c *qt.C) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			w := &jimm.Watcher{
+				Database: db.Database{
+					DB: jimmtest.PostgresDB(c, nil),
+				},
+				Dialer: jimmtest.DialerMap{
+					"controller-1": &jimmtest.Dialer{
+						API: &jimmtest.API{
+							ModelInfo_: test.sourceModelInfo,
+						},
+					},
+					"controller-2": &jimmtest.Dialer{
+						API: &jimmtest.API{
+							ModelInfo_: test.targetModelInfo,
+						},
+					},
+				},
+			}
+			err := w.Database.Migrate(ctx, false)
+			c.Assert(err, qt.IsNil)
+			env := jimmtest.ParseEnvironment(c, testWatchMigrationsEnv)
+			env.PopulateDB(c, w.Database)
+
+			model := env.Model("alice@canonical.com", "model-1").DBObject(c, w.Database)
+			target := env.Controller("controller-2").DBObject(c, w.Database)
+			model.MigrationControllerID = sql.NullInt32{Int32: int32(target.ID), Valid: true}
+			err = w.Database.UpdateModel(ctx, &model)
+			c.Assert(err, qt.IsNil)
+			err = w.Database.AddModelMigration(ctx, &dbmodel.ModelMigration{
+				ModelID:            model.ID,
+				SourceControllerID: model.ControllerID,
+				TargetControllerID: target.ID,
+				InitiatedBy:        "alice@canonical.com",
+				Phase:              dbmodel.MigrationPhaseRunning,
+				StartedAt:          time.Now(),
+			})
+			c.Assert(err, qt.IsNil)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := w.WatchMigrations(ctx, 10*time.Millisecond)
+				checkIfContextCanceled(c, ctx, err)
+			}()
+
+			var migration dbmodel.ModelMigration
+			for i := 0; i < 500; i++ {
+				err = w.Database.ForEachModelMigration(ctx, db.ModelMigrationFilter{ModelID: model.ID}, func(m *dbmodel.ModelMigration) error {
+					migration = *m
+					return nil
+				})
+				c.Assert(err, qt.IsNil)
+				if migration.Phase != dbmodel.MigrationPhaseRunning || migration.Status != "" {
+					break
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			cancel()
+			wg.Wait()
+
+			c.Check(migration.Phase, qt.Equals, test.expectPhase)
+			c.Check(migration.Status, qt.Equals, test.expectStatus)
+			c.Check(migration.Error, qt.Equals, test.expectError)
+			c.Check(migration.FinishedAt.Valid, qt.Equals, test.expectMigrationDone)
+
+			m := dbmodel.Model{ID: model.ID}
+			err = w.Database.GetModel(context.Background(), &m)
+			c.Assert(err, qt.IsNil)
+			c.Check(m.Controller.Name, qt.Equals, test.expectController)
+			c.Check(m.MigrationControllerID.Valid, qt.Equals, !test.expectMigrationDone)
+		})
+	}
+}
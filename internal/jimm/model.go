@@ -259,13 +259,16 @@ func (b *modelBuilder) WithCloudRegion(region string) *modelBuilder {
 			b.err = errors.E(errors.CodeBadRequest, fmt.Sprintf("unsupported cloud region %s/%s", b.cloud.Name, region))
 			return b
 		}
-		// shuffle controllers
-		shuffleRegionControllers(regionControllers)
+		// select the most suitable controller
+		rc, err := b.jimm.selectRegionController(b.ctx, regionControllers)
+		if err != nil {
+			b.err = errors.E(err, fmt.Sprintf("cannot add model to cloud region %s/%s: %s", b.cloud.Name, region, err))
+			return b
+		}
 
-		// and select the first controller in the slice
 		b.cloudRegion = region
-		b.cloudRegionID = regionControllers[0].CloudRegionID
-		b.controller = &regionControllers[0].Controller
+		b.cloudRegionID = rc.CloudRegionID
+		b.controller = &rc.Controller
 
 		break
 	}
@@ -422,11 +425,14 @@ func (b *modelBuilder) selectCloudRegion() error {
 		return errors.E(fmt.Sprintf("unsupported cloud %s", b.cloud.Name))
 	}
 
-	// shuffle controllers according to their priority
-	shuffleRegionControllers(regionControllers)
+	// select the most suitable controller across all regions
+	rc, err := b.jimm.selectRegionController(b.ctx, regionControllers)
+	if err != nil {
+		return errors.E(err, fmt.Sprintf("cannot add model to cloud %s: %s", b.cloud.Name, err))
+	}
 
-	b.cloudRegionID = regionControllers[0].CloudRegionID
-	b.controller = &regionControllers[0].Controller
+	b.cloudRegionID = rc.CloudRegionID
+	b.controller = &rc.Controller
 
 	return nil
 }
@@ -756,6 +762,45 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 	modelInfo.ControllerUUID = jimmSummary.ControllerUUID
 	modelInfo.OwnerTag = jimmSummary.OwnerTag
 
+	userAccess, err := j.modelUserAccess(ctx, jimmModel.ResourceTag())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	modelAccess, err := j.GetUserModelAccess(ctx, user, jimmModel.ResourceTag())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	users := make([]jujuparams.ModelUserInfo, 0, len(userAccess))
+	for username, access := range userAccess {
+		// If the user does not contain an "@" sign (no domain), it means
+		// this is a local user of this controller and JIMM does not
+		// care or know about local users.
+		if !strings.Contains(username, "@") {
+			continue
+		}
+		if modelAccess == "admin" || username == user.Name || username == ofganames.EveryoneUser {
+			users = append(users, jujuparams.ModelUserInfo{
+				UserName: username,
+				Access:   jujuparams.UserAccessPermission(access),
+			})
+		}
+	}
+	modelInfo.Users = users
+
+	if modelAccess != "admin" && modelAccess != "write" {
+		// Users need "write" level access (or above) to see machine
+		// information.
+		modelInfo.Machines = nil
+	}
+
+	return modelInfo, nil
+}
+
+// modelUserAccess returns the highest level of access each user has to
+// the given model, keyed by user name.
+func (j *JIMM) modelUserAccess(ctx context.Context, mt names.ModelTag) (map[string]string, error) {
 	userAccess := make(map[string]string)
 
 	for _, relation := range []openfga.Relation{
@@ -765,9 +810,9 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 		ofganames.WriterRelation,
 		ofganames.ReaderRelation,
 	} {
-		usersWithSpecifiedRelation, err := openfga.ListUsersWithAccess(ctx, j.OpenFGAClient, jimmModel.ResourceTag(), relation)
+		usersWithSpecifiedRelation, err := openfga.ListUsersWithAccess(ctx, j.OpenFGAClient, mt, relation)
 		if err != nil {
-			return nil, errors.E(op, err)
+			return nil, err
 		}
 		for _, u := range usersWithSpecifiedRelation {
 			// Since we are checking user relations in decreasing level of
@@ -778,36 +823,73 @@ func (j *JIMM) mergeModelInfo(ctx context.Context, user *openfga.User, modelInfo
 			}
 		}
 	}
+	return userAccess, nil
+}
 
-	modelAccess, err := j.GetUserModelAccess(ctx, user, jimmModel.ResourceTag())
+// ModelUserInfo returns information on the users that have access to the
+// given model, as recorded in OpenFGA. Model administrators see every user,
+// other users with read access see only themselves and the everyone user.
+func (j *JIMM) ModelUserInfo(ctx context.Context, user *openfga.User, mt names.ModelTag) ([]jujuparams.ModelUserInfo, error) {
+	const op = errors.Op("jimm.ModelUserInfo")
+
+	var m dbmodel.Model
+	m.SetTag(mt)
+	if err := j.Database.GetModel(ctx, &m); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	modelAccess, err := j.GetUserModelAccess(ctx, user, mt)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if modelAccess == "" {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	userAccess, err := j.modelUserAccess(ctx, mt)
 	if err != nil {
 		return nil, errors.E(op, err)
 	}
 
 	users := make([]jujuparams.ModelUserInfo, 0, len(userAccess))
 	for username, access := range userAccess {
-		// If the user does not contain an "@" sign (no domain), it means
-		// this is a local user of this controller and JIMM does not
-		// care or know about local users.
 		if !strings.Contains(username, "@") {
 			continue
 		}
-		if modelAccess == "admin" || username == user.Name || username == ofganames.EveryoneUser {
-			users = append(users, jujuparams.ModelUserInfo{
-				UserName: username,
-				Access:   jujuparams.UserAccessPermission(access),
-			})
+		if modelAccess != "admin" && username != user.Name && username != ofganames.EveryoneUser {
+			continue
 		}
+		info := jujuparams.ModelUserInfo{
+			ModelTag:    mt.String(),
+			UserName:    username,
+			DisplayName: username,
+			Access:      jujuparams.UserAccessPermission(access),
+		}
+		if username != ofganames.EveryoneUser {
+			identity, err := dbmodel.NewIdentity(username)
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			err = j.Database.FetchIdentity(ctx, identity)
+			switch {
+			case err == nil:
+				info.DisplayName = identity.DisplayName
+				if identity.LastLogin.Valid {
+					info.LastConnection = &identity.LastLogin.Time
+				}
+			case errors.ErrorCode(err) == errors.CodeNotFound:
+				// The identity has a relation to the model but has
+				// never logged in, so there is nothing more to add.
+			default:
+				return nil, errors.E(op, err)
+			}
+		}
+		users = append(users, info)
 	}
-	modelInfo.Users = users
-
-	if modelAccess != "admin" && modelAccess != "write" {
-		// Users need "write" level access (or above) to see machine
-		// information.
-		modelInfo.Machines = nil
-	}
-
-	return modelInfo, nil
+	sort.Slice(users, func(i, j int) bool {
+		return users[i].UserName < users[j].UserName
+	})
+	return users, nil
 }
 
 // ModelStatus returns a jujuparams.ModelStatus for the given model. If
@@ -0,0 +1,91 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+// SelectModels returns the UUIDs of the models in modelUUIDs that match
+// the given selector. The order of the models is maintained. If the
+// selector is empty modelUUIDs is returned unchanged.
+func (j *JIMM) SelectModels(ctx context.Context, modelUUIDs []string, selector params.ModelSelector) ([]string, error) {
+	const op = errors.Op("jimm.SelectModels")
+
+	if selector == (params.ModelSelector{}) {
+		return modelUUIDs, nil
+	}
+	if selector.Name != "" {
+		if _, err := path.Match(selector.Name, ""); err != nil {
+			return nil, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("invalid model name pattern %q", selector.Name))
+		}
+	}
+
+	var groupModels map[string]bool
+	if selector.Group != "" {
+		group := dbmodel.GroupEntry{Name: selector.Group}
+		if err := j.Database.GetGroup(ctx, &group); err != nil {
+			return nil, errors.E(op, err)
+		}
+		entities, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation), ofganames.AdministratorRelation, openfga.ModelType, nil)
+		if err != nil {
+			return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		groupModels = make(map[string]bool, len(entities))
+		for _, e := range entities {
+			groupModels[e.ID] = true
+		}
+	}
+
+	models, err := j.Database.GetModelsByUUID(ctx, modelUUIDs)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	selected := make(map[string]bool, len(models))
+	for _, m := range models {
+		selected[m.UUID.String] = matchModelSelector(&m, selector, groupModels)
+	}
+
+	var uuids []string
+	for _, uuid := range modelUUIDs {
+		if selected[uuid] {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids, nil
+}
+
+// matchModelSelector returns whether the given model matches the
+// selector. If the selector includes a group, groupModels holds the UUIDs
+// of the models the group administers.
+func matchModelSelector(m *dbmodel.Model, selector params.ModelSelector, groupModels map[string]bool) bool {
+	if selector.Controller != "" && m.Controller.Name != selector.Controller {
+		return false
+	}
+	if selector.Cloud != "" && m.CloudRegion.Cloud.Name != selector.Cloud {
+		return false
+	}
+	if selector.Region != "" && m.CloudRegion.Name != selector.Region {
+		return false
+	}
+	if selector.Owner != "" && m.OwnerIdentityName != selector.Owner {
+		return false
+	}
+	if selector.Name != "" {
+		if ok, _ := path.Match(selector.Name, m.Name); !ok {
+			return false
+		}
+	}
+	if selector.Group != "" && !groupModels[m.UUID.String] {
+		return false
+	}
+	return true
+}
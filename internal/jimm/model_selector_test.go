@@ -0,0 +1,194 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+	"github.com/juju/names/v5"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+const selectModelsTestEnv = `clouds:
+- name: test-cloud
+  type: test-provider
+  regions:
+  - name: region-1
+  - name: region-2
+cloud-credentials:
+- owner: alice@canonical.com
+  name: cred-1
+  cloud: test-cloud
+- owner: bob@canonical.com
+  name: cred-1
+  cloud: test-cloud
+controllers:
+- name: controller-1
+  uuid: 00000001-0000-0000-0000-000000000001
+  cloud: test-cloud
+  region: region-1
+- name: controller-2
+  uuid: 00000001-0000-0000-0000-000000000002
+  cloud: test-cloud
+  region: region-2
+models:
+- name: db-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000001
+  controller: controller-1
+  cloud: test-cloud
+  region: region-1
+  cloud-credential: cred-1
+  owner: alice@canonical.com
+- name: db-2
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000002
+  controller: controller-2
+  cloud: test-cloud
+  region: region-2
+  cloud-credential: cred-1
+  owner: bob@canonical.com
+- name: web-1
+  type: iaas
+  uuid: 00000002-0000-0000-0000-000000000003
+  controller: controller-1
+  cloud: test-cloud
+  region: region-1
+  cloud-credential: cred-1
+  owner: bob@canonical.com
+`
+
+var selectModelsTests = []struct {
+	name         string
+	selector     params.ModelSelector
+	expectModels []string
+	expectError  string
+}{{
+	name:         "Empty",
+	expectModels: []string{"db-1", "db-2", "web-1"},
+}, {
+	name:         "Controller",
+	selector:     params.ModelSelector{Controller: "controller-1"},
+	expectModels: []string{"db-1", "web-1"},
+}, {
+	name:         "CloudRegion",
+	selector:     params.ModelSelector{Cloud: "test-cloud", Region: "region-2"},
+	expectModels: []string{"db-2"},
+}, {
+	name:     "UnknownCloud",
+	selector: params.ModelSelector{Cloud: "no-such-cloud"},
+}, {
+	name:         "Owner",
+	selector:     params.ModelSelector{Owner: "bob@canonical.com"},
+	expectModels: []string{"db-2", "web-1"},
+}, {
+	name:         "Name",
+	selector:     params.ModelSelector{Name: "db-*"},
+	expectModels: []string{"db-1", "db-2"},
+}, {
+	name:         "Combined",
+	selector:     params.ModelSelector{Name: "db-*", Owner: "bob@canonical.com"},
+	expectModels: []string{"db-2"},
+}, {
+	name:         "Group",
+	selector:     params.ModelSelector{Group: "test-group"},
+	expectModels: []string{"db-1", "web-1"},
+}, {
+	name:         "GroupAndName",
+	selector:     params.ModelSelector{Group: "test-group", Name: "web-?"},
+	expectModels: []string{"web-1"},
+}, {
+	name:        "UnknownGroup",
+	selector:    params.ModelSelector{Group: "no-such-group"},
+	expectError: `.*not found.*`,
+}, {
+	name:        "InvalidName",
+	selector:    params.ModelSelector{Name: "db-["},
+	expectError: `invalid model name pattern "db-\["`,
+}}
+
+func TestSelectModels(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, time.Now),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env := jimmtest.ParseEnvironment(c, selectModelsTestEnv)
+	env.PopulateDB(c, j.Database)
+
+	modelNames := map[string]string{
+		"00000002-0000-0000-0000-000000000001": "db-1",
+		"00000002-0000-0000-0000-000000000002": "db-2",
+		"00000002-0000-0000-0000-000000000003": "web-1",
+	}
+	allUUIDs := []string{
+		"00000002-0000-0000-0000-000000000001",
+		"00000002-0000-0000-0000-000000000002",
+		"00000002-0000-0000-0000-000000000003",
+	}
+
+	// The group administers db-1 and web-1.
+	group, err := j.Database.AddGroup(ctx, "test-group")
+	c.Assert(err, qt.IsNil)
+	for _, modelUUID := range []string{allUUIDs[0], allUUIDs[2]} {
+		err = client.AddRelation(ctx, openfga.Tuple{
+			Object:   ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation),
+			Relation: ofganames.AdministratorRelation,
+			Target:   ofganames.ConvertTag(names.NewModelTag(modelUUID)),
+		})
+		c.Assert(err, qt.IsNil)
+	}
+
+	for _, test := range selectModelsTests {
+		c.Run(test.name, func(c *qt.C) {
+			uuids, err := j.SelectModels(ctx, allUUIDs, test.selector)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			var selected []string
+			for _, modelUUID := range uuids {
+				selected = append(selected, modelNames[modelUUID])
+			}
+			c.Check(selected, qt.DeepEquals, test.expectModels)
+		})
+	}
+}
+
+func TestSelectModelsWithoutDatabase(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{}
+	uuids := []string{"00000002-0000-0000-0000-000000000001"}
+
+	// An empty selector doesn't need to look up the models.
+	selected, err := j.SelectModels(context.Background(), uuids, params.ModelSelector{})
+	c.Assert(err, qt.IsNil)
+	c.Check(selected, qt.DeepEquals, uuids)
+
+	_, err = j.SelectModels(context.Background(), uuids, params.ModelSelector{Name: "["})
+	c.Check(err, qt.ErrorMatches, `invalid model name pattern "\["`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
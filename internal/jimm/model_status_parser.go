@@ -4,8 +4,10 @@ package jimm
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
 
-	"github.com/itchyny/gojq"
 	jujucmd "github.com/juju/cmd/v3"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/juju/storage"
@@ -19,85 +21,252 @@ import (
 	"github.com/canonical/jimm/v3/pkg/api/params"
 )
 
-// QueryModels queries every specified model in modelUUIDs.
+var (
+	// queryModelsConcurrency is the maximum number of models that are
+	// queried at the same time by QueryModels.
+	queryModelsConcurrency = 32
+
+	// queryModelsControllerConcurrency is the maximum number of models
+	// hosted on a single controller that are queried at the same time by
+	// QueryModels.
+	queryModelsControllerConcurrency = 8
+
+	// queryModelTimeout is the maximum time QueryModels spends
+	// retrieving the status of a single model.
+	queryModelTimeout = 30 * time.Second
+
+	// modelStatusCacheTTL is the length of time a model status retrieved
+	// by QueryModels is reused for subsequent queries. A value of 0
+	// disables the cache.
+	modelStatusCacheTTL = 30 * time.Second
+
+	// modelStatusCacheSweepInterval is the minimum interval between
+	// sweeps of the model status cache for expired entries.
+	modelStatusCacheSweepInterval = time.Minute
+)
+
+// QueryModels queries every specified model in modelUUIDs using the
+// registered query engine with the given name.
 //
-// The jqQuery must be a valid jq query and can return every result, even iterative listings.
+// The query must be valid for the query engine and can return every result, even iterative listings.
 // If a result is erroneous, for example, bad data type parsing, the resulting struct field
 // Errors will contain a map from model UUID -> []error. Otherwise, the Results field
-// will contain model UUID -> []Jq result.
-func (j *JIMM) QueryModelsJq(ctx context.Context, modelUUIDs []string, jqQuery string) (params.CrossModelQueryResponse, error) {
+// will contain model UUID -> []query result.
+//
+// Models are queried in parallel, with a limit on the number of models
+// queried on each controller at the same time. Model statuses are cached
+// for a short time so that repeated queries do not need to contact the
+// controllers again.
+func (j *JIMM) QueryModels(ctx context.Context, modelUUIDs []string, queryType, query string) (params.CrossModelQueryResponse, error) {
 	op := errors.Op("QueryModels")
 	results := params.CrossModelQueryResponse{
 		Results: make(map[string][]any),
 		Errors:  make(map[string][]string),
 	}
 
-	query, err := gojq.Parse(jqQuery)
+	engine, ok := lookupQueryEngine(queryType)
+	if !ok {
+		return results, errors.E(op, errors.Code("invalid query type"), "unable to query models")
+	}
+	q, err := engine.Compile(query)
 	if err != nil {
-		return results, errors.E(op, "failed to parse jq query", err)
+		return results, errors.E(op, errors.CodeBadRequest, fmt.Sprintf("failed to parse %s query: %s", queryType, err), err)
 	}
 
-	// Set up a formatterParamsRetriever to handle the heavy lifting
-	// of each facade call and type conversion.
-	retriever := newFormatterParamsRetriever(j)
-
 	models, err := j.Database.GetModelsByUUID(ctx, modelUUIDs)
 	if err != nil {
 		return results, errors.E(op, "failed to get models for user")
 	}
 
+	sem := make(chan struct{}, queryModelsConcurrency)
+	controllerSems := controllerSemaphores(models)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
 	for _, model := range models {
-		modelUUID := model.UUID.String
-		params, err := retriever.GetParams(ctx, model)
-		if err != nil {
-			zapctx.Error(ctx, "failed to get status formatter params", zap.String("model-uuid", modelUUID))
-			results.Errors[modelUUID] = append(results.Errors[modelUUID], err.Error())
-			continue
-		}
+		wg.Add(1)
+		go func(model dbmodel.Model) {
+			defer wg.Done()
+			modelUUID := model.UUID.String
+			values, errs := j.queryModel(ctx, queryType, q, model, controllerSems[model.ControllerID], sem)
 
-		// We use very specific formatting parameters to ensure like-for-like output
-		// with the default juju client installation performing a "status --format json".
-		formatter := status.NewStatusFormatter(*params)
+			mu.Lock()
+			defer mu.Unlock()
+			if len(values) > 0 {
+				results.Results[modelUUID] = values
+			}
+			if len(errs) > 0 {
+				results.Errors[modelUUID] = errs
+			}
+		}(model)
+	}
+	wg.Wait()
+	return results, nil
+}
 
-		formattedStatus, err := formatter.Format()
-		if err != nil {
-			zapctx.Error(ctx, "failed to format status", zap.String("model-uuid", modelUUID))
-			results.Errors[modelUUID] = append(results.Errors[modelUUID], err.Error())
-			continue
+// QueryModelsJq queries every specified model in modelUUIDs using a jq
+// query. See QueryModels for details.
+func (j *JIMM) QueryModelsJq(ctx context.Context, modelUUIDs []string, jqQuery string) (params.CrossModelQueryResponse, error) {
+	return j.QueryModels(ctx, modelUUIDs, QueryEngineJq, jqQuery)
+}
+
+// queryModel runs the given query against the status of the given
+// model. The status is retrieved once a slot is available in both the
+// controller and global semaphores. Query results and errors are returned
+// separately, errors from the query are prefixed with the query type.
+func (j *JIMM) queryModel(ctx context.Context, queryType string, q Query, model dbmodel.Model, controllerSem, sem chan struct{}) ([]any, []string) {
+	modelUUID := model.UUID.String
+	fb, err := j.cachedModelStatus(ctx, model, controllerSem, sem)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	// The cached status is decoded for every query so that queries
+	// never share values.
+	status := make(map[string]any)
+	if err := json.Unmarshal(fb, &status); err != nil {
+		return nil, []string{err.Error()}
+	}
+
+	values, queryErrs := q.Run(ctx, status)
+	var errs []string
+	for _, err := range queryErrs {
+		zapctx.Debug(ctx, "query error", zap.String("model-uuid", modelUUID), zap.String("type", queryType), zap.Error(err))
+		errs = append(errs, queryType+" error: "+err.Error())
+	}
+	return values, errs
+}
+
+// controllerSemaphores returns a semaphore for each controller hosting
+// the given models, limiting the number of models on that controller
+// whose status is retrieved at the same time.
+func controllerSemaphores(models []dbmodel.Model) map[uint]chan struct{} {
+	sems := make(map[uint]chan struct{})
+	for _, model := range models {
+		if _, ok := sems[model.ControllerID]; !ok {
+			sems[model.ControllerID] = make(chan struct{}, queryModelsControllerConcurrency)
+		}
+	}
+	return sems
+}
+
+// cachedModelStatus returns the formatted status of the given model as
+// JSON, as would be output by "juju status --format json". If the model
+// status is not in the cache it is retrieved from the controller, waiting
+// for a slot in both of the given semaphores first.
+func (j *JIMM) cachedModelStatus(ctx context.Context, model dbmodel.Model, controllerSem, sem chan struct{}) ([]byte, error) {
+	modelUUID := model.UUID.String
+	fb, ok := j.modelStatuses.get(modelUUID, time.Now())
+	if !ok {
+		for _, s := range []chan struct{}{controllerSem, sem} {
+			select {
+			case s <- struct{}{}:
+				defer func(s chan struct{}) { <-s }(s)
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 		}
-		// We could use output.NewFormatter() from 3.0+ juju/juju, but ultimately
-		// we just want some JSON output, regardless of user formatting. As such json.Marshal
-		// *should* be OK. But TODO: make sure this is fine.
-		fb, err := json.Marshal(formattedStatus)
+		var err error
+		fb, err = j.modelStatus(ctx, model)
 		if err != nil {
-			zapctx.Error(ctx, "failed to marshal formatted status", zap.String("model-uuid", modelUUID))
-			results.Errors[modelUUID] = append(results.Errors[modelUUID], err.Error())
-			continue
+			return nil, err
 		}
-		tempMap := make(map[string]any)
-		if err := json.Unmarshal(fb, &tempMap); err != nil {
-			return results, errors.E(op, err)
+		if modelStatusCacheTTL > 0 {
+			j.modelStatuses.put(modelUUID, fb, time.Now().Add(modelStatusCacheTTL))
 		}
-		queryIter := query.RunWithContext(ctx, tempMap)
+	}
+	return fb, nil
+}
 
-		for {
-			v, ok := queryIter.Next()
-			if !ok {
-				break
-			}
+// modelStatus retrieves the status of the given model from its controller
+// and returns it formatted as JSON.
+func (j *JIMM) modelStatus(ctx context.Context, model dbmodel.Model) ([]byte, error) {
+	modelUUID := model.UUID.String
+	ctx, cancel := context.WithTimeout(ctx, queryModelTimeout)
+	defer cancel()
 
-			// Jq errors can range from one failure in an iterative query to an entirely broken
-			// query. As such, we simply append all to the errors field and continue to collect
-			// both erreoneous and valid query results.
-			if err, ok := v.(error); ok {
-				results.Errors[modelUUID] = append(results.Errors[modelUUID], "jq error: "+err.Error())
-				continue
-			}
+	// Set up a formatterParamsRetriever to handle the heavy lifting
+	// of each facade call and type conversion.
+	retriever := newFormatterParamsRetriever(j)
+	params, err := retriever.GetParams(ctx, model)
+	if err != nil {
+		zapctx.Error(ctx, "failed to get status formatter params", zap.String("model-uuid", modelUUID))
+		return nil, err
+	}
 
-			results.Results[modelUUID] = append(results.Results[modelUUID], v)
+	// We use very specific formatting parameters to ensure like-for-like output
+	// with the default juju client installation performing a "status --format json".
+	formatter := status.NewStatusFormatter(*params)
+
+	formattedStatus, err := formatter.Format()
+	if err != nil {
+		zapctx.Error(ctx, "failed to format status", zap.String("model-uuid", modelUUID))
+		return nil, err
+	}
+	// We could use output.NewFormatter() from 3.0+ juju/juju, but ultimately
+	// we just want some JSON output, regardless of user formatting. As such json.Marshal
+	// *should* be OK. But TODO: make sure this is fine.
+	fb, err := json.Marshal(formattedStatus)
+	if err != nil {
+		zapctx.Error(ctx, "failed to marshal formatted status", zap.String("model-uuid", modelUUID))
+		return nil, err
+	}
+	return fb, nil
+}
+
+// modelStatusCache holds recently retrieved model statuses, keyed by
+// model UUID.
+type modelStatusCache struct {
+	mu        sync.Mutex
+	entries   map[string]modelStatusCacheEntry
+	nextSweep time.Time
+}
+
+type modelStatusCacheEntry struct {
+	status  []byte
+	expires time.Time
+}
+
+// get returns the cached status of the given model if there is one that
+// has not expired at the given time. An expired entry is removed from the
+// cache.
+func (c *modelStatusCache) get(modelUUID string, now time.Time) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[modelUUID]
+	if !ok {
+		return nil, false
+	}
+	if !now.Before(e.expires) {
+		delete(c.entries, modelUUID)
+		return nil, false
+	}
+	return e.status, true
+}
+
+// put caches the status of the given model until the given expiry time.
+// Expired entries for models that are not requested again are removed by
+// a sweep of the cache at most once every modelStatusCacheSweepInterval.
+func (c *modelStatusCache) put(modelUUID string, status []byte, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries == nil {
+		c.entries = make(map[string]modelStatusCacheEntry)
+	}
+	now := time.Now()
+	if !now.Before(c.nextSweep) {
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
 		}
+		c.nextSweep = now.Add(modelStatusCacheSweepInterval)
+	}
+	c.entries[modelUUID] = modelStatusCacheEntry{
+		status:  status,
+		expires: expires,
 	}
-	return results, nil
 }
 
 // formatterParamsRetriever is a self-contained block of
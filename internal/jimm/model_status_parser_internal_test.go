@@ -0,0 +1,44 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestModelStatusCache(t *testing.T) {
+	c := qt.New(t)
+
+	var cache modelStatusCache
+	now := time.Now()
+
+	_, ok := cache.get("model-1", now)
+	c.Check(ok, qt.IsFalse)
+
+	cache.put("model-1", []byte(`{"model":{}}`), now.Add(time.Minute))
+	status, ok := cache.get("model-1", now)
+	c.Check(ok, qt.IsTrue)
+	c.Check(string(status), qt.Equals, `{"model":{}}`)
+
+	// Entries are not returned once they have expired, and are removed
+	// from the cache.
+	_, ok = cache.get("model-1", now.Add(time.Minute))
+	c.Check(ok, qt.IsFalse)
+	c.Check(cache.entries, qt.HasLen, 0)
+
+	// Expired entries are not swept more often than the sweep interval.
+	cache.put("model-2", []byte(`{}`), now.Add(-time.Second))
+	cache.put("model-3", []byte(`{}`), now.Add(time.Minute))
+	c.Check(cache.entries, qt.HasLen, 2)
+
+	// Expired entries are removed when a new entry is added once the
+	// sweep interval has passed.
+	cache.nextSweep = time.Now()
+	cache.put("model-4", []byte(`{}`), now.Add(time.Minute))
+	c.Check(cache.entries, qt.HasLen, 2)
+	_, ok = cache.entries["model-2"]
+	c.Check(ok, qt.IsFalse)
+}
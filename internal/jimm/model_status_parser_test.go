@@ -3,6 +3,7 @@ package jimm_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -770,3 +771,73 @@ func TestQueryModelsJq(t *testing.T) {
 	}
 	`, qt.JSONEquals, res)
 }
+
+func TestQueryModelsJqCacheAndTimeout(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	c.Patch(jimm.QueryModelTimeout, 10*time.Millisecond)
+	c.Patch(jimm.ModelStatusCacheTTL, time.Minute)
+
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	var statusCalls atomic.Int32
+	storageAPI := jimmtest.API{
+		ListFilesystems_: func(ctx context.Context, machines []string) ([]jujuparams.FilesystemDetailsListResult, error) {
+			return []jujuparams.FilesystemDetailsListResult{}, nil
+		},
+		ListVolumes_: func(ctx context.Context, machines []string) ([]jujuparams.VolumeDetailsListResult, error) {
+			return []jujuparams.VolumeDetailsListResult{}, nil
+		},
+		ListStorageDetails_: func(ctx context.Context) ([]jujuparams.StorageDetails, error) {
+			return []jujuparams.StorageDetails{}, nil
+		},
+	}
+	model1API := storageAPI
+	model1API.Status_ = func(_ context.Context, _ []string) (*jujuparams.FullStatus, error) {
+		statusCalls.Add(1)
+		return &model1, nil
+	}
+	model2API := storageAPI
+	model2API.Status_ = func(ctx context.Context, _ []string) (*jujuparams.FullStatus, error) {
+		// Model 2 never responds.
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+		Dialer: jimmtest.ModelDialerMap{
+			"10000000-0000-0000-0000-000000000000": &jimmtest.Dialer{API: &model1API},
+			"20000000-0000-0000-0000-000000000000": &jimmtest.Dialer{API: &model2API},
+		},
+	}
+
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	env := jimmtest.ParseEnvironment(c, crossModelQueryEnv)
+	env.PopulateDB(c, j.Database)
+
+	modelUUIDs := []string{
+		"10000000-0000-0000-0000-000000000000",
+		"20000000-0000-0000-0000-000000000000",
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := j.QueryModelsJq(ctx, modelUUIDs, ".model.name")
+		c.Assert(err, qt.IsNil)
+		c.Check(res.Results, qt.DeepEquals, map[string][]any{
+			"10000000-0000-0000-0000-000000000000": {"model-1"},
+		})
+		c.Check(res.Errors, qt.HasLen, 1)
+		c.Check(res.Errors["20000000-0000-0000-0000-000000000000"], qt.HasLen, 1)
+	}
+	// The status of model 1 was cached by the first query.
+	c.Check(statusCalls.Load(), qt.Equals, int32(1))
+}
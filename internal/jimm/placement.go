@@ -0,0 +1,341 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+const (
+	// PlacementPolicyPriority places new models on the controller with
+	// the highest cloud-region priority. This is the default policy.
+	PlacementPolicyPriority = "priority"
+
+	// PlacementPolicyLeastModels places new models on the controller
+	// hosting the fewest models.
+	PlacementPolicyLeastModels = "least-models"
+
+	// PlacementPolicyLeastUnits places new models on the controller
+	// hosting the fewest units.
+	PlacementPolicyLeastUnits = "least-units"
+
+	// PlacementPolicyLeastMachines places new models on the controller
+	// hosting the fewest machines.
+	PlacementPolicyLeastMachines = "least-machines"
+
+	// placementConfigName is the name of the configuration record that
+	// holds JIMM's placement settings. It is stored separately from
+	// JIMM's controller config so that it is not reported as controller
+	// configuration.
+	placementConfigName = "placement"
+
+	// placementPolicyConfigKey is the key in the placement configuration
+	// that holds the name of the selected placement policy.
+	placementPolicyConfigKey = "policy"
+)
+
+// A PlacementCandidate is a controller that could host a new model,
+// along with the current load on that controller.
+type PlacementCandidate struct {
+	dbmodel.CloudRegionControllerPriority
+
+	// Models is the number of models hosted on the controller.
+	Models int
+
+	// Units is the number of units hosted on the controller.
+	Units int64
+
+	// Machines is the number of machines hosted on the controller.
+	Machines int64
+}
+
+// A PlacementPolicy decides which controller should host a new model.
+type PlacementPolicy interface {
+	// Less reports whether candidate a should be preferred over
+	// candidate b. Candidates that compare equal are tried in a random
+	// order.
+	Less(a, b *PlacementCandidate) bool
+}
+
+// A PlacementPolicyFunc is a function that implements PlacementPolicy.
+type PlacementPolicyFunc func(a, b *PlacementCandidate) bool
+
+// Less implements PlacementPolicy.
+func (f PlacementPolicyFunc) Less(a, b *PlacementCandidate) bool {
+	return f(a, b)
+}
+
+var (
+	placementPoliciesMu sync.RWMutex
+	placementPolicies   = map[string]PlacementPolicy{
+		PlacementPolicyPriority: PlacementPolicyFunc(func(a, b *PlacementCandidate) bool {
+			return a.Priority > b.Priority
+		}),
+		PlacementPolicyLeastModels: PlacementPolicyFunc(func(a, b *PlacementCandidate) bool {
+			if a.Models != b.Models {
+				return a.Models < b.Models
+			}
+			return a.Priority > b.Priority
+		}),
+		PlacementPolicyLeastUnits: PlacementPolicyFunc(func(a, b *PlacementCandidate) bool {
+			if a.Units != b.Units {
+				return a.Units < b.Units
+			}
+			return a.Priority > b.Priority
+		}),
+		PlacementPolicyLeastMachines: PlacementPolicyFunc(func(a, b *PlacementCandidate) bool {
+			if a.Machines != b.Machines {
+				return a.Machines < b.Machines
+			}
+			return a.Priority > b.Priority
+		}),
+	}
+)
+
+// RegisterPlacementPolicy makes a placement policy available under the
+// given name, replacing any policy previously registered with that name.
+func RegisterPlacementPolicy(name string, p PlacementPolicy) {
+	placementPoliciesMu.Lock()
+	defer placementPoliciesMu.Unlock()
+	placementPolicies[name] = p
+}
+
+// PlacementPolicies returns the names of all the registered placement
+// policies.
+func PlacementPolicies() []string {
+	placementPoliciesMu.RLock()
+	defer placementPoliciesMu.RUnlock()
+	policies := make([]string, 0, len(placementPolicies))
+	for name := range placementPolicies {
+		policies = append(policies, name)
+	}
+	sort.Strings(policies)
+	return policies
+}
+
+func lookupPlacementPolicy(name string) (PlacementPolicy, bool) {
+	placementPoliciesMu.RLock()
+	defer placementPoliciesMu.RUnlock()
+	p, ok := placementPolicies[name]
+	return p, ok
+}
+
+// SetPlacementPolicy sets the policy used to select the controller that
+// hosts new models. Only JIMM administrators may set the placement policy.
+func (j *JIMM) SetPlacementPolicy(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.SetPlacementPolicy")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if _, ok := lookupPlacementPolicy(name); !ok {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("unknown placement policy %q, expected one of %s", name, strings.Join(PlacementPolicies(), ", ")))
+	}
+	err := j.Database.Transaction(func(tx *db.Database) error {
+		config := dbmodel.ControllerConfig{
+			Name: placementConfigName,
+		}
+		err := tx.GetControllerConfig(ctx, &config)
+		if err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+			return err
+		}
+		if config.Config == nil {
+			config.Config = make(map[string]interface{})
+		}
+		config.Config[placementPolicyConfigKey] = name
+		return tx.UpsertControllerConfig(ctx, &config)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// placementPolicy returns the name of the currently selected placement
+// policy and the policy itself. If no policy has been selected, or the
+// selected policy is no longer registered, the priority policy is used.
+func (j *JIMM) placementPolicy(ctx context.Context) (string, PlacementPolicy, error) {
+	config := dbmodel.ControllerConfig{
+		Name: placementConfigName,
+	}
+	if err := j.Database.GetControllerConfig(ctx, &config); err != nil && errors.ErrorCode(err) != errors.CodeNotFound {
+		return "", nil, err
+	}
+	name, _ := config.Config[placementPolicyConfigKey].(string)
+	if name == "" {
+		name = PlacementPolicyPriority
+	}
+	p, ok := lookupPlacementPolicy(name)
+	if !ok {
+		zapctx.Warn(ctx, "unknown placement policy, using default", zap.String("policy", name))
+		name = PlacementPolicyPriority
+		p, _ = lookupPlacementPolicy(name)
+	}
+	return name, p, nil
+}
+
+// placementCandidates creates the placement candidates for the given
+// controllers. The current load on each controller is only determined
+// when it is needed, that is when the placement policy compares load or
+// when a controller has a model limit.
+func (j *JIMM) placementCandidates(ctx context.Context, policy string, controllers []dbmodel.CloudRegionControllerPriority) ([]PlacementCandidate, error) {
+	candidates := make([]PlacementCandidate, len(controllers))
+	needLoad := policy != PlacementPolicyPriority
+	for i, rc := range controllers {
+		candidates[i].CloudRegionControllerPriority = rc
+		if rc.Controller.MaxModels > 0 {
+			needLoad = true
+		}
+	}
+	if !needLoad {
+		return candidates, nil
+	}
+
+	ids := make([]uint, len(controllers))
+	for i, rc := range controllers {
+		ids[i] = rc.Controller.ID
+	}
+	loads, err := j.Database.ControllerLoads(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		load := loads[candidates[i].Controller.ID]
+		candidates[i].Models = load.Models
+		candidates[i].Units = load.Units
+		candidates[i].Machines = load.Machines
+	}
+	return candidates, nil
+}
+
+// selectPlacementCandidate selects the candidate that should host a new
+// model according to the given policy, with ties broken randomly.
+// Deprecated controllers, controllers that are currently unavailable and
+// controllers that are at their model limit are skipped. If no candidate
+// is suitable the returned error records why each one was rejected.
+func selectPlacementCandidate(p PlacementPolicy, candidates []PlacementCandidate) (*PlacementCandidate, error) {
+	shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return p.Less(&candidates[i], &candidates[j])
+	})
+
+	var rejected []string
+	for i, c := range candidates {
+		switch {
+		case c.Controller.Deprecated:
+			rejected = append(rejected, fmt.Sprintf("%s: controller deprecated", c.Controller.Name))
+		case c.Controller.UnavailableSince.Valid:
+			rejected = append(rejected, fmt.Sprintf("%s: controller unavailable since %s", c.Controller.Name, c.Controller.UnavailableSince.Time.UTC().Format(time.RFC3339)))
+		case c.Controller.MaxModels > 0 && uint(c.Models) >= c.Controller.MaxModels:
+			rejected = append(rejected, fmt.Sprintf("%s: controller at model limit (%d)", c.Controller.Name, c.Controller.MaxModels))
+		default:
+			return &candidates[i], nil
+		}
+	}
+	return nil, errors.E(errors.CodeNotFound, fmt.Sprintf("no suitable controller available (%s)", strings.Join(rejected, "; ")))
+}
+
+// selectRegionController selects the controller that should host a new
+// model from the given candidates using the current placement policy.
+func (j *JIMM) selectRegionController(ctx context.Context, controllers []dbmodel.CloudRegionControllerPriority) (*dbmodel.CloudRegionControllerPriority, error) {
+	name, p, err := j.placementPolicy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := j.placementCandidates(ctx, name, controllers)
+	if err != nil {
+		return nil, err
+	}
+	c, err := selectPlacementCandidate(p, candidates)
+	if err != nil {
+		return nil, err
+	}
+	return &c.CloudRegionControllerPriority, nil
+}
+
+// A ModelPlacement records where a new model would be placed.
+type ModelPlacement struct {
+	// Policy is the name of the placement policy used.
+	Policy string
+
+	// Controller is the controller that would host the model.
+	Controller dbmodel.Controller
+
+	// CloudRegion is the cloud region the model would be created in.
+	CloudRegion string
+}
+
+// PlaceModel determines which controller a model created with the given
+// arguments would be placed on, without creating the model.
+func (j *JIMM) PlaceModel(ctx context.Context, user *openfga.User, args *ModelCreateArgs) (*ModelPlacement, error) {
+	const op = errors.Op("jimm.PlaceModel")
+
+	owner, err := dbmodel.NewIdentity(args.Owner.Id())
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	// Only JIMM admins are able to place models on behalf of other users.
+	if owner.Name != user.Name && !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	policy, _, err := j.placementPolicy(ctx)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	builder := newModelBuilder(ctx, j)
+	builder = builder.WithOwner(owner)
+	builder = builder.WithName(args.Name)
+	builder = builder.WithCloud(user, args.Cloud)
+	builder = builder.WithCloudRegion(args.CloudRegion)
+	if err := builder.Error(); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return &ModelPlacement{
+		Policy:      policy,
+		Controller:  *builder.controller,
+		CloudRegion: builder.cloudRegion,
+	}, nil
+}
+
+// SetControllerMaxModels sets the maximum number of models that will be
+// placed on the given controller. A limit of 0 removes the limit. Only
+// JIMM administrators may set a controller's model limit.
+func (j *JIMM) SetControllerMaxModels(ctx context.Context, user *openfga.User, controllerName string, maxModels uint) error {
+	const op = errors.Op("jimm.SetControllerMaxModels")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	err := j.Database.Transaction(func(db *db.Database) error {
+		c := dbmodel.Controller{
+			Name: controllerName,
+		}
+		if err := db.GetController(ctx, &c); err != nil {
+			return err
+		}
+		c.MaxModels = maxModels
+		return db.UpdateController(ctx, &c)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
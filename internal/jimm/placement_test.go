@@ -0,0 +1,197 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func candidate(name string, priority uint, models int, units int64) jimm.PlacementCandidate {
+	return jimm.PlacementCandidate{
+		CloudRegionControllerPriority: dbmodel.CloudRegionControllerPriority{
+			Priority:   priority,
+			Controller: dbmodel.Controller{Name: name},
+		},
+		Models:   models,
+		Units:    units,
+		Machines: units,
+	}
+}
+
+func TestSelectPlacementCandidate(t *testing.T) {
+	c := qt.New(t)
+
+	deprecated := candidate("controller-2", 2, 0, 0)
+	deprecated.Controller.Deprecated = true
+	unavailable := candidate("controller-2", 2, 0, 0)
+	unavailable.Controller.UnavailableSince = sql.NullTime{
+		Time:  time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Valid: true,
+	}
+	full := candidate("controller-3", 3, 5, 0)
+	full.Controller.MaxModels = 5
+
+	tests := []struct {
+		about            string
+		policy           string
+		candidates       []jimm.PlacementCandidate
+		expectController string
+		expectError      string
+	}{{
+		about:  "highest priority controller selected",
+		policy: jimm.PlacementPolicyPriority,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 0, 0),
+			candidate("controller-2", 2, 10, 10),
+		},
+		expectController: "controller-2",
+	}, {
+		about:  "least models controller selected",
+		policy: jimm.PlacementPolicyLeastModels,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 1, 10),
+			candidate("controller-2", 2, 10, 0),
+		},
+		expectController: "controller-1",
+	}, {
+		about:  "least units controller selected",
+		policy: jimm.PlacementPolicyLeastUnits,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 1, 10),
+			candidate("controller-2", 2, 10, 0),
+		},
+		expectController: "controller-2",
+	}, {
+		about:  "least machines ties broken by priority",
+		policy: jimm.PlacementPolicyLeastMachines,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 1, 3),
+			candidate("controller-2", 2, 10, 3),
+		},
+		expectController: "controller-2",
+	}, {
+		about:  "deprecated controller skipped",
+		policy: jimm.PlacementPolicyPriority,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 0, 0),
+			deprecated,
+		},
+		expectController: "controller-1",
+	}, {
+		about:  "unavailable controller skipped",
+		policy: jimm.PlacementPolicyPriority,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 0, 0),
+			unavailable,
+		},
+		expectController: "controller-1",
+	}, {
+		about:  "controller at model limit skipped",
+		policy: jimm.PlacementPolicyPriority,
+		candidates: []jimm.PlacementCandidate{
+			candidate("controller-1", 1, 0, 0),
+			full,
+		},
+		expectController: "controller-1",
+	}, {
+		about:  "no suitable controller",
+		policy: jimm.PlacementPolicyPriority,
+		candidates: []jimm.PlacementCandidate{
+			unavailable,
+			full,
+		},
+		expectError: `no suitable controller available \(controller-3: controller at model limit \(5\); controller-2: controller unavailable since 2024-01-02T03:04:05Z\)`,
+	}}
+
+	for _, test := range tests {
+		c.Run(test.about, func(c *qt.C) {
+			p, ok := jimm.LookupPlacementPolicy(test.policy)
+			c.Assert(ok, qt.IsTrue)
+			pc, err := jimm.SelectPlacementCandidate(p, test.candidates)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			c.Check(pc.Controller.Name, qt.Equals, test.expectController)
+		})
+	}
+}
+
+func TestRegisterPlacementPolicy(t *testing.T) {
+	c := qt.New(t)
+
+	jimm.RegisterPlacementPolicy("test-reverse-name", jimm.PlacementPolicyFunc(func(a, b *jimm.PlacementCandidate) bool {
+		return a.Controller.Name > b.Controller.Name
+	}))
+	c.Check(jimm.PlacementPolicies(), qt.Contains, "test-reverse-name")
+
+	p, ok := jimm.LookupPlacementPolicy("test-reverse-name")
+	c.Assert(ok, qt.IsTrue)
+	pc, err := jimm.SelectPlacementCandidate(p, []jimm.PlacementCandidate{
+		candidate("controller-a", 1, 0, 0),
+		candidate("controller-b", 1, 0, 0),
+	})
+	c.Assert(err, qt.IsNil)
+	c.Check(pc.Controller.Name, qt.Equals, "controller-b")
+}
+
+func TestSetPlacementPolicy(t *testing.T) {
+	c := qt.New(t)
+
+	j := &jimm.JIMM{}
+	user := openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil)
+	err := j.SetPlacementPolicy(context.Background(), user, jimm.PlacementPolicyLeastModels)
+	c.Check(err, qt.ErrorMatches, "unauthorized")
+
+	user.JimmAdmin = true
+	err = j.SetPlacementPolicy(context.Background(), user, "no-such-policy")
+	c.Check(err, qt.ErrorMatches, `unknown placement policy "no-such-policy", expected one of .*`)
+	c.Check(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+}
+
+func TestSetPlacementPolicyStoredSeparately(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	j := &jimm.JIMM{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+	}
+	err := j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	policy, err := j.PlacementPolicy(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(policy, qt.Equals, jimm.PlacementPolicyPriority)
+
+	user := openfga.NewUser(&dbmodel.Identity{Name: "alice@canonical.com"}, nil)
+	user.JimmAdmin = true
+	err = j.SetPlacementPolicy(ctx, user, jimm.PlacementPolicyLeastModels)
+	c.Assert(err, qt.IsNil)
+	err = j.SetPlacementPolicy(ctx, user, jimm.PlacementPolicyLeastUnits)
+	c.Assert(err, qt.IsNil)
+
+	policy, err = j.PlacementPolicy(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(policy, qt.Equals, jimm.PlacementPolicyLeastUnits)
+
+	// The placement policy is not reported as controller config.
+	config, err := j.GetControllerConfig(ctx, nil)
+	c.Assert(err, qt.IsNil)
+	c.Check(config.Config, qt.HasLen, 0)
+}
@@ -0,0 +1,149 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/itchyny/gojq"
+	"github.com/jmespath/go-jmespath"
+)
+
+const (
+	// QueryEngineJq runs jq queries against model statuses. This is
+	// the default query engine.
+	QueryEngineJq = "jq"
+
+	// QueryEngineJMESPath runs JMESPath queries against model statuses.
+	QueryEngineJMESPath = "jmespath"
+
+	// QueryEngineFilter runs filter expressions, such as
+	// `application.charm == "postgresql" && unit.status != "active"`,
+	// against the entities in model statuses.
+	QueryEngineFilter = "filter"
+)
+
+// A QueryEngine compiles queries that are run against model statuses.
+type QueryEngine interface {
+	// Compile parses the given query so that it can be run against
+	// any number of model statuses.
+	Compile(query string) (Query, error)
+}
+
+// A Query is a compiled query.
+type Query interface {
+	// Run runs the query against the given model status, which is in
+	// the same form as the output of "juju status --format json". Run
+	// returns every result of the query along with any errors
+	// encountered producing them.
+	Run(ctx context.Context, status map[string]any) ([]any, []error)
+}
+
+// A QueryEngineFunc is a function that implements QueryEngine.
+type QueryEngineFunc func(query string) (Query, error)
+
+// Compile implements QueryEngine.
+func (f QueryEngineFunc) Compile(query string) (Query, error) {
+	return f(query)
+}
+
+var (
+	queryEnginesMu sync.RWMutex
+	queryEngines   = map[string]QueryEngine{
+		QueryEngineJq:       QueryEngineFunc(compileJqQuery),
+		QueryEngineJMESPath: QueryEngineFunc(compileJMESPathQuery),
+		QueryEngineFilter:   QueryEngineFunc(compileFilterQuery),
+	}
+)
+
+// RegisterQueryEngine makes a query engine available under the given
+// name, replacing any engine previously registered with that name.
+func RegisterQueryEngine(name string, e QueryEngine) {
+	queryEnginesMu.Lock()
+	defer queryEnginesMu.Unlock()
+	queryEngines[name] = e
+}
+
+// QueryEngines returns the names of all the registered query engines.
+func QueryEngines() []string {
+	queryEnginesMu.RLock()
+	defer queryEnginesMu.RUnlock()
+	engines := make([]string, 0, len(queryEngines))
+	for name := range queryEngines {
+		engines = append(engines, name)
+	}
+	sort.Strings(engines)
+	return engines
+}
+
+func lookupQueryEngine(name string) (QueryEngine, bool) {
+	queryEnginesMu.RLock()
+	defer queryEnginesMu.RUnlock()
+	e, ok := queryEngines[name]
+	return e, ok
+}
+
+// jqQuery is a Query that runs a jq query.
+type jqQuery struct {
+	query *gojq.Query
+}
+
+func compileJqQuery(query string) (Query, error) {
+	q, err := gojq.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	return jqQuery{query: q}, nil
+}
+
+// Run implements Query.
+func (q jqQuery) Run(ctx context.Context, status map[string]any) ([]any, []error) {
+	var values []any
+	var errs []error
+	queryIter := q.query.RunWithContext(ctx, status)
+	for {
+		v, ok := queryIter.Next()
+		if !ok {
+			break
+		}
+
+		// Jq errors can range from one failure in an iterative query to an entirely broken
+		// query. As such, we simply append all to the errors field and continue to collect
+		// both erreoneous and valid query results.
+		if err, ok := v.(error); ok {
+			errs = append(errs, err)
+			continue
+		}
+
+		values = append(values, v)
+	}
+	return values, errs
+}
+
+// jmespathQuery is a Query that runs a JMESPath expression.
+type jmespathQuery struct {
+	query *jmespath.JMESPath
+}
+
+func compileJMESPathQuery(query string) (Query, error) {
+	q, err := jmespath.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+	return jmespathQuery{query: q}, nil
+}
+
+// Run implements Query. A JMESPath expression produces a single result,
+// a null result is taken to mean that the model does not match.
+func (q jmespathQuery) Run(_ context.Context, status map[string]any) ([]any, []error) {
+	v, err := q.query.Search(status)
+	if err != nil {
+		return nil, []error{err}
+	}
+	if v == nil {
+		return nil, nil
+	}
+	return []any{v}, nil
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+)
+
+const queryEngineTestStatus = `{
+	"model": {
+		"name": "model-1",
+		"type": "iaas",
+		"model-status": {"current": "available"}
+	},
+	"machines": {
+		"0": {
+			"juju-status": {"current": "started"},
+			"containers": {
+				"0/lxd/0": {"juju-status": {"current": "pending"}}
+			}
+		},
+		"1": {"juju-status": {"current": "down"}}
+	},
+	"applications": {
+		"postgresql": {
+			"charm": "postgresql",
+			"charm-rev": 345,
+			"application-status": {"current": "active"},
+			"units": {
+				"postgresql/0": {
+					"machine": "0",
+					"workload-status": {"current": "active"},
+					"subordinates": {
+						"telegraf/0": {"workload-status": {"current": "blocked"}}
+					}
+				},
+				"postgresql/1": {
+					"machine": "1",
+					"workload-status": {"current": "error"}
+				}
+			}
+		},
+		"telegraf": {
+			"charm": "telegraf",
+			"charm-rev": 75,
+			"application-status": {"current": "blocked"}
+		},
+		"wordpress": {
+			"charm": "wordpress",
+			"charm-rev": 12,
+			"application-status": {"current": "active"},
+			"units": {
+				"wordpress/0": {
+					"machine": "0/lxd/0",
+					"workload-status": {"current": "active"}
+				}
+			}
+		}
+	}
+}`
+
+var queryEngineTests = []struct {
+	name          string
+	queryType     string
+	query         string
+	expectResults []any
+	expectErrors  []string
+	expectError   string
+}{{
+	name:          "jq",
+	queryType:     jimm.QueryEngineJq,
+	query:         ".applications | keys[]",
+	expectResults: []any{"postgresql", "telegraf", "wordpress"},
+}, {
+	name:         "jq error",
+	queryType:    jimm.QueryEngineJq,
+	query:        ".model.name | tonumber",
+	expectErrors: []string{`invalid number: "model-1"`},
+}, {
+	name:        "jq parse error",
+	queryType:   jimm.QueryEngineJq,
+	query:       ".[",
+	expectError: `unexpected EOF`,
+}, {
+	name:          "jmespath",
+	queryType:     jimm.QueryEngineJMESPath,
+	query:         "sort(values(applications)[?\"charm-rev\" > `50`].charm)",
+	expectResults: []any{[]any{"postgresql", "telegraf"}},
+}, {
+	name:      "jmespath no match",
+	queryType: jimm.QueryEngineJMESPath,
+	query:     "applications.mysql",
+}, {
+	name:        "jmespath parse error",
+	queryType:   jimm.QueryEngineJMESPath,
+	query:       "applications.[",
+	expectError: `.*`,
+}, {
+	name:      "filter units",
+	queryType: jimm.QueryEngineFilter,
+	query:     `application.charm == "postgresql" && unit.status != "active"`,
+	expectResults: []any{
+		map[string]any{"model": "model-1", "application": "postgresql", "unit": "postgresql/1", "machine": "1"},
+	},
+}, {
+	name:      "filter subordinate units",
+	queryType: jimm.QueryEngineFilter,
+	query:     `unit.status == "blocked"`,
+	expectResults: []any{
+		map[string]any{"model": "model-1", "application": "telegraf", "unit": "telegraf/0", "machine": "0"},
+	},
+}, {
+	name:      "filter applications",
+	queryType: jimm.QueryEngineFilter,
+	query:     `application.charm-rev >= 75 || !(application.status == "active")`,
+	expectResults: []any{
+		map[string]any{"model": "model-1", "application": "postgresql"},
+		map[string]any{"model": "model-1", "application": "telegraf"},
+	},
+}, {
+	name:      "filter machines",
+	queryType: jimm.QueryEngineFilter,
+	query:     `machine.status =~ "^(pending|down)$"`,
+	expectResults: []any{
+		map[string]any{"model": "model-1", "machine": "0/lxd/0"},
+		map[string]any{"model": "model-1", "machine": "1"},
+	},
+}, {
+	name:      "filter applications and machines",
+	queryType: jimm.QueryEngineFilter,
+	query:     `application.name == "wordpress" && machine.juju-status.current == "pending"`,
+	expectResults: []any{
+		map[string]any{"model": "model-1", "application": "wordpress", "unit": "wordpress/0", "machine": "0/lxd/0"},
+	},
+}, {
+	name:      "filter model",
+	queryType: jimm.QueryEngineFilter,
+	query:     `model.type == 'iaas'`,
+	expectResults: []any{
+		map[string]any{"model": "model-1"},
+	},
+}, {
+	name:      "filter no match",
+	queryType: jimm.QueryEngineFilter,
+	query:     `model.status == "suspended"`,
+}, {
+	name:        "filter unknown entity",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `relation.name == "db"`,
+	expectError: `unknown entity "relation" at position 0, expected one of application, machine, model or unit`,
+}, {
+	name:        "filter missing field",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `unit == "db"`,
+	expectError: `invalid field "unit" at position 0`,
+}, {
+	name:        "filter unterminated string",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `unit.name == "db`,
+	expectError: `unterminated string at position 13`,
+}, {
+	name:        "filter unbalanced parentheses",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `(unit.name == "db"`,
+	expectError: `expected "\)" at position 18, found end of query`,
+}, {
+	name:        "filter invalid regular expression",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `unit.name =~ "("`,
+	expectError: `invalid regular expression at position 13: .*`,
+}, {
+	name:        "filter trailing tokens",
+	queryType:   jimm.QueryEngineFilter,
+	query:       `unit.name == "db" "x"`,
+	expectError: `unexpected "x" at position 18`,
+}}
+
+func TestQueryEngines(t *testing.T) {
+	c := qt.New(t)
+
+	var status map[string]any
+	err := json.Unmarshal([]byte(queryEngineTestStatus), &status)
+	c.Assert(err, qt.IsNil)
+
+	for _, name := range []string{jimm.QueryEngineFilter, jimm.QueryEngineJMESPath, jimm.QueryEngineJq} {
+		c.Check(jimm.QueryEngines(), qt.Contains, name)
+	}
+
+	for _, test := range queryEngineTests {
+		c.Run(test.name, func(c *qt.C) {
+			engine, ok := jimm.LookupQueryEngine(test.queryType)
+			c.Assert(ok, qt.IsTrue)
+			q, err := engine.Compile(test.query)
+			if test.expectError != "" {
+				c.Check(err, qt.ErrorMatches, test.expectError)
+				return
+			}
+			c.Assert(err, qt.IsNil)
+			results, errs := q.Run(context.Background(), status)
+			c.Check(results, qt.DeepEquals, test.expectResults)
+			var errStrings []string
+			for _, err := range errs {
+				errStrings = append(errStrings, err.Error())
+			}
+			c.Check(errStrings, qt.DeepEquals, test.expectErrors)
+		})
+	}
+}
+
+func TestRegisterQueryEngine(t *testing.T) {
+	c := qt.New(t)
+
+	errTest := errors.E("test engine")
+	jimm.RegisterQueryEngine("test", jimm.QueryEngineFunc(func(query string) (jimm.Query, error) {
+		return nil, errTest
+	}))
+	c.Check(jimm.QueryEngines(), qt.Contains, "test")
+
+	e, ok := jimm.LookupQueryEngine("test")
+	c.Assert(ok, qt.IsTrue)
+	_, err := e.Compile(".")
+	c.Check(err, qt.Equals, errTest)
+}
@@ -0,0 +1,565 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// Filter queries are boolean expressions evaluated against the entities
+// in a model status, for example:
+//
+//	application.charm == "postgresql" && unit.status != "active"
+//
+// Fields are referenced as <entity>.<field>, where entity is one of
+// model, application, unit or machine and field is a, possibly dotted,
+// path into that entity's "juju status --format json" output. Every
+// entity also has a "name" field and a "status" field, which is its
+// current workload (or juju, for machines) status.
+//
+// The expression is evaluated for every combination of entities it
+// references: once per unit if it references units (or both applications
+// and machines), otherwise once per application, once per machine, or
+// once for the model. Each unit is evaluated with its application and
+// machine. Every time the expression is true the names of the entities
+// it was evaluated with are returned.
+//
+// Supported operators are ==, !=, <, <=, >, >=, =~ (regular expression
+// match), &&, || and !. Operands may be fields, quoted strings, numbers,
+// true, false or null.
+
+const (
+	filterModel       = "model"
+	filterApplication = "application"
+	filterUnit        = "unit"
+	filterMachine     = "machine"
+)
+
+// filterStatusFields holds the location of the "status" field for each
+// entity type.
+var filterStatusFields = map[string][]string{
+	filterModel:       {"model-status", "current"},
+	filterApplication: {"application-status", "current"},
+	filterUnit:        {"workload-status", "current"},
+	filterMachine:     {"juju-status", "current"},
+}
+
+// filterQuery is a Query that evaluates a filter expression.
+type filterQuery struct {
+	expr filterExpr
+	// kinds holds the entity types referenced in the expression.
+	kinds map[string]bool
+}
+
+func compileFilterQuery(query string) (Query, error) {
+	tokens, err := lexFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	p := filterParser{
+		tokens: tokens,
+		kinds:  make(map[string]bool),
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != filterTokenEOF {
+		return nil, errors.E(fmt.Sprintf("unexpected %s at position %d", t, t.pos))
+	}
+	return filterQuery{expr: expr, kinds: p.kinds}, nil
+}
+
+// A filterEntity is an entity that a filter expression is evaluated
+// against.
+type filterEntity struct {
+	name  string
+	value map[string]any
+}
+
+// Run implements Query.
+func (q filterQuery) Run(_ context.Context, status map[string]any) ([]any, []error) {
+	model := mapField(status, filterModel)
+	modelName, _ := model["name"].(string)
+	var results []any
+	visit := func(env map[string]filterEntity) {
+		env[filterModel] = filterEntity{name: modelName, value: model}
+		if !filterTruth(q.expr.eval(env)) {
+			return
+		}
+		result := make(map[string]any, len(env))
+		for kind, e := range env {
+			result[kind] = e.name
+		}
+		results = append(results, result)
+	}
+
+	machines := mapField(status, "machines")
+	applications := mapField(status, "applications")
+	switch {
+	case q.kinds[filterUnit] || q.kinds[filterApplication] && q.kinds[filterMachine]:
+		visitUnit := func(appName, unitName string, unit map[string]any, machineID string) {
+			env := map[string]filterEntity{
+				filterApplication: {name: appName, value: mapField(applications, appName)},
+				filterUnit:        {name: unitName, value: unit},
+			}
+			if machineID != "" {
+				env[filterMachine] = filterEntity{name: machineID, value: lookupMachine(machines, machineID)}
+			}
+			visit(env)
+		}
+		for _, appName := range sortedKeys(applications) {
+			units := mapField(mapField(applications, appName), "units")
+			for _, unitName := range sortedKeys(units) {
+				unit := mapField(units, unitName)
+				machineID, _ := unit["machine"].(string)
+				visitUnit(appName, unitName, unit, machineID)
+
+				subordinates := mapField(unit, "subordinates")
+				for _, subName := range sortedKeys(subordinates) {
+					subApp, _, _ := strings.Cut(subName, "/")
+					visitUnit(subApp, subName, mapField(subordinates, subName), machineID)
+				}
+			}
+		}
+	case q.kinds[filterApplication]:
+		for _, appName := range sortedKeys(applications) {
+			visit(map[string]filterEntity{
+				filterApplication: {name: appName, value: mapField(applications, appName)},
+			})
+		}
+	case q.kinds[filterMachine]:
+		var visitMachines func(map[string]any)
+		visitMachines = func(machines map[string]any) {
+			for _, id := range sortedKeys(machines) {
+				machine := mapField(machines, id)
+				visit(map[string]filterEntity{
+					filterMachine: {name: id, value: machine},
+				})
+				visitMachines(mapField(machine, "containers"))
+			}
+		}
+		visitMachines(machines)
+	default:
+		visit(make(map[string]filterEntity))
+	}
+	return results, nil
+}
+
+// lookupMachine finds the machine, or container, with the given ID in
+// the given machines.
+func lookupMachine(machines map[string]any, id string) map[string]any {
+	parts := strings.Split(id, "/")
+	machine := mapField(machines, parts[0])
+	for i := 2; i < len(parts); i += 2 {
+		machine = mapField(mapField(machine, "containers"), strings.Join(parts[:i+1], "/"))
+	}
+	return machine
+}
+
+func mapField(m map[string]any, key string) map[string]any {
+	v, _ := m[key].(map[string]any)
+	return v
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// A filterExpr is a node in a parsed filter expression.
+type filterExpr interface {
+	eval(env map[string]filterEntity) any
+}
+
+type filterLiteral struct {
+	value any
+}
+
+func (e filterLiteral) eval(map[string]filterEntity) any {
+	return e.value
+}
+
+type filterField struct {
+	kind string
+	path []string
+}
+
+func (e filterField) eval(env map[string]filterEntity) any {
+	entity, ok := env[e.kind]
+	if !ok {
+		return nil
+	}
+	path := e.path
+	if len(path) == 1 {
+		switch path[0] {
+		case "name":
+			return entity.name
+		case "status":
+			path = filterStatusFields[e.kind]
+		}
+	}
+	var v any = entity.value
+	for _, p := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[p]
+	}
+	return v
+}
+
+type filterNot struct {
+	expr filterExpr
+}
+
+func (e filterNot) eval(env map[string]filterEntity) any {
+	return !filterTruth(e.expr.eval(env))
+}
+
+type filterAnd struct {
+	a, b filterExpr
+}
+
+func (e filterAnd) eval(env map[string]filterEntity) any {
+	return filterTruth(e.a.eval(env)) && filterTruth(e.b.eval(env))
+}
+
+type filterOr struct {
+	a, b filterExpr
+}
+
+func (e filterOr) eval(env map[string]filterEntity) any {
+	return filterTruth(e.a.eval(env)) || filterTruth(e.b.eval(env))
+}
+
+type filterCompare struct {
+	op   string
+	a, b filterExpr
+	re   *regexp.Regexp
+}
+
+func (e filterCompare) eval(env map[string]filterEntity) any {
+	a := e.a.eval(env)
+	switch e.op {
+	case "=~":
+		s, ok := a.(string)
+		return ok && e.re.MatchString(s)
+	case "==":
+		return reflect.DeepEqual(a, e.b.eval(env))
+	case "!=":
+		return !reflect.DeepEqual(a, e.b.eval(env))
+	}
+
+	var cmp int
+	switch a := a.(type) {
+	case float64:
+		b, ok := e.b.eval(env).(float64)
+		if !ok {
+			return false
+		}
+		switch {
+		case a < b:
+			cmp = -1
+		case a > b:
+			cmp = 1
+		}
+	case string:
+		b, ok := e.b.eval(env).(string)
+		if !ok {
+			return false
+		}
+		cmp = strings.Compare(a, b)
+	default:
+		return false
+	}
+	switch e.op {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	default:
+		return cmp >= 0
+	}
+}
+
+// filterTruth returns whether the given value is considered true when
+// used as a condition. Null, false, zero and empty values are false.
+func filterTruth(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenField
+	filterTokenString
+	filterTokenNumber
+	filterTokenOp
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	if t.kind == filterTokenEOF {
+		return "end of query"
+	}
+	return strconv.Quote(t.text)
+}
+
+// filterOps holds the operators of the filter language, longest first.
+var filterOps = []string{"==", "!=", "<=", ">=", "=~", "&&", "||", "<", ">", "!", "(", ")"}
+
+// lexFilter splits a filter query into tokens.
+func lexFilter(query string) ([]filterToken, error) {
+	var tokens []filterToken
+	i := 0
+next:
+	for i < len(query) {
+		c := rune(query[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+			continue
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(query) && rune(query[end]) != c {
+				if query[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(query) {
+				return nil, errors.E(fmt.Sprintf("unterminated string at position %d", i))
+			}
+			s := query[i+1 : end]
+			if c == '"' {
+				var err error
+				s, err = strconv.Unquote(query[i : end+1])
+				if err != nil {
+					return nil, errors.E(fmt.Sprintf("invalid string at position %d", i))
+				}
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: s, pos: i})
+			i = end + 1
+			continue
+		case c == '-' || c >= '0' && c <= '9':
+			end := i + 1
+			for end < len(query) && strings.ContainsRune("0123456789.eE+-", rune(query[end])) {
+				end++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenNumber, text: query[i:end], pos: i})
+			i = end
+			continue
+		case c == '_' || unicode.IsLetter(c):
+			end := i + 1
+			for end < len(query) && isFilterFieldChar(rune(query[end])) {
+				end++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenField, text: query[i:end], pos: i})
+			i = end
+			continue
+		}
+		for _, op := range filterOps {
+			if strings.HasPrefix(query[i:], op) {
+				tokens = append(tokens, filterToken{kind: filterTokenOp, text: op, pos: i})
+				i += len(op)
+				continue next
+			}
+		}
+		return nil, errors.E(fmt.Sprintf("unexpected character %q at position %d", c, i))
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(query)}), nil
+}
+
+func isFilterFieldChar(c rune) bool {
+	return c == '_' || c == '-' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c)
+}
+
+// filterParser is a recursive descent parser for filter expressions.
+type filterParser struct {
+	tokens []filterToken
+	// kinds records the entity types referenced in the expression.
+	kinds map[string]bool
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[0]
+}
+
+func (p *filterParser) next() filterToken {
+	t := p.tokens[0]
+	if t.kind != filterTokenEOF {
+		p.tokens = p.tokens[1:]
+	}
+	return t
+}
+
+func (p *filterParser) isOp(op string) bool {
+	t := p.peek()
+	return t.kind == filterTokenOp && t.text == op
+}
+
+func (p *filterParser) parseOr() (filterExpr, error) {
+	a, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("||") {
+		p.next()
+		b, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		a = filterOr{a: a, b: b}
+	}
+	return a, nil
+}
+
+func (p *filterParser) parseAnd() (filterExpr, error) {
+	a, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("&&") {
+		p.next()
+		b, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		a = filterAnd{a: a, b: b}
+	}
+	return a, nil
+}
+
+func (p *filterParser) parseNot() (filterExpr, error) {
+	if p.isOp("!") {
+		p.next()
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return filterNot{expr: e}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterExpr, error) {
+	if p.isOp("(") {
+		p.next()
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			t := p.peek()
+			return nil, errors.E(fmt.Sprintf("expected \")\" at position %d, found %s", t.pos, t))
+		}
+		p.next()
+		return e, nil
+	}
+
+	a, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	t := p.peek()
+	if t.kind != filterTokenOp {
+		return a, nil
+	}
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+		p.next()
+		b, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return filterCompare{op: t.text, a: a, b: b}, nil
+	case "=~":
+		p.next()
+		pt := p.next()
+		if pt.kind != filterTokenString {
+			return nil, errors.E(fmt.Sprintf("expected regular expression string at position %d, found %s", pt.pos, pt))
+		}
+		re, err := regexp.Compile(pt.text)
+		if err != nil {
+			return nil, errors.E(fmt.Sprintf("invalid regular expression at position %d: %s", pt.pos, err))
+		}
+		return filterCompare{op: t.text, a: a, re: re}, nil
+	default:
+		return a, nil
+	}
+}
+
+func (p *filterParser) parseOperand() (filterExpr, error) {
+	t := p.next()
+	switch t.kind {
+	case filterTokenString:
+		return filterLiteral{value: t.text}, nil
+	case filterTokenNumber:
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, errors.E(fmt.Sprintf("invalid number %s at position %d", t, t.pos))
+		}
+		return filterLiteral{value: f}, nil
+	case filterTokenField:
+		switch t.text {
+		case "true":
+			return filterLiteral{value: true}, nil
+		case "false":
+			return filterLiteral{value: false}, nil
+		case "null":
+			return filterLiteral{value: nil}, nil
+		}
+		parts := strings.Split(t.text, ".")
+		switch parts[0] {
+		case filterModel, filterApplication, filterUnit, filterMachine:
+		default:
+			return nil, errors.E(fmt.Sprintf("unknown entity %q at position %d, expected one of application, machine, model or unit", parts[0], t.pos))
+		}
+		if len(parts) < 2 || slices.Contains(parts[1:], "") {
+			return nil, errors.E(fmt.Sprintf("invalid field %s at position %d", t, t.pos))
+		}
+		p.kinds[parts[0]] = true
+		return filterField{kind: parts[0], path: parts[1:]}, nil
+	default:
+		return nil, errors.E(fmt.Sprintf("unexpected %s at position %d", t, t.pos))
+	}
+}
@@ -27,6 +27,9 @@ func (j *JIMM) AddRelation(ctx context.Context, user *openfga.User, tuples []api
 	if err != nil {
 		return errors.E(err)
 	}
+	if err := j.checkTuplesNotExternallyManaged(ctx, parsedTuples); err != nil {
+		return errors.E(op, err)
+	}
 	err = j.OpenFGAClient.AddRelation(ctx, parsedTuples...)
 	if err != nil {
 		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
@@ -45,6 +48,9 @@ func (j *JIMM) RemoveRelation(ctx context.Context, user *openfga.User, tuples []
 	if err != nil {
 		return errors.E(op, err)
 	}
+	if err := j.checkTuplesNotExternallyManaged(ctx, parsedTuples); err != nil {
+		return errors.E(op, err)
+	}
 	err = j.OpenFGAClient.RemoveRelation(ctx, parsedTuples...)
 	if err != nil {
 		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
@@ -0,0 +1,133 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+
+	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/openfga"
+)
+
+// AddRole creates a role within JIMMs DB for reference by OpenFGA.
+func (j *JIMM) AddRole(ctx context.Context, user *openfga.User, name string) (*dbmodel.RoleEntry, error) {
+	const op = errors.Op("jimm.AddRole")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	re, err := j.Database.AddRole(ctx, name)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return re, nil
+}
+
+// CountRoles returns the number of roles that exist.
+func (j *JIMM) CountRoles(ctx context.Context, user *openfga.User) (int, error) {
+	const op = errors.Op("jimm.CountRoles")
+
+	if !user.JimmAdmin {
+		return 0, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	count, err := j.Database.CountRoles(ctx)
+	if err != nil {
+		return 0, errors.E(op, err)
+	}
+	return count, nil
+}
+
+// getRole returns a role based on the provided UUID or name.
+func (j *JIMM) getRole(ctx context.Context, user *openfga.User, role *dbmodel.RoleEntry) (*dbmodel.RoleEntry, error) {
+	const op = errors.Op("jimm.getRole")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+	if err := j.Database.GetRole(ctx, role); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return role, nil
+}
+
+// GetRoleByUUID returns a role based on the provided UUID.
+func (j *JIMM) GetRoleByUUID(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error) {
+	return j.getRole(ctx, user, &dbmodel.RoleEntry{UUID: uuid})
+}
+
+// GetRoleByName returns a role based on the provided name.
+func (j *JIMM) GetRoleByName(ctx context.Context, user *openfga.User, name string) (*dbmodel.RoleEntry, error) {
+	return j.getRole(ctx, user, &dbmodel.RoleEntry{Name: name})
+}
+
+// RenameRole renames a role in JIMM's DB.
+func (j *JIMM) RenameRole(ctx context.Context, user *openfga.User, oldName, newName string) error {
+	const op = errors.Op("jimm.RenameRole")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	role := &dbmodel.RoleEntry{
+		Name: oldName,
+	}
+	err := j.Database.GetRole(ctx, role)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	role.Name = newName
+
+	if err := j.Database.UpdateRole(ctx, role); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveRole removes a role from JIMM's DB, along with all of its
+// assignments and the entitlements it grants.
+func (j *JIMM) RemoveRole(ctx context.Context, user *openfga.User, name string) error {
+	const op = errors.Op("jimm.RemoveRole")
+
+	if !user.JimmAdmin {
+		return errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	role := &dbmodel.RoleEntry{
+		Name: name,
+	}
+	err := j.Database.GetRole(ctx, role)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	err = j.OpenFGAClient.RemoveRole(ctx, role.ResourceTag())
+	if err != nil {
+		return errors.E(op, err)
+	}
+
+	if err := j.Database.RemoveRole(ctx, role); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListRoles returns a list of roles known to JIMM.
+func (j *JIMM) ListRoles(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.RoleEntry, error) {
+	const op = errors.Op("jimm.ListRoles")
+
+	if !user.JimmAdmin {
+		return nil, errors.E(op, errors.CodeUnauthorized, "unauthorized")
+	}
+
+	var roles []dbmodel.RoleEntry
+	err := j.Database.ForEachRole(ctx, filter.Limit(), filter.Offset(), func(re *dbmodel.RoleEntry) error {
+		roles = append(roles, *re)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	return roles, nil
+}
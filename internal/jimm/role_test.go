@@ -0,0 +1,146 @@
+// Copyright 2024 Canonical.
+
+package jimm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/canonical/ofga"
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+)
+
+func newRoleTestJIMM(c *qt.C) (*jimm.JIMM, *openfga.OFGAClient) {
+	ofgaClient, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+
+	now := time.Now().UTC().Round(time.Millisecond)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: ofgaClient,
+	}
+
+	err = j.Database.Migrate(context.Background(), false)
+	c.Assert(err, qt.IsNil)
+	return j, ofgaClient
+}
+
+func TestRoleLifecycle(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	j, ofgaClient := newRoleTestJIMM(c)
+
+	user, _, _, _, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, ofgaClient)
+
+	_, err := j.AddRole(ctx, u, "db-operators")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeUnauthorized)
+
+	u.JimmAdmin = true
+	role, err := j.AddRole(ctx, u, "db-operators")
+	c.Assert(err, qt.IsNil)
+	c.Assert(role.UUID, qt.Not(qt.Equals), "")
+
+	_, err = j.AddRole(ctx, u, "db-operators")
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeAlreadyExists)
+
+	count, err := j.CountRoles(ctx, u)
+	c.Assert(err, qt.IsNil)
+	c.Assert(count, qt.Equals, 1)
+
+	gotRole, err := j.GetRoleByUUID(ctx, u, role.UUID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotRole, qt.DeepEquals, role)
+
+	err = j.RenameRole(ctx, u, "db-operators", "database-operators")
+	c.Assert(err, qt.IsNil)
+
+	gotRole, err = j.GetRoleByName(ctx, u, "database-operators")
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotRole.UUID, qt.Equals, role.UUID)
+
+	_, err = j.AddRole(ctx, u, "auditors")
+	c.Assert(err, qt.IsNil)
+
+	roles, err := j.ListRoles(ctx, u, pagination.NewOffsetFilter(10, 0))
+	c.Assert(err, qt.IsNil)
+	c.Assert(roles, qt.HasLen, 2)
+	c.Assert(roles[0].Name, qt.Equals, "auditors")
+	c.Assert(roles[1].Name, qt.Equals, "database-operators")
+}
+
+func TestRemoveRoleRemovesTuples(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+	j, ofgaClient := newRoleTestJIMM(c)
+
+	user, group, controller, model, _, _, _ := createTestControllerEnvironment(ctx, c, j.Database)
+	u := openfga.NewUser(&user, ofgaClient)
+	u.JimmAdmin = true
+
+	role, err := j.AddRole(ctx, u, "db-operators")
+	c.Assert(err, qt.IsNil)
+
+	tuples := []openfga.Tuple{
+		// This tuple should remain as it has no relation to the role
+		{
+			Object:   ofganames.ConvertTag(user.ResourceTag()),
+			Relation: ofganames.MemberRelation,
+			Target:   ofganames.ConvertTag(group.ResourceTag()),
+		},
+		// Below tuples should all be removed as they relate to the role
+		{
+			Object:   ofganames.ConvertTag(user.ResourceTag()),
+			Relation: ofganames.AssigneeRelation,
+			Target:   ofganames.ConvertTag(role.ResourceTag()),
+		},
+		{
+			Object:   ofganames.ConvertTagWithRelation(group.ResourceTag(), ofganames.MemberRelation),
+			Relation: ofganames.AssigneeRelation,
+			Target:   ofganames.ConvertTag(role.ResourceTag()),
+		},
+		{
+			Object:   ofganames.ConvertTagWithRelation(role.ResourceTag(), ofganames.AssigneeRelation),
+			Relation: ofganames.AdministratorRelation,
+			Target:   ofganames.ConvertTag(controller.ResourceTag()),
+		},
+		{
+			Object:   ofganames.ConvertTagWithRelation(role.ResourceTag(), ofganames.AssigneeRelation),
+			Relation: ofganames.WriterRelation,
+			Target:   ofganames.ConvertTag(model.ResourceTag()),
+		},
+	}
+	err = ofgaClient.AddRelation(ctx, tuples...)
+	c.Assert(err, qt.IsNil)
+
+	allowed, err := ofgaClient.CheckRelation(ctx, openfga.Tuple{
+		Object:   ofganames.ConvertTag(user.ResourceTag()),
+		Relation: ofganames.WriterRelation,
+		Target:   ofganames.ConvertTag(model.ResourceTag()),
+	}, false)
+	c.Assert(err, qt.IsNil)
+	c.Assert(allowed, qt.IsTrue)
+
+	err = j.RemoveRole(ctx, u, role.Name)
+	c.Assert(err, qt.IsNil)
+
+	err = j.RemoveRole(ctx, u, role.Name)
+	c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeNotFound)
+
+	remainingTuples, _, err := ofgaClient.ReadRelatedObjects(ctx, ofga.Tuple{}, 0, "")
+	c.Assert(err, qt.IsNil)
+	c.Assert(remainingTuples, qt.HasLen, 1)
+}
@@ -5,12 +5,15 @@ package jimm
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
 	"github.com/juju/zaputil/zapctx"
 	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
@@ -127,3 +130,148 @@ func (j *JIMM) GrantServiceAccountAccess(ctx context.Context, u *openfga.User, s
 	}
 	return nil
 }
+
+// ListServiceAccounts returns the client IDs of all the service accounts
+// the given user administers, either directly or through group membership.
+func (j *JIMM) ListServiceAccounts(ctx context.Context, u *openfga.User) ([]string, error) {
+	const op = errors.Op("jimm.ListServiceAccounts")
+
+	svcAccTags, err := j.OpenFGAClient.ListObjects(ctx, ofganames.ConvertTag(u.ResourceTag()), ofganames.AdministratorRelation, openfga.ServiceAccountType, nil)
+	if err != nil {
+		return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	clientIDs := make([]string, 0, len(svcAccTags))
+	for _, tag := range svcAccTags {
+		clientIDs = append(clientIDs, tag.ID)
+	}
+	sort.Strings(clientIDs)
+	return clientIDs, nil
+}
+
+// ListServiceAccountAdministrators returns the users and groups that are
+// directly related to the service account as administrators. The entities
+// are returned as JAAS tag strings, i.e. user-alice@canonical.com or
+// group-admins#member. The user must be an administrator of the service
+// account.
+func (j *JIMM) ListServiceAccountAdministrators(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) ([]string, error) {
+	const op = errors.Op("jimm.ListServiceAccountAdministrators")
+
+	if err := j.checkServiceAccountAdmin(ctx, u, svcAccTag); err != nil {
+		return nil, errors.E(op, err)
+	}
+
+	key := openfga.Tuple{
+		Relation: ofganames.AdministratorRelation,
+		Target:   ofganames.ConvertTag(svcAccTag),
+	}
+	var administrators []string
+	var token string
+	for {
+		tuples, ct, err := j.OpenFGAClient.ReadRelatedObjects(ctx, key, 50, token)
+		if err != nil {
+			return nil, errors.E(op, errors.CodeOpenFGARequestFailed, err)
+		}
+		for _, tuple := range tuples {
+			administrator, err := j.ToJAASTag(ctx, tuple.Object, true)
+			if err != nil {
+				zapctx.Error(ctx, "failed to resolve service account administrator", zap.String("object", tuple.Object.String()), zap.Error(err))
+				administrator, _ = j.ToJAASTag(ctx, tuple.Object, false)
+			}
+			administrators = append(administrators, administrator)
+		}
+		if ct == "" || ct == token {
+			break
+		}
+		token = ct
+	}
+	sort.Strings(administrators)
+	return administrators, nil
+}
+
+// RemoveServiceAccount removes the service account identity along with
+// its cloud credentials and all of its OpenFGA relations. A service
+// account that still owns models cannot be removed, the models must be
+// destroyed first. The user must be an administrator of the service
+// account.
+func (j *JIMM) RemoveServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+	const op = errors.Op("jimm.RemoveServiceAccount")
+
+	if err := j.checkServiceAccountAdmin(ctx, u, svcAccTag); err != nil {
+		return errors.E(op, err)
+	}
+
+	identity, err := dbmodel.NewIdentity(svcAccTag.Id())
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+	if err := j.Database.FetchIdentity(ctx, identity); err != nil {
+		return errors.E(op, err)
+	}
+
+	count, err := j.Database.CountModelsByOwner(ctx, identity)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if count > 0 {
+		return errors.E(op, errors.CodeBadRequest, fmt.Sprintf("service account still owns %d model(s)", count))
+	}
+
+	var creds []dbmodel.CloudCredential
+	err = j.Database.ForEachCloudCredential(ctx, identity.Name, "", func(cred *dbmodel.CloudCredential) error {
+		creds = append(creds, *cred)
+		return nil
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	for _, cred := range creds {
+		if err := j.RevokeCloudCredential(ctx, identity, cred.ResourceTag(), false); err != nil {
+			return errors.E(op, err)
+		}
+		if cred.AttributesInVault && j.CredentialStore != nil {
+			if err := j.CredentialStore.Put(ctx, cred.ResourceTag(), nil); err != nil {
+				zapctx.Error(ctx, "failed to remove credential attributes", zap.String("credential", cred.ResourceTag().String()), zap.Error(err))
+				return errors.E(op, err)
+			}
+		}
+	}
+
+	err = j.Database.Transaction(func(tx *db.Database) error {
+		identity.Disabled = true
+		if err := tx.UpdateIdentity(ctx, identity); err != nil {
+			return err
+		}
+		return tx.RemoveIdentity(ctx, identity)
+	})
+	if err != nil {
+		return errors.E(op, err)
+	}
+	j.sessions.closeAll(identity.Name)
+
+	if err := j.OpenFGAClient.RemoveUser(ctx, identity.ResourceTag()); err != nil {
+		zapctx.Error(ctx, "failed to remove service account relations", zap.String("client-id", svcAccTag.Id()), zap.Error(err))
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	if err := j.OpenFGAClient.RemoveServiceAccount(ctx, svcAccTag); err != nil {
+		zapctx.Error(ctx, "failed to remove service account administrators", zap.String("client-id", svcAccTag.Id()), zap.Error(err))
+		return errors.E(op, errors.CodeOpenFGARequestFailed, err)
+	}
+	return nil
+}
+
+// checkServiceAccountAdmin returns an error with code CodeUnauthorized if
+// the user is neither a JIMM administrator nor an administrator of the
+// service account.
+func (j *JIMM) checkServiceAccountAdmin(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+	if u.JimmAdmin {
+		return nil
+	}
+	ok, err := u.IsServiceAccountAdmin(ctx, svcAccTag)
+	if err != nil {
+		return errors.E(err)
+	}
+	if !ok {
+		return errors.E(errors.CodeUnauthorized, "unauthorized")
+	}
+	return nil
+}
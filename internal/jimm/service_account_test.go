@@ -277,3 +277,141 @@ func TestGrantServiceAccountAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestListServiceAccountsAndAdministrators(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		OpenFGAClient: client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	aliceUser := openfga.NewUser(alice, client)
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	bobUser := openfga.NewUser(bob, client)
+
+	clientID1 := "39caae91-b914-41ae-83f8-c7b86ca5ad5a@serviceaccount"
+	clientID2 := "fca1f605-736e-4d1f-bcd2-aecc726923be@serviceaccount"
+	err = j.AddServiceAccount(ctx, aliceUser, clientID1)
+	c.Assert(err, qt.IsNil)
+	err = j.AddServiceAccount(ctx, aliceUser, clientID2)
+	c.Assert(err, qt.IsNil)
+
+	clientIDs, err := j.ListServiceAccounts(ctx, aliceUser)
+	c.Assert(err, qt.IsNil)
+	c.Assert(clientIDs, qt.DeepEquals, []string{clientID1, clientID2})
+
+	clientIDs, err = j.ListServiceAccounts(ctx, bobUser)
+	c.Assert(err, qt.IsNil)
+	c.Assert(clientIDs, qt.HasLen, 0)
+
+	_, err = j.ListServiceAccountAdministrators(ctx, bobUser, jimmnames.NewServiceAccountTag(clientID1))
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	err = j.GrantServiceAccountAccess(ctx, aliceUser, jimmnames.NewServiceAccountTag(clientID1), []string{"user-bob@canonical.com"})
+	c.Assert(err, qt.IsNil)
+
+	administrators, err := j.ListServiceAccountAdministrators(ctx, bobUser, jimmnames.NewServiceAccountTag(clientID1))
+	c.Assert(err, qt.IsNil)
+	c.Assert(administrators, qt.DeepEquals, []string{"user-alice@canonical.com", "user-bob@canonical.com"})
+}
+
+func TestRemoveServiceAccount(t *testing.T) {
+	c := qt.New(t)
+
+	ctx := context.Background()
+	client, _, _, err := jimmtest.SetupTestOFGAClient(c.Name())
+	c.Assert(err, qt.IsNil)
+	api := &jimmtest.API{
+		RevokeCredential_: func(context.Context, names.CloudCredentialTag) error {
+			return nil
+		},
+	}
+	store := &jimmtest.InMemoryCredentialStore{}
+	j := &jimm.JIMM{
+		UUID: uuid.NewString(),
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, func() time.Time { return now }),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: api,
+		},
+		CredentialStore: store,
+		OpenFGAClient:   client,
+	}
+	err = j.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+
+	clientID := "39caae91-b914-41ae-83f8-c7b86ca5ad5a@serviceaccount"
+	svcAccTag := jimmnames.NewServiceAccountTag(clientID)
+	svcAccId, err := dbmodel.NewIdentity(clientID)
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.DB.Create(svcAccId).Error, qt.IsNil)
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	c.Assert(j.Database.DB.Create(alice).Error, qt.IsNil)
+	aliceUser := openfga.NewUser(alice, client)
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+	bobUser := openfga.NewUser(bob, client)
+
+	err = j.AddServiceAccount(ctx, aliceUser, clientID)
+	c.Assert(err, qt.IsNil)
+
+	cloud := dbmodel.Cloud{
+		Name: "test-cloud",
+		Type: "test-provider",
+		Regions: []dbmodel.CloudRegion{{
+			Name: "test-region-1",
+		}},
+	}
+	c.Assert(j.Database.DB.Create(&cloud).Error, qt.IsNil)
+
+	cred := dbmodel.CloudCredential{
+		Name:              "test-credential-1",
+		CloudName:         cloud.Name,
+		OwnerIdentityName: svcAccId.Name,
+		AuthType:          "empty",
+		AttributesInVault: true,
+	}
+	err = j.Database.SetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.IsNil)
+	err = store.Put(ctx, cred.ResourceTag(), map[string]string{"key": "value"})
+	c.Assert(err, qt.IsNil)
+
+	svcAccUser := openfga.NewUser(svcAccId, client)
+	err = svcAccUser.SetCloudAccess(ctx, cloud.ResourceTag(), ofganames.CanAddModelRelation)
+	c.Assert(err, qt.IsNil)
+
+	err = j.RemoveServiceAccount(ctx, bobUser, svcAccTag)
+	c.Assert(err, qt.ErrorMatches, "unauthorized")
+
+	err = j.RemoveServiceAccount(ctx, aliceUser, svcAccTag)
+	c.Assert(err, qt.IsNil)
+
+	err = j.Database.GetCloudCredential(ctx, &cred)
+	c.Assert(err, qt.ErrorMatches, "cloudcredential .* not found")
+	attrs, err := store.Get(ctx, cred.ResourceTag())
+	c.Assert(err, qt.IsNil)
+	c.Assert(attrs, qt.HasLen, 0)
+
+	err = j.Database.FetchIdentity(ctx, &dbmodel.Identity{Name: clientID})
+	c.Assert(err, qt.ErrorMatches, "record not found")
+
+	ok, err := aliceUser.IsServiceAccountAdmin(ctx, svcAccTag)
+	c.Assert(err, qt.IsNil)
+	c.Assert(ok, qt.IsFalse)
+	c.Assert(svcAccUser.GetCloudAccess(ctx, cloud.ResourceTag()), qt.Equals, ofganames.NoRelation)
+}
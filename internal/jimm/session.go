@@ -0,0 +1,138 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// sessionRegistry records the connections that are currently authenticated
+// as each identity, so that they can be closed when an identity is
+// disabled. The zero value is ready to use.
+type sessionRegistry struct {
+	mu       sync.Mutex
+	nextID   uint64
+	sessions map[string]map[uint64]func()
+}
+
+// add registers the close function of a connection authenticated as the
+// given identity. The returned function removes the registration and must
+// be called when the connection ends.
+func (r *sessionRegistry) add(identityName string, closeF func()) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.sessions == nil {
+		r.sessions = make(map[string]map[uint64]func())
+	}
+	if r.sessions[identityName] == nil {
+		r.sessions[identityName] = make(map[uint64]func())
+	}
+	r.nextID++
+	id := r.nextID
+	r.sessions[identityName][id] = closeF
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			delete(r.sessions[identityName], id)
+			if len(r.sessions[identityName]) == 0 {
+				delete(r.sessions, identityName)
+			}
+		})
+	}
+}
+
+// closeAll closes every connection registered for the given identity and
+// returns the number of connections closed.
+func (r *sessionRegistry) closeAll(identityName string) int {
+	r.mu.Lock()
+	sessions := r.sessions[identityName]
+	delete(r.sessions, identityName)
+	r.mu.Unlock()
+
+	for _, closeF := range sessions {
+		closeF()
+	}
+	return len(sessions)
+}
+
+// identities returns the names of the identities with registered
+// connections.
+func (r *sessionRegistry) identities() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.sessions))
+	for name := range r.sessions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterSession records that a connection to this JIMM instance has been
+// authenticated as the identity with the given name. If the identity is
+// disabled while the connection is open closeF will be called to tear the
+// connection down. The returned function must be called once the
+// connection has been closed.
+func (j *JIMM) RegisterSession(identityName string, closeF func()) (unregister func()) {
+	return j.sessions.add(identityName, closeF)
+}
+
+// CloseDisabledSessions closes the connections to this JIMM instance of
+// every identity that has been disabled or removed, possibly by another
+// JIMM instance, since the connection was authenticated.
+func (j *JIMM) CloseDisabledSessions(ctx context.Context) error {
+	const op = errors.Op("jimm.CloseDisabledSessions")
+
+	names := j.sessions.identities()
+	if len(names) == 0 {
+		return nil
+	}
+	enabled, err := j.Database.EnabledIdentityNames(ctx, names)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	isEnabled := make(map[string]bool, len(enabled))
+	for _, name := range enabled {
+		isEnabled[name] = true
+	}
+	for _, name := range names {
+		if isEnabled[name] {
+			continue
+		}
+		n := j.sessions.closeAll(name)
+		zapctx.Info(ctx, "closed sessions of disabled identity", zap.String("identity", name), zap.Int("closed-sessions", n))
+	}
+	return nil
+}
+
+// WatchDisabledIdentities calls CloseDisabledSessions every interval so
+// that identities disabled through any JIMM instance lose their
+// connections to this one. WatchDisabledIdentities finishes when the given
+// context is canceled.
+func (j *JIMM) WatchDisabledIdentities(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := j.CloseDisabledSessions(ctx); err != nil {
+				zapctx.Error(ctx, "failed to close sessions of disabled identities", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
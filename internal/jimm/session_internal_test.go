@@ -0,0 +1,33 @@
+// Copyright 2024 Canonical.
+
+package jimm
+
+import (
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+)
+
+func TestSessionRegistry(t *testing.T) {
+	c := qt.New(t)
+
+	var r sessionRegistry
+	var aliceClosed, bobClosed int
+	unregisterAlice1 := r.add("alice@canonical.com", func() { aliceClosed++ })
+	r.add("alice@canonical.com", func() { aliceClosed++ })
+	r.add("bob@canonical.com", func() { bobClosed++ })
+
+	unregisterAlice1()
+	// Unregistering twice is a no-op.
+	unregisterAlice1()
+	c.Check(r.identities(), qt.DeepEquals, []string{"alice@canonical.com", "bob@canonical.com"})
+
+	c.Check(r.closeAll("alice@canonical.com"), qt.Equals, 1)
+	c.Check(aliceClosed, qt.Equals, 1)
+	c.Check(bobClosed, qt.Equals, 0)
+
+	c.Check(r.closeAll("alice@canonical.com"), qt.Equals, 0)
+	c.Check(r.closeAll("bob@canonical.com"), qt.Equals, 1)
+	c.Check(bobClosed, qt.Equals, 1)
+	c.Check(r.identities(), qt.HasLen, 0)
+}
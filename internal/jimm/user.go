@@ -19,6 +19,9 @@ func (j *JIMM) UserLogin(ctx context.Context, identityName string) (*openfga.Use
 	if err != nil {
 		return nil, errors.E(op, err, errors.CodeUnauthorized)
 	}
+	if user.Disabled {
+		return nil, errors.E(op, errors.CodeUnauthorized, "identity disabled")
+	}
 	err = j.updateUserLastLogin(ctx, identityName)
 	if err != nil {
 		return nil, errors.E(op, err, errors.CodeUnauthorized)
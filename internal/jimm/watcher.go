@@ -58,10 +58,16 @@ func (w *Watcher) Watch(ctx context.Context, interval time.Duration) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// watched holds the names of every controller that has been watched,
+	// so that the metrics of any that are removed can be deleted.
+	watched := make(map[string]bool)
+
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
+		current := make(map[string]bool)
 		err := w.Database.ForEachController(ctx, func(ctl *dbmodel.Controller) error {
+			current[ctl.Name] = true
 			ctx := zapctx.WithFields(ctx, zap.String("controller", ctl.Name))
 			r.run(ctl.Name, func() {
 				zapctx.Info(ctx, "starting controller watcher")
@@ -76,6 +82,16 @@ func (w *Watcher) Watch(ctx context.Context, interval time.Duration) error {
 				return errors.E(op, err)
 			}
 			zapctx.Warn(ctx, "temporary error polling for controllers", zap.Error(err))
+		} else {
+			for name := range watched {
+				if !current[name] {
+					deleteControllerMetrics(name)
+					delete(watched, name)
+				}
+			}
+			for name := range current {
+				watched[name] = true
+			}
 		}
 		select {
 		case <-ctx.Done():
@@ -225,15 +241,22 @@ func (w *Watcher) deltaProcessedNotification() {
 // changes on the controller.
 //
 // nolint:gocognit // We ignore watch as watchers are removed in Juju 4.0.
-func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller) error {
+func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller) (err error) {
 	const op = errors.Op("jimm.watchController")
 
+	health := newControllerHealthRecorder(ctx, w.Database, ctl)
+
 	// connect to the controller
+	start := time.Now()
 	api, err := w.dialController(ctx, ctl)
+	health.dialed(ctx, time.Since(start), ctl.AgentVersion, err)
 	if err != nil {
 		return errors.E(op, err)
 	}
 	defer api.Close()
+	defer func() {
+		health.stopped(ctx, err)
+	}()
 	// start the all watcher
 	id, err := api.WatchAllModels(ctx)
 	if err != nil {
@@ -244,6 +267,11 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 			zapctx.Error(ctx, "failed to stop all model watcher", zap.Error(err))
 		}
 	}()
+	health.watching(ctx)
+
+	pingCtx, cancelPing := context.WithCancel(ctx)
+	defer cancelPing()
+	go pingController(pingCtx, api, health)
 
 	checkDyingModel := func(m *dbmodel.Model) error {
 		if m.Life == state.Dying.String() || m.Life == state.Dead.String() {
@@ -306,6 +334,9 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 		return modelStates[uuid]
 	}
 
+	// The first set of deltas from the all watcher holds the complete
+	// state of every model, so is used to remove any stale inventory.
+	first := true
 	for {
 		// wait for updates from the all watcher.
 		deltas, err := api.AllModelWatcherNext(ctx, id)
@@ -313,11 +344,21 @@ func (w *Watcher) watchController(ctx context.Context, ctl *dbmodel.Controller)
 			return errors.E(op, err)
 		}
 		servermon.MonitorDeltasReceivedCount.WithLabelValues(ctl.UUID).Add(float64(len(deltas)))
+		inventory := make(inventoryBatch)
 		for _, d := range deltas {
 			eid := d.Entity.EntityId()
 			ctx := zapctx.WithFields(ctx, zap.String("model-uuid", eid.ModelUUID), zap.String("kind", eid.Kind), zap.String("id", eid.Id))
 			zapctx.Debug(ctx, "processing delta")
-			if err := w.handleDelta(ctx, modelStatef, d); err != nil {
+			if err := w.handleDelta(ctx, modelStatef, inventory, d); err != nil {
+				return errors.E(op, err)
+			}
+		}
+		if err := w.updateInventory(ctx, inventory); err != nil {
+			return errors.E(op, err)
+		}
+		if first {
+			first = false
+			if err := w.pruneInventory(ctx, modelStates, deltas); err != nil {
 				return errors.E(op, err)
 			}
 		}
@@ -450,13 +491,14 @@ func (w *Watcher) watchAllModelSummaries(ctx context.Context, ctl *dbmodel.Contr
 	}
 }
 
-func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelState, d jujuparams.Delta) error {
+func (w *Watcher) handleDelta(ctx context.Context, modelIDf func(string) *modelState, inventory inventoryBatch, d jujuparams.Delta) error {
 	defer w.deltaProcessedNotification()
 	eid := d.Entity.EntityId()
 	state := modelIDf(eid.ModelUUID)
 	if state == nil {
 		return nil
 	}
+	inventory.add(state.id, d)
 	switch eid.Kind {
 	case "application":
 		if d.Removed {
@@ -323,6 +323,115 @@ var watcherTests = []struct {
 
 		c.Check(model.Units, qt.Equals, int64(0))
 	},
+}, {
+	name: "UpdateInventory",
+	deltas: [][]jujuparams.Delta{
+		{{
+			Entity: &jujuparams.ApplicationInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Name:      "app-1",
+				CharmURL:  "ch:amd64/jammy/app-12",
+				Life:      life.Value(state.Alive.String()),
+				Status: jujuparams.StatusInfo{
+					Current: "active",
+				},
+			},
+		}, {
+			Entity: &jujuparams.UnitInfo{
+				ModelUUID:   "00000002-0000-0000-0000-000000000001",
+				Name:        "app-1/0",
+				Application: "app-1",
+				MachineId:   "0",
+				WorkloadStatus: jujuparams.StatusInfo{
+					Current: "blocked",
+					Message: "waiting for database",
+				},
+			},
+		}, {
+			Entity: &jujuparams.MachineInfo{
+				ModelUUID:  "00000002-0000-0000-0000-000000000001",
+				Id:         "0",
+				InstanceId: "machine-0",
+				Base:       "ubuntu@22.04",
+			},
+		}},
+		{{
+			Removed: true,
+			Entity: &jujuparams.MachineInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Id:        "0",
+			},
+		}},
+		nil,
+	},
+	checkDB: func(c *qt.C, d db.Database) {
+		ctx := context.Background()
+
+		apps, err := d.FindModelApplications(ctx, db.ModelApplicationFilter{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(apps, qt.HasLen, 1)
+		c.Check(apps[0].Name, qt.Equals, "app-1")
+		c.Check(apps[0].CharmName, qt.Equals, "app")
+		c.Check(apps[0].CharmRevision, qt.Equals, 12)
+		c.Check(apps[0].Status, qt.Equals, "active")
+
+		units, err := d.FindModelUnits(ctx, db.ModelUnitFilter{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(units, qt.HasLen, 1)
+		c.Check(units[0].Name, qt.Equals, "app-1/0")
+		c.Check(units[0].MachineID, qt.Equals, "0")
+		c.Check(units[0].WorkloadStatus, qt.Equals, "blocked")
+		c.Check(units[0].WorkloadMessage, qt.Equals, "waiting for database")
+
+		machines, err := d.FindModelMachines(ctx, db.ModelMachineFilter{})
+		c.Assert(err, qt.IsNil)
+		c.Check(machines, qt.HasLen, 0)
+	},
+}, {
+	name: "PruneInventory",
+	initDB: func(c *qt.C, d db.Database) {
+		ctx := context.Background()
+
+		var m dbmodel.Model
+		m.SetTag(names.NewModelTag("00000002-0000-0000-0000-000000000001"))
+		err := d.GetModel(ctx, &m)
+		c.Assert(err, qt.IsNil)
+
+		err = d.UpsertModelApplication(ctx, &dbmodel.ModelApplication{
+			ModelID:       m.ID,
+			Name:          "stale",
+			CharmRevision: -1,
+		})
+		c.Assert(err, qt.IsNil)
+		err = d.UpsertModelUnit(ctx, &dbmodel.ModelUnit{
+			ModelID:     m.ID,
+			Name:        "stale/0",
+			Application: "stale",
+		})
+		c.Assert(err, qt.IsNil)
+	},
+	deltas: [][]jujuparams.Delta{
+		{{
+			Entity: &jujuparams.ApplicationInfo{
+				ModelUUID: "00000002-0000-0000-0000-000000000001",
+				Name:      "app-1",
+			},
+		}},
+		nil,
+	},
+	checkDB: func(c *qt.C, d db.Database) {
+		ctx := context.Background()
+
+		apps, err := d.FindModelApplications(ctx, db.ModelApplicationFilter{})
+		c.Assert(err, qt.IsNil)
+		c.Assert(apps, qt.HasLen, 1)
+		c.Check(apps[0].Name, qt.Equals, "app-1")
+		c.Check(apps[0].CharmRevision, qt.Equals, -1)
+
+		units, err := d.FindModelUnits(ctx, db.ModelUnitFilter{})
+		c.Assert(err, qt.IsNil)
+		c.Check(units, qt.HasLen, 0)
+	},
 }, {
 	name: "UnknownModelsIgnored",
 	deltas: [][]jujuparams.Delta{
@@ -882,6 +991,81 @@ func TestWatcherClearsControllerUnavailable(t *testing.T) {
 	c.Assert(ctl.UnavailableSince.Valid, qt.IsFalse)
 }
 
+func TestWatcherRecordsControllerHealth(t *testing.T) {
+	c := qt.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c.Patch(jimm.ControllerPingInterval, time.Millisecond)
+
+	var pings int32
+	pinged := make(chan struct{})
+	w := &jimm.Watcher{
+		Database: db.Database{
+			DB: jimmtest.PostgresDB(c, nil),
+		},
+		Dialer: &jimmtest.Dialer{
+			API: &jimmtest.API{
+				AllModelWatcherNext_: func(_ context.Context, _ string) ([]jujuparams.Delta, error) {
+					<-pinged
+					return nil, errors.E("connection lost")
+				},
+				ModelInfo_: func(_ context.Context, info *jujuparams.ModelInfo) error {
+					return errors.E(errors.CodeNotFound)
+				},
+				Ping_: func(ctx context.Context) error {
+					if atomic.AddInt32(&pings, 1) == 1 {
+						return nil
+					}
+					// The first ping has been recorded, wait for the
+					// watcher to stop.
+					close(pinged)
+					<-ctx.Done()
+					return ctx.Err()
+				},
+				WatchAllModels_: func(ctx context.Context) (string, error) {
+					return "1234", nil
+				},
+			},
+		},
+		Pubsub: &testPublisher{},
+	}
+
+	env := jimmtest.ParseEnvironment(c, testWatcherEnv)
+	err := w.Database.Migrate(ctx, false)
+	c.Assert(err, qt.IsNil)
+	env.PopulateDB(c, w.Database)
+
+	ctl := dbmodel.Controller{
+		Name: "controller-1",
+	}
+	err = w.Database.GetController(ctx, &ctl)
+	c.Assert(err, qt.IsNil)
+
+	err = jimm.WatchController(w, ctx, &ctl)
+	c.Check(err, qt.ErrorMatches, `connection lost`)
+
+	health := dbmodel.ControllerHealth{ControllerID: ctl.ID}
+	err = w.Database.GetControllerHealth(ctx, &health)
+	c.Assert(err, qt.IsNil)
+	c.Check(health.WatcherState, qt.Equals, dbmodel.WatcherStateStopped)
+	c.Check(health.LastDial.Valid, qt.IsTrue)
+	c.Check(health.LastPing.Valid, qt.IsTrue)
+	c.Check(health.ErrorStreak, qt.Equals, 1)
+	c.Check(health.DegradedSince.Valid, qt.IsTrue)
+	c.Check(health.LastError, qt.Equals, "connection lost")
+
+	events, err := w.Database.FindControllerHealthEvents(ctx, ctl.ID, 0)
+	c.Assert(err, qt.IsNil)
+	c.Assert(events, qt.HasLen, 3)
+	c.Check(events[0].WatcherState, qt.Equals, dbmodel.WatcherStateStopped)
+	c.Check(events[0].Error, qt.Equals, "connection lost")
+	c.Check(events[1].WatcherState, qt.Equals, dbmodel.WatcherStateWatching)
+	c.Check(events[1].Healthy, qt.IsTrue)
+	c.Check(events[2].WatcherState, qt.Equals, dbmodel.WatcherStateConnecting)
+}
+
 func TestWatcherRemoveDyingModelsOnStartup(t *testing.T) {
 	c := qt.New(t)
 
@@ -50,10 +50,10 @@ type OAuthHandlerParams struct {
 // BrowserOAuthAuthenticator handles authorisation code authentication within JIMM
 // via OIDC.
 type BrowserOAuthAuthenticator interface {
-	AuthCodeURL() (string, string, error)
-	Exchange(ctx context.Context, code string) (*oauth2.Token, error)
-	ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error)
-	Email(idToken *oidc.IDToken) (string, error)
+	AuthCodeURL(provider string) (string, string, error)
+	Exchange(ctx context.Context, provider, code string) (*oauth2.Token, error)
+	ExtractAndVerifyIDToken(ctx context.Context, provider string, oauth2Token *oauth2.Token) (*oidc.IDToken, error)
+	Email(provider string, idToken *oidc.IDToken) (string, error)
 	UpdateIdentity(ctx context.Context, email string, token *oauth2.Token) error
 	CreateBrowserSession(
 		ctx context.Context,
@@ -95,22 +95,31 @@ func (oah *OAuthHandler) Routes() chi.Router {
 func (oah *OAuthHandler) SetupMiddleware() {
 }
 
-// Login handles /auth/login.
+// Login handles /auth/login. The identity provider to log in with may be
+// chosen with the provider query parameter, otherwise the default identity
+// provider is used.
 func (oah *OAuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	redirectURL, state, err := oah.authenticator.AuthCodeURL()
+	provider := r.URL.Query().Get("provider")
+	redirectURL, state, err := oah.authenticator.AuthCodeURL(provider)
 	if err != nil {
-		writeError(ctx, w, http.StatusInternalServerError, err, "failed to generate auth redirect URL")
+		status := http.StatusInternalServerError
+		if errors.ErrorCode(err) == errors.CodeBadRequest {
+			status = http.StatusBadRequest
+		}
+		writeError(ctx, w, status, err, "failed to generate auth redirect URL")
 		return
 	}
-	http.SetCookie(w, &http.Cookie{
-		Name:     auth.StateKey,
-		Value:    state,
-		MaxAge:   900,                                     // 15 min.
-		Path:     AuthResourceBasePath + CallbackEndpoint, // Only send the cookie back on /auth paths.
-		HttpOnly: true,                                    // Restrict access from JS.
-		SameSite: http.SameSiteLaxMode,                    // Allow the cookie to be sent on a redirect from the IdP to JIMM.
-	})
+	for name, value := range map[string]string{auth.StateKey: state, auth.ProviderKey: provider} {
+		http.SetCookie(w, &http.Cookie{
+			Name:     name,
+			Value:    value,
+			MaxAge:   900,                                     // 15 min.
+			Path:     AuthResourceBasePath + CallbackEndpoint, // Only send the cookie back on /auth paths.
+			HttpOnly: true,                                    // Restrict access from JS.
+			SameSite: http.SameSiteLaxMode,                    // Allow the cookie to be sent on a redirect from the IdP to JIMM.
+		})
+	}
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 }
 
@@ -138,23 +147,34 @@ func (oah *OAuthHandler) Callback(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The provider cookie is only missing for logins started before
+	// multiple providers were supported, which used the default provider.
+	var provider string
+	if c, err := r.Cookie(auth.ProviderKey); err == nil {
+		provider = c.Value
+	}
+
 	authSvc := oah.authenticator
 
-	token, err := authSvc.Exchange(ctx, code)
+	token, err := authSvc.Exchange(ctx, provider, code)
 	if err != nil {
 		writeError(ctx, w, http.StatusForbidden, err, "failed to exchange authcode")
 		return
 	}
 
-	idToken, err := authSvc.ExtractAndVerifyIDToken(ctx, token)
+	idToken, err := authSvc.ExtractAndVerifyIDToken(ctx, provider, token)
 	if err != nil {
 		writeError(ctx, w, http.StatusInternalServerError, err, "failed to extract and verify id token")
 		return
 	}
 
-	email, err := authSvc.Email(idToken)
+	email, err := authSvc.Email(provider, idToken)
 	if err != nil {
-		writeError(ctx, w, http.StatusInternalServerError, err, "failed to extract email from id token")
+		status := http.StatusInternalServerError
+		if errors.ErrorCode(err) == errors.CodeUnauthorized {
+			status = http.StatusForbidden
+		}
+		writeError(ctx, w, status, err, "failed to extract email from id token")
 		return
 	}
 
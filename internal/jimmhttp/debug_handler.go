@@ -4,26 +4,90 @@ package jimmhttp
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
+	"github.com/juju/zaputil/zapctx"
+	"go.uber.org/zap"
 
+	"github.com/canonical/jimm/v3/pkg/api/params"
 	"github.com/canonical/jimm/v3/version"
 )
 
+const (
+	// defaultControllerHistory is the number of health events returned
+	// for each controller by /controllers if none is requested.
+	defaultControllerHistory = 10
+
+	// maxControllerHistory is the maximum number of health events that
+	// can be requested for each controller.
+	maxControllerHistory = 100
+)
+
 // DebugHandler holds the grouped router to be mounted and
 // any service checks we wish to register.
 // Implements jimmhttp.JIMMHttpHandler
 type DebugHandler struct {
 	Router       *chi.Mux
 	StatusChecks map[string]StatusCheck
+
+	// ReadinessChecks are the checks run by /readyz. The server is
+	// ready to receive requests only if every check passes.
+	ReadinessChecks map[string]StatusCheck
+
+	// LivenessChecks are the checks run by /livez. If any check fails
+	// the server should be restarted.
+	LivenessChecks map[string]StatusCheck
+
+	Controllers ControllerHealthSource
+
+	// ControllersAuth authorises requests to /controllers.
+	ControllersAuth func(http.Handler) http.Handler
+}
+
+// DebugHandlerParams holds the parameters used to create a DebugHandler.
+type DebugHandlerParams struct {
+	// StatusChecks are the checks reported by /status.
+	StatusChecks map[string]StatusCheck
+
+	// ReadinessChecks are the checks run by /readyz.
+	ReadinessChecks map[string]StatusCheck
+
+	// LivenessChecks are the checks run by /livez.
+	LivenessChecks map[string]StatusCheck
+
+	// Controllers provides the controller health reported by
+	// /controllers.
+	Controllers ControllerHealthSource
+
+	// ControllersAuth, if set, is middleware that authorises requests to
+	// /controllers. The controller health includes error messages that
+	// contain controller addresses, so it should not be exposed to
+	// unauthenticated users.
+	ControllersAuth func(http.Handler) http.Handler
+}
+
+// A ControllerHealthSource provides the health of the controllers
+// reported by the /controllers endpoint.
+type ControllerHealthSource interface {
+	// ControllerHealthReports returns the health of every controller
+	// with, at most, the given number of recent health events.
+	ControllerHealthReports(ctx context.Context, history int) ([]params.ControllerHealthReport, error)
 }
 
 // NewDebugHandler returns a new debug handler
-func NewDebugHandler(statusChecks map[string]StatusCheck) *DebugHandler {
-	return &DebugHandler{Router: chi.NewRouter(), StatusChecks: statusChecks}
+func NewDebugHandler(p DebugHandlerParams) *DebugHandler {
+	return &DebugHandler{
+		Router:          chi.NewRouter(),
+		StatusChecks:    p.StatusChecks,
+		ReadinessChecks: p.ReadinessChecks,
+		LivenessChecks:  p.LivenessChecks,
+		Controllers:     p.Controllers,
+		ControllersAuth: p.ControllersAuth,
+	}
 }
 
 // Routes returns the grouped routers routes with group specific middlewares.
@@ -31,6 +95,13 @@ func (dh *DebugHandler) Routes() chi.Router {
 	dh.SetupMiddleware()
 	dh.Router.Get("/info", dh.Info)
 	dh.Router.Get("/status", dh.Status)
+	dh.Router.Get("/readyz", dh.Readyz)
+	dh.Router.Get("/livez", dh.Livez)
+	if dh.ControllersAuth != nil {
+		dh.Router.With(dh.ControllersAuth).Get("/controllers", dh.ControllerHealth)
+	} else {
+		dh.Router.Get("/controllers", dh.ControllerHealth)
+	}
 	return dh.Router
 }
 
@@ -50,9 +121,38 @@ func (dh *DebugHandler) Info(w http.ResponseWriter, r *http.Request) {
 
 // Status handles /status, returning the currently registered status checks.
 func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
-	checks := dh.StatusChecks
+	results, _ := runStatusChecks(r.Context(), dh.StatusChecks)
+	render.JSON(w, r, results)
+}
+
+// Readyz handles /readyz, running the readiness checks. If any check
+// fails the response has a status of 503 Service Unavailable.
+func (dh *DebugHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	writeProbeResults(w, r, dh.ReadinessChecks)
+}
+
+// Livez handles /livez, running the liveness checks. If any check fails
+// the response has a status of 503 Service Unavailable.
+func (dh *DebugHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	writeProbeResults(w, r, dh.LivenessChecks)
+}
+
+// writeProbeResults runs the given checks and writes the results,
+// setting the response status to reflect whether they all passed.
+func writeProbeResults(w http.ResponseWriter, r *http.Request, checks map[string]StatusCheck) {
+	results, passed := runStatusChecks(r.Context(), checks)
+	if !passed {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, results)
+}
+
+// runStatusChecks runs the given checks concurrently, returning the
+// results and whether every check passed.
+func runStatusChecks(ctx context.Context, checks map[string]StatusCheck) (map[string]statusResult, bool) {
 	var mu sync.Mutex
 	results := make(map[string]statusResult, len(checks))
+	passed := true
 	var wg sync.WaitGroup
 	wg.Add(len(checks))
 	for k, check := range checks {
@@ -63,7 +163,7 @@ func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
 				Name: check.Name(),
 			}
 			start := time.Now()
-			v, err := check.Check(r.Context())
+			v, err := check.Check(ctx)
 			result.Duration = time.Since(start)
 			if err == nil {
 				result.Passed = true
@@ -74,10 +174,40 @@ func (dh *DebugHandler) Status(w http.ResponseWriter, r *http.Request) {
 			mu.Lock()
 			defer mu.Unlock()
 			results[k] = result
+			passed = passed && result.Passed
 		}()
 	}
 	wg.Wait()
-	render.JSON(w, r, results)
+	return results, passed
+}
+
+// ControllerHealth handles /controllers, returning the health of every
+// controller along with its recent health history. The number of history
+// entries returned for each controller can be set with the "history"
+// query parameter.
+func (dh *DebugHandler) ControllerHealth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if dh.Controllers == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	history := defaultControllerHistory
+	if v := r.URL.Query().Get("history"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			render.JSON(w, r, map[string]string{"error": "invalid history"})
+			return
+		}
+		history = min(n, maxControllerHistory)
+	}
+	reports, err := dh.Controllers.ControllerHealthReports(ctx, history)
+	if err != nil {
+		zapctx.Error(ctx, "HTTP error", zap.NamedError("/controllers", err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	render.JSON(w, r, reports)
 }
 
 // A statusResult is the type that represents the result of a status check
@@ -124,6 +254,57 @@ func (c statusCheck) Check(ctx context.Context) (interface{}, error) {
 	return c.f(ctx)
 }
 
+// MakeCachedStatusCheck returns a status check that runs the given check
+// at most once in every ttl, returning the previous result in between.
+// If timeout is greater than zero each run of the check is limited to
+// that duration.
+func MakeCachedStatusCheck(check StatusCheck, ttl, timeout time.Duration) StatusCheck {
+	return &cachedStatusCheck{
+		check:   check,
+		ttl:     ttl,
+		timeout: timeout,
+	}
+}
+
+// A cachedStatusCheck is the implementation of StatusCheck returned from
+// MakeCachedStatusCheck.
+type cachedStatusCheck struct {
+	check   StatusCheck
+	ttl     time.Duration
+	timeout time.Duration
+
+	mu      sync.Mutex
+	expires time.Time
+	value   interface{}
+	err     error
+}
+
+// Name implements StatusCheck.Name.
+func (c *cachedStatusCheck) Name() string {
+	return c.check.Name()
+}
+
+// Check implements StatusCheck.Check.
+func (c *cachedStatusCheck) Check(ctx context.Context) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if now.Before(c.expires) {
+		return c.value, c.err
+	}
+	// The result is shared between requests, so the check is not
+	// canceled if the request that triggered it goes away.
+	ctx = context.WithoutCancel(ctx)
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+	c.value, c.err = c.check.Check(ctx)
+	c.expires = now.Add(c.ttl)
+	return c.value, c.err
+}
+
 var startTime = time.Now().UTC()
 
 // ServerStartTime is a StatusCheck that returns the server start time.
@@ -16,6 +16,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimmhttp"
+	"github.com/canonical/jimm/v3/pkg/api/params"
 	"github.com/canonical/jimm/v3/version"
 )
 
@@ -91,3 +92,221 @@ func TestDebugStatusStatusError(t *testing.T) {
 	c.Check(v["start_time"]["Value"], qt.Equals, "test error")
 	c.Check(v["start_time"]["Passed"], qt.Equals, false)
 }
+
+type controllerHealthSource struct {
+	history int
+	reports []params.ControllerHealthReport
+	err     error
+}
+
+func (s *controllerHealthSource) ControllerHealthReports(_ context.Context, history int) ([]params.ControllerHealthReport, error) {
+	s.history = history
+	return s.reports, s.err
+}
+
+func TestDebugControllers(t *testing.T) {
+	c := qt.New(t)
+
+	since := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	source := controllerHealthSource{
+		reports: []params.ControllerHealthReport{{
+			Name:   "controller-1",
+			UUID:   "00000000-0000-0000-0000-000000000001",
+			Status: "degraded",
+			Health: &params.ControllerHealth{
+				WatcherState:  "watching",
+				ErrorStreak:   3,
+				DegradedSince: &since,
+				LastError:     "ping timeout",
+				UpdatedAt:     since,
+			},
+			History: []params.ControllerHealthEvent{{
+				Time:         since,
+				WatcherState: "watching",
+				Error:        "ping timeout",
+			}},
+		}},
+	}
+
+	tests := []struct {
+		path           string
+		expectStatus   int
+		expectHistory  int
+		expectResponse bool
+	}{{
+		path:           "/controllers",
+		expectStatus:   http.StatusOK,
+		expectHistory:  10,
+		expectResponse: true,
+	}, {
+		path:           "/controllers?history=2",
+		expectStatus:   http.StatusOK,
+		expectHistory:  2,
+		expectResponse: true,
+	}, {
+		path:           "/controllers?history=1000",
+		expectStatus:   http.StatusOK,
+		expectHistory:  100,
+		expectResponse: true,
+	}, {
+		path:         "/controllers?history=many",
+		expectStatus: http.StatusBadRequest,
+	}}
+	for _, test := range tests {
+		c.Run(test.path, func(c *qt.C) {
+			source.history = -1
+			r := jimmhttp.NewDebugHandler(jimmhttp.DebugHandlerParams{Controllers: &source}).Routes()
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.path, nil)
+			c.Assert(err, qt.IsNil)
+			r.ServeHTTP(rr, req)
+
+			resp := rr.Result()
+			defer resp.Body.Close()
+			c.Check(resp.StatusCode, qt.Equals, test.expectStatus)
+			if !test.expectResponse {
+				return
+			}
+			c.Check(source.history, qt.Equals, test.expectHistory)
+			buf, err := io.ReadAll(resp.Body)
+			c.Assert(err, qt.IsNil)
+			c.Check(buf, qt.JSONEquals, source.reports)
+		})
+	}
+}
+
+func TestDebugControllersAuth(t *testing.T) {
+	c := qt.New(t)
+
+	source := controllerHealthSource{}
+	r := jimmhttp.NewDebugHandler(jimmhttp.DebugHandlerParams{
+		Controllers: &source,
+		ControllersAuth: func(http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			})
+		},
+	}).Routes()
+
+	tests := []struct {
+		path         string
+		expectStatus int
+	}{{
+		path:         "/controllers",
+		expectStatus: http.StatusUnauthorized,
+	}, {
+		// Other endpoints are not affected.
+		path:         "/status",
+		expectStatus: http.StatusOK,
+	}}
+	for _, test := range tests {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", test.path, nil)
+		c.Assert(err, qt.IsNil)
+		r.ServeHTTP(rr, req)
+		c.Check(rr.Code, qt.Equals, test.expectStatus, qt.Commentf("%s", test.path))
+	}
+}
+
+func TestDebugControllersError(t *testing.T) {
+	c := qt.New(t)
+
+	r := jimmhttp.NewDebugHandler(jimmhttp.DebugHandlerParams{
+		Controllers: &controllerHealthSource{err: errors.E("test error")},
+	}).Routes()
+	rr := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/controllers", nil)
+	c.Assert(err, qt.IsNil)
+	r.ServeHTTP(rr, req)
+	c.Check(rr.Result().StatusCode, qt.Equals, http.StatusInternalServerError)
+}
+
+func TestDebugProbes(t *testing.T) {
+	c := qt.New(t)
+
+	passing := jimmhttp.MakeStatusCheck("passing", func(context.Context) (interface{}, error) {
+		return "ok", nil
+	})
+	failing := jimmhttp.MakeStatusCheck("failing", func(context.Context) (interface{}, error) {
+		return nil, errors.E("test error")
+	})
+
+	tests := []struct {
+		path         string
+		params       jimmhttp.DebugHandlerParams
+		expectStatus int
+	}{{
+		path: "/readyz",
+		params: jimmhttp.DebugHandlerParams{
+			ReadinessChecks: map[string]jimmhttp.StatusCheck{"a": passing, "b": passing},
+		},
+		expectStatus: http.StatusOK,
+	}, {
+		path: "/readyz",
+		params: jimmhttp.DebugHandlerParams{
+			ReadinessChecks: map[string]jimmhttp.StatusCheck{"a": passing, "b": failing},
+			LivenessChecks:  map[string]jimmhttp.StatusCheck{"a": passing},
+		},
+		expectStatus: http.StatusServiceUnavailable,
+	}, {
+		path: "/livez",
+		params: jimmhttp.DebugHandlerParams{
+			ReadinessChecks: map[string]jimmhttp.StatusCheck{"b": failing},
+			LivenessChecks:  map[string]jimmhttp.StatusCheck{"a": passing},
+		},
+		expectStatus: http.StatusOK,
+	}, {
+		path: "/livez",
+		params: jimmhttp.DebugHandlerParams{
+			LivenessChecks: map[string]jimmhttp.StatusCheck{"b": failing},
+		},
+		expectStatus: http.StatusServiceUnavailable,
+	}}
+	for i, test := range tests {
+		c.Run(fmt.Sprintf("%d%s", i, test.path), func(c *qt.C) {
+			r := jimmhttp.NewDebugHandler(test.params).Routes()
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", test.path, nil)
+			c.Assert(err, qt.IsNil)
+			r.ServeHTTP(rr, req)
+
+			resp := rr.Result()
+			defer resp.Body.Close()
+			c.Check(resp.StatusCode, qt.Equals, test.expectStatus)
+			var v map[string]map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&v)
+			c.Assert(err, qt.IsNil)
+			checks := test.params.ReadinessChecks
+			if test.path == "/livez" {
+				checks = test.params.LivenessChecks
+			}
+			c.Check(v, qt.HasLen, len(checks))
+		})
+	}
+}
+
+func TestCachedStatusCheck(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	var calls int
+	check := jimmhttp.MakeCachedStatusCheck(jimmhttp.MakeStatusCheck("test", func(ctx context.Context) (interface{}, error) {
+		calls++
+		_, ok := ctx.Deadline()
+		c.Check(ok, qt.IsTrue)
+		return calls, nil
+	}), 50*time.Millisecond, time.Second)
+	c.Check(check.Name(), qt.Equals, "test")
+
+	v, err := check.Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, 1)
+	v, err = check.Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, 1)
+
+	time.Sleep(60 * time.Millisecond)
+	v, err = check.Check(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(v, qt.Equals, 2)
+}
@@ -21,6 +21,7 @@ func SetupBackend(ctx context.Context, jimm jujuapi.JIMM) (*rebac_handlers.ReBAC
 		Entitlements:  newEntitlementService(),
 		Groups:        newGroupService(jimm),
 		Identities:    newidentitiesService(jimm),
+		Roles:         newRoleService(jimm),
 		Resources:     newResourcesService(jimm),
 		Capabilities:  newCapabilitiesService(),
 	})
@@ -53,6 +53,13 @@ var capabilities = []resources.Capability{
 			"PATCH",
 		},
 	},
+	{
+		Endpoint: "/identities/{id}/roles",
+		Methods: []resources.CapabilityMethods{
+			"GET",
+			"PATCH",
+		},
+	},
 	{
 		Endpoint: "/identities/{id}/entitlements",
 		Methods: []resources.CapabilityMethods{
@@ -82,6 +89,13 @@ var capabilities = []resources.Capability{
 			"PATCH",
 		},
 	},
+	{
+		Endpoint: "/groups/{id}/roles",
+		Methods: []resources.CapabilityMethods{
+			"GET",
+			"PATCH",
+		},
+	},
 	{
 		Endpoint: "/groups/{id}/entitlements",
 		Methods: []resources.CapabilityMethods{
@@ -89,6 +103,28 @@ var capabilities = []resources.Capability{
 			"PATCH",
 		},
 	},
+	{
+		Endpoint: "/roles",
+		Methods: []resources.CapabilityMethods{
+			"GET",
+			"POST",
+		},
+	},
+	{
+		Endpoint: "/roles/{id}",
+		Methods: []resources.CapabilityMethods{
+			"GET",
+			"PUT",
+			"DELETE",
+		},
+	},
+	{
+		Endpoint: "/roles/{id}/entitlements",
+		Methods: []resources.CapabilityMethods{
+			"GET",
+			"PATCH",
+		},
+	},
 	{
 		Endpoint: "/entitlements",
 		Methods: []resources.CapabilityMethods{
@@ -3,6 +3,7 @@ package rebac_admin
 
 var (
 	NewGroupService      = newGroupService
+	NewRoleService       = newRoleService
 	NewidentitiesService = newidentitiesService
 	NewResourcesService  = newResourcesService
 	Capabilities         = capabilities
@@ -232,12 +232,54 @@ func (s *groupsService) PatchGroupIdentities(ctx context.Context, groupId string
 
 // GetGroupRoles returns a page of Roles for Group `groupId`.
 func (s *groupsService) GetGroupRoles(ctx context.Context, groupId string, params *resources.GetGroupsItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
-	return nil, v1.NewNotImplementedError("get group roles not implemented")
+	user, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !jimmnames.IsValidGroupId(groupId) {
+		return nil, v1.NewValidationError("invalid group ID")
+	}
+	filter := utils.CreateTokenPaginationFilter(params.Size, params.NextToken, params.NextPageToken)
+	group := ofganames.WithMemberRelation(jimmnames.NewGroupTag(groupId))
+	return listAssignedRoles(ctx, s.jimm, user, group, filter)
 }
 
 // PatchGroupRoles performs addition or removal of a Role to/from a Group identified by `groupId`.
 func (s *groupsService) PatchGroupRoles(ctx context.Context, groupId string, rolePatches []resources.GroupRolesPatchItem) (bool, error) {
-	return false, v1.NewNotImplementedError("patch group roles not implemented")
+	user, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	if !jimmnames.IsValidGroupId(groupId) {
+		return false, v1.NewValidationError("invalid group ID")
+	}
+	group := ofganames.WithMemberRelation(jimmnames.NewGroupTag(groupId))
+	var toRemove []apiparams.RelationshipTuple
+	var toAdd []apiparams.RelationshipTuple
+	for _, rolePatch := range rolePatches {
+		t, err := roleAssignmentTuple(group, rolePatch.Role)
+		if err != nil {
+			return false, err
+		}
+		if rolePatch.Op == resources.GroupRolesPatchItemOpAdd {
+			toAdd = append(toAdd, t)
+		} else {
+			toRemove = append(toRemove, t)
+		}
+	}
+	if toAdd != nil {
+		err := s.jimm.AddRelation(ctx, user, toAdd)
+		if err != nil {
+			return false, err
+		}
+	}
+	if toRemove != nil {
+		err := s.jimm.RemoveRelation(ctx, user, toRemove)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
 }
 
 // GetGroupEntitlements returns a page of Entitlements for Group `groupId`.
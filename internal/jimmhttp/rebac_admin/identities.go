@@ -96,12 +96,57 @@ func (s *identitiesService) DeleteIdentity(ctx context.Context, identityId strin
 
 // // GetIdentityRoles returns a page of Roles for identity `identityId`.
 func (s *identitiesService) GetIdentityRoles(ctx context.Context, identityId string, params *resources.GetIdentitiesItemRolesParams) (*resources.PaginatedResponse[resources.Role], error) {
-	return nil, v1.NewNotImplementedError("get identity roles not implemented")
+	user, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objUser, err := s.jimm.FetchIdentity(ctx, identityId)
+	if err != nil {
+		return nil, v1.NewNotFoundError(fmt.Sprintf("User with id %s not found", identityId))
+	}
+	filter := utils.CreateTokenPaginationFilter(params.Size, params.NextToken, params.NextPageToken)
+	return listAssignedRoles(ctx, s.jimm, user, objUser.ResourceTag().String(), filter)
 }
 
 // // PatchIdentityRoles performs addition or removal of a Role to/from an Identity.
 func (s *identitiesService) PatchIdentityRoles(ctx context.Context, identityId string, rolePatches []resources.IdentityRolesPatchItem) (bool, error) {
-	return false, v1.NewNotImplementedError("get identity roles not implemented")
+	user, err := utils.GetUserFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	objUser, err := s.jimm.FetchIdentity(ctx, identityId)
+	if err != nil {
+		return false, v1.NewNotFoundError(fmt.Sprintf("User with id %s not found", identityId))
+	}
+	additions := make([]apiparams.RelationshipTuple, 0)
+	deletions := make([]apiparams.RelationshipTuple, 0)
+	for _, p := range rolePatches {
+		t, err := roleAssignmentTuple(objUser.ResourceTag().String(), p.Role)
+		if err != nil {
+			return false, err
+		}
+		if p.Op == resources.IdentityRolesPatchItemOpAdd {
+			additions = append(additions, t)
+		} else if p.Op == resources.IdentityRolesPatchItemOpRemove {
+			deletions = append(deletions, t)
+		}
+	}
+	if len(additions) > 0 {
+		err = s.jimm.AddRelation(ctx, user, additions)
+		if err != nil {
+			zapctx.Error(context.Background(), "cannot add relations", zap.Error(err))
+			return false, v1.NewUnknownError(err.Error())
+		}
+	}
+	if len(deletions) > 0 {
+		err = s.jimm.RemoveRelation(ctx, user, deletions)
+		if err != nil {
+			zapctx.Error(context.Background(), "cannot remove relations", zap.Error(err))
+			return false, v1.NewUnknownError(err.Error())
+		}
+	}
+	return true, nil
 }
 
 // GetIdentityGroups returns a page of Groups for identity `identityId`.
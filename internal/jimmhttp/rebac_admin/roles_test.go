@@ -0,0 +1,419 @@
+// Copyright 2024 Canonical.
+
+package rebac_admin_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/canonical/ofga"
+	rebac_handlers "github.com/canonical/rebac-admin-ui-handlers/v1"
+	"github.com/canonical/rebac-admin-ui-handlers/v1/resources"
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/internal/common/pagination"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/jimmhttp/rebac_admin"
+	"github.com/canonical/jimm/v3/internal/openfga"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest/mocks"
+	"github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func TestCreateRole(t *testing.T) {
+	c := qt.New(t)
+	var addErr error
+	jimm := jimmtest.JIMM{
+		RoleService: mocks.RoleService{
+			AddRole_: func(ctx context.Context, user *openfga.User, name string) (*dbmodel.RoleEntry, error) {
+				return &dbmodel.RoleEntry{UUID: "test-uuid", Name: name}, addErr
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+	resp, err := roleSvc.CreateRole(ctx, &resources.Role{Name: "db-operators"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(*resp.Id, qt.Equals, "test-uuid")
+	c.Assert(resp.Name, qt.Equals, "db-operators")
+
+	_, err = roleSvc.CreateRole(ctx, &resources.Role{Name: "db#operators"})
+	c.Assert(err, qt.ErrorMatches, ".*invalid role name")
+
+	addErr = errors.New("foo")
+	_, err = roleSvc.CreateRole(ctx, &resources.Role{Name: "db-operators"})
+	c.Assert(err, qt.ErrorMatches, "foo")
+}
+
+func TestUpdateRole(t *testing.T) {
+	c := qt.New(t)
+	roleID := "role-id"
+	var renameErr error
+	jimm := jimmtest.JIMM{
+		RoleService: mocks.RoleService{
+			GetRoleByUUID_: func(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error) {
+				return &dbmodel.RoleEntry{UUID: roleID, Name: "test-role"}, nil
+			},
+			RenameRole_: func(ctx context.Context, user *openfga.User, oldName, newName string) error {
+				if oldName != "test-role" {
+					return errors.New("invalid old role name")
+				}
+				return renameErr
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+	_, err := roleSvc.UpdateRole(ctx, &resources.Role{Name: "new-role"})
+	c.Assert(err, qt.ErrorMatches, ".*missing role ID")
+	resp, err := roleSvc.UpdateRole(ctx, &resources.Role{Id: &roleID, Name: "new-role"})
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp, qt.DeepEquals, &resources.Role{Id: &roleID, Name: "new-role"})
+	renameErr = errors.New("foo")
+	_, err = roleSvc.UpdateRole(ctx, &resources.Role{Id: &roleID, Name: "new-role"})
+	c.Assert(err, qt.ErrorMatches, "foo")
+}
+
+func TestListRoles(t *testing.T) {
+	c := qt.New(t)
+	var listErr error
+	returnedRoles := []dbmodel.RoleEntry{
+		{Name: "role-1"},
+		{Name: "role-2"},
+		{Name: "role-3"},
+	}
+	jimm := jimmtest.JIMM{
+		RoleService: mocks.RoleService{
+			ListRoles_: func(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.RoleEntry, error) {
+				return returnedRoles, listErr
+			},
+			CountRoles_: func(ctx context.Context, user *openfga.User) (int, error) {
+				return 10, nil
+			},
+		},
+	}
+	expected := []resources.Role{}
+	id := ""
+	for _, role := range returnedRoles {
+		expected = append(expected, resources.Role{Name: role.Name, Id: &id})
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+	resp, err := roleSvc.ListRoles(ctx, &resources.GetRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp.Data, qt.DeepEquals, expected)
+	c.Assert(*resp.Meta.Page, qt.Equals, 0)
+	c.Assert(resp.Meta.Size, qt.Equals, len(expected))
+	c.Assert(*resp.Meta.Total, qt.Equals, 10)
+	c.Assert(*resp.Next.Page, qt.Equals, 1)
+	listErr = errors.New("foo")
+	_, err = roleSvc.ListRoles(ctx, &resources.GetRolesParams{})
+	c.Assert(err, qt.ErrorMatches, "foo")
+}
+
+func TestDeleteRole(t *testing.T) {
+	c := qt.New(t)
+	var deleteErr error
+	jimm := jimmtest.JIMM{
+		RoleService: mocks.RoleService{
+			GetRoleByUUID_: func(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error) {
+				return &dbmodel.RoleEntry{UUID: uuid, Name: "test-role"}, nil
+			},
+			RemoveRole_: func(ctx context.Context, user *openfga.User, name string) error {
+				if name != "test-role" {
+					return errors.New("invalid name provided")
+				}
+				return deleteErr
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+	res, err := roleSvc.DeleteRole(ctx, "role-id")
+	c.Assert(res, qt.IsTrue)
+	c.Assert(err, qt.IsNil)
+	deleteErr = errors.New("foo")
+	_, err = roleSvc.DeleteRole(ctx, "role-id")
+	c.Assert(err, qt.ErrorMatches, "foo")
+}
+
+func TestGetRoleEntitlements(t *testing.T) {
+	c := qt.New(t)
+	var listRelationsErr error
+	var gotObject string
+	testTuple := openfga.Tuple{
+		Object:   &ofga.Entity{Kind: "role", ID: "my-role", Relation: "assignee"},
+		Relation: ofga.Relation("administrator"),
+		Target:   &ofga.Entity{Kind: "model", ID: "my-model"},
+	}
+	jimm := jimmtest.JIMM{
+		RelationService: mocks.RelationService{
+			ListObjectRelations_: func(ctx context.Context, user *openfga.User, object string, pageSize int32, ct pagination.EntitlementToken) ([]openfga.Tuple, pagination.EntitlementToken, error) {
+				gotObject = object
+				return []openfga.Tuple{testTuple}, pagination.NewEntitlementToken(""), listRelationsErr
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+
+	_, err := roleSvc.GetRoleEntitlements(ctx, "invalid-role-id", nil)
+	c.Assert(err, qt.ErrorMatches, ".* invalid role ID")
+
+	roleID := uuid.New().String()
+	res, err := roleSvc.GetRoleEntitlements(ctx, roleID, &resources.GetRolesItemEntitlementsParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotObject, qt.Equals, "role-"+roleID+"#assignee")
+	c.Assert(res.Data, qt.DeepEquals, []resources.EntityEntitlement{{
+		Entitlement: "administrator",
+		EntityId:    "my-model",
+		EntityType:  "model",
+	}})
+	c.Assert(res.Next.PageToken, qt.IsNil)
+
+	listRelationsErr = errors.New("foo")
+	_, err = roleSvc.GetRoleEntitlements(ctx, roleID, &resources.GetRolesItemEntitlementsParams{})
+	c.Assert(err, qt.ErrorMatches, "foo")
+}
+
+func TestPatchRoleEntitlements(t *testing.T) {
+	c := qt.New(t)
+	var added, removed []params.RelationshipTuple
+	jimm := jimmtest.JIMM{
+		RelationService: mocks.RelationService{
+			AddRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				added = tuples
+				return nil
+			},
+			RemoveRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				removed = tuples
+				return nil
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	roleSvc := rebac_admin.NewRoleService(&jimm)
+
+	_, err := roleSvc.PatchRoleEntitlements(ctx, "invalid-role-id", nil)
+	c.Assert(err, qt.ErrorMatches, ".* invalid role ID")
+
+	roleID := uuid.New().String()
+	modelID := uuid.New().String()
+	operations := []resources.RoleEntitlementsPatchItem{
+		{Entitlement: resources.EntityEntitlement{
+			Entitlement: "administrator",
+			EntityId:    modelID,
+			EntityType:  "model",
+		}, Op: resources.Add},
+		{Entitlement: resources.EntityEntitlement{
+			Entitlement: "reader",
+			EntityId:    modelID,
+			EntityType:  "model",
+		}, Op: resources.Remove},
+	}
+	res, err := roleSvc.PatchRoleEntitlements(ctx, roleID, operations)
+	c.Assert(err, qt.IsNil)
+	c.Assert(res, qt.IsTrue)
+	c.Assert(added, qt.DeepEquals, []params.RelationshipTuple{{
+		Object:       "role-" + roleID + "#assignee",
+		Relation:     "administrator",
+		TargetObject: "model-" + modelID,
+	}})
+	c.Assert(removed, qt.DeepEquals, []params.RelationshipTuple{{
+		Object:       "role-" + roleID + "#assignee",
+		Relation:     "reader",
+		TargetObject: "model-" + modelID,
+	}})
+}
+
+func TestGetGroupRoles(t *testing.T) {
+	c := qt.New(t)
+	roleID := uuid.New().String()
+	var gotTuple params.RelationshipTuple
+	jimm := jimmtest.JIMM{
+		RelationService: mocks.RelationService{
+			ListRelationshipTuples_: func(ctx context.Context, user *openfga.User, tuple params.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
+				gotTuple = tuple
+				return []openfga.Tuple{{
+					Object:   &ofga.Entity{Kind: "group", ID: "my-group", Relation: "member"},
+					Relation: ofga.Relation("assignee"),
+					Target:   &ofga.Entity{Kind: "role", ID: roleID},
+				}}, "continuation-token", nil
+			},
+		},
+		RoleService: mocks.RoleService{
+			GetRoleByUUID_: func(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error) {
+				return &dbmodel.RoleEntry{UUID: uuid, Name: "db-operators"}, nil
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	groupSvc := rebac_admin.NewGroupService(&jimm)
+
+	_, err := groupSvc.GetGroupRoles(ctx, "invalid-group-id", &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.ErrorMatches, ".* invalid group ID")
+
+	groupID := uuid.New().String()
+	res, err := groupSvc.GetGroupRoles(ctx, groupID, &resources.GetGroupsItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotTuple, qt.DeepEquals, params.RelationshipTuple{
+		Object:       "group-" + groupID + "#member",
+		Relation:     "assignee",
+		TargetObject: "role",
+	})
+	c.Assert(res.Data, qt.DeepEquals, []resources.Role{{Id: &roleID, Name: "db-operators"}})
+	c.Assert(*res.Next.PageToken, qt.Equals, "continuation-token")
+}
+
+func TestPatchGroupRoles(t *testing.T) {
+	c := qt.New(t)
+	var added, removed []params.RelationshipTuple
+	jimm := jimmtest.JIMM{
+		RelationService: mocks.RelationService{
+			AddRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				added = tuples
+				return nil
+			},
+			RemoveRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				removed = tuples
+				return nil
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	groupSvc := rebac_admin.NewGroupService(&jimm)
+
+	groupID := uuid.New().String()
+	roleID := uuid.New().String()
+	_, err := groupSvc.PatchGroupRoles(ctx, groupID, []resources.GroupRolesPatchItem{{
+		Role: "not-a-role-id",
+		Op:   resources.GroupRolesPatchItemOpAdd,
+	}})
+	c.Assert(err, qt.ErrorMatches, ".*invalid role ID: not-a-role-id")
+
+	res, err := groupSvc.PatchGroupRoles(ctx, groupID, []resources.GroupRolesPatchItem{{
+		Role: roleID,
+		Op:   resources.GroupRolesPatchItemOpAdd,
+	}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res, qt.IsTrue)
+	c.Assert(added, qt.DeepEquals, []params.RelationshipTuple{{
+		Object:       "group-" + groupID + "#member",
+		Relation:     "assignee",
+		TargetObject: "role-" + roleID,
+	}})
+	c.Assert(removed, qt.IsNil)
+}
+
+func TestGetIdentityRoles(t *testing.T) {
+	c := qt.New(t)
+	roleID := uuid.New().String()
+	var gotTuple params.RelationshipTuple
+	jimm := jimmtest.JIMM{
+		FetchIdentity_: func(ctx context.Context, username string) (*openfga.User, error) {
+			if username == "bob@canonical.com" {
+				return openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil), nil
+			}
+			return nil, dbmodel.IdentityCreationError
+		},
+		RelationService: mocks.RelationService{
+			ListRelationshipTuples_: func(ctx context.Context, user *openfga.User, tuple params.RelationshipTuple, pageSize int32, continuationToken string) ([]openfga.Tuple, string, error) {
+				gotTuple = tuple
+				return []openfga.Tuple{{
+					Object:   &ofga.Entity{Kind: "user", ID: "bob@canonical.com"},
+					Relation: ofga.Relation("assignee"),
+					Target:   &ofga.Entity{Kind: "role", ID: roleID},
+				}}, "", nil
+			},
+		},
+		RoleService: mocks.RoleService{
+			GetRoleByUUID_: func(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error) {
+				return &dbmodel.RoleEntry{UUID: uuid, Name: "db-operators"}, nil
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	idSvc := rebac_admin.NewidentitiesService(&jimm)
+
+	_, err := idSvc.GetIdentityRoles(ctx, "bob-not-found@canonical.com", &resources.GetIdentitiesItemRolesParams{})
+	c.Assert(err, qt.ErrorMatches, ".*not found")
+
+	res, err := idSvc.GetIdentityRoles(ctx, "bob@canonical.com", &resources.GetIdentitiesItemRolesParams{})
+	c.Assert(err, qt.IsNil)
+	c.Assert(gotTuple.Object, qt.Equals, "user-bob@canonical.com")
+	c.Assert(res.Data, qt.DeepEquals, []resources.Role{{Id: &roleID, Name: "db-operators"}})
+	c.Assert(res.Next.PageToken, qt.IsNil)
+}
+
+func TestPatchIdentityRoles(t *testing.T) {
+	c := qt.New(t)
+	var patchTuplesErr error
+	var removed []params.RelationshipTuple
+	jimm := jimmtest.JIMM{
+		FetchIdentity_: func(ctx context.Context, username string) (*openfga.User, error) {
+			if username == "bob@canonical.com" {
+				return openfga.NewUser(&dbmodel.Identity{Name: "bob@canonical.com"}, nil), nil
+			}
+			return nil, dbmodel.IdentityCreationError
+		},
+		RelationService: mocks.RelationService{
+			AddRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				return patchTuplesErr
+			},
+			RemoveRelation_: func(ctx context.Context, user *openfga.User, tuples []params.RelationshipTuple) error {
+				removed = tuples
+				return patchTuplesErr
+			},
+		},
+	}
+	user := openfga.User{}
+	ctx := context.Background()
+	ctx = rebac_handlers.ContextWithIdentity(ctx, &user)
+	idSvc := rebac_admin.NewidentitiesService(&jimm)
+
+	roleID := uuid.New().String()
+	_, err := idSvc.PatchIdentityRoles(ctx, "bob-not-found@canonical.com", []resources.IdentityRolesPatchItem{{
+		Role: roleID,
+		Op:   resources.IdentityRolesPatchItemOpAdd,
+	}})
+	c.Assert(err, qt.ErrorMatches, ".*not found")
+
+	res, err := idSvc.PatchIdentityRoles(ctx, "bob@canonical.com", []resources.IdentityRolesPatchItem{{
+		Role: roleID,
+		Op:   resources.IdentityRolesPatchItemOpRemove,
+	}})
+	c.Assert(err, qt.IsNil)
+	c.Assert(res, qt.IsTrue)
+	c.Assert(removed, qt.DeepEquals, []params.RelationshipTuple{{
+		Object:       "user-bob@canonical.com",
+		Relation:     "assignee",
+		TargetObject: "role-" + roleID,
+	}})
+
+	patchTuplesErr = errors.New("foo")
+	_, err = idSvc.PatchIdentityRoles(ctx, "bob@canonical.com", []resources.IdentityRolesPatchItem{{
+		Role: roleID,
+		Op:   resources.IdentityRolesPatchItemOpAdd,
+	}})
+	c.Assert(err, qt.ErrorMatches, ".*foo")
+}
@@ -1,6 +1,32 @@
 // Copyright 2024 Canonical.
 package jimmjwx
 
+import (
+	"context"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
 var (
-	GenerateJWK = generateJWK
+	RotateJWKS          = (*JWKSService).rotateJWKS
+	SignerCheckInterval = &signerCheckInterval
 )
+
+// GenerateJWK generates a new key for the given algorithm, returning a
+// JWKS containing its public key and the PEM encoded private key.
+func GenerateJWK(ctx context.Context, alg jwa.SignatureAlgorithm) (jwk.Set, []byte, error) {
+	key, err := generateKey(alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	set, err := newJWKS(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	pemData, err := encodePrivateKeys(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return set, pemData, nil
+}
@@ -4,16 +4,11 @@ package jimmjwx
 
 import (
 	"context"
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/x509"
-	"encoding/pem"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/juju/zaputil/zapctx"
 	"github.com/lestrrat-go/jwx/v2/jwa"
-	"github.com/lestrrat-go/jwx/v2/jwk"
 	"go.uber.org/zap"
 
 	"github.com/canonical/jimm/v3/internal/errors"
@@ -24,68 +19,192 @@ import (
 // It utilises the underlying credential store currently in effect.
 type JWKSService struct {
 	credentialStore credentials.CredentialStore
+	keyAlgorithm    jwa.SignatureAlgorithm
+
+	mu sync.Mutex
+	// rotateListeners are called whenever the keys change.
+	rotateListeners []func()
 }
 
-// NewJWKSService returns a new JWKS service for handling JIMMs JWKS.
-func NewJWKSService(credStore credentials.CredentialStore) *JWKSService {
-	return &JWKSService{credentialStore: credStore}
+// NewJWKSService returns a new JWKS service for handling JIMMs JWKS. New
+// keys are generated for the given signature algorithm, see
+// ValidateKeyAlgorithm for the supported algorithms.
+func NewJWKSService(credStore credentials.CredentialStore, keyAlgorithm jwa.SignatureAlgorithm) *JWKSService {
+	return &JWKSService{credentialStore: credStore, keyAlgorithm: keyAlgorithm}
 }
 
-func rotateJWKS(ctx context.Context, credStore credentials.CredentialStore, initialExpiryTime time.Time) error {
-	// putJwks simply attempts the process of setting up the JWKS suite
-	// and all secrets required for JIMM to sign JWTs and clients to verify
-	// JWTs from JIMM.
-	putJwks := func(expires time.Time) error {
-		set, key, err := generateJWK(ctx)
-		if err != nil {
-			return err
-		}
+// OnRotate registers a function that is called whenever this service
+// changes the stored keys, for example to invalidate caches of the keys.
+func (jwks *JWKSService) OnRotate(f func()) {
+	jwks.mu.Lock()
+	defer jwks.mu.Unlock()
+	jwks.rotateListeners = append(jwks.rotateListeners, f)
+}
 
-		err = credStore.PutJWKS(ctx, set)
-		if err != nil {
-			return err
-		}
+// rotated notifies the rotate listeners that the stored keys have changed.
+func (jwks *JWKSService) rotated() {
+	jwks.mu.Lock()
+	defer jwks.mu.Unlock()
+	for _, f := range jwks.rotateListeners {
+		f()
+	}
+}
 
-		err = credStore.PutJWKSPrivateKey(ctx, key)
-		if err != nil {
-			return err
-		}
+// retiredKeyGracePeriod is the period for which a key that is no longer
+// used for signing is kept in the JWKS after a rotation. This allows
+// controllers that have cached the previous JWKS time to fetch the new
+// one before JWTs signed by the new key are rejected.
+const retiredKeyGracePeriod = 24 * time.Hour
+
+// nextExpiry returns the time at which keys rotated at the given time will
+// next be rotated.
+func nextExpiry(t time.Time) time.Time {
+	return t.AddDate(0, 3, 0)
+}
+
+// lastRotation returns the time at which keys expiring at the given time
+// were rotated. Due to the normalisation of dates by time.AddDate the
+// returned time may be a few days after the actual rotation, but never
+// before it.
+func lastRotation(expires time.Time) time.Time {
+	return expires.AddDate(0, -3, 0)
+}
+
+// putJWKS persists the given keys, and a JWKS publishing the public keys
+// of the keys and of any retired keys, to the credential store. The first
+// key is used to sign JWTs, the second is the next signing key. The
+// JWKS is persisted before the private keys so that a key is always
+// published before it is used.
+func (jwks *JWKSService) putJWKS(ctx context.Context, keys []privateKey, retired []privateKey, expires time.Time) error {
+	credStore := jwks.credentialStore
+	// The keys may have been partially updated on failure.
+	defer jwks.rotated()
 
-		err = credStore.PutJWKSExpiry(ctx, expires)
+	published := make([]privateKey, 0, len(retired)+len(keys))
+	published = append(published, retired...)
+	set, err := newJWKS(append(published, keys...)...)
+	if err != nil {
+		return err
+	}
+	if err := credStore.PutJWKS(ctx, set); err != nil {
+		return err
+	}
+	pemData, err := encodePrivateKeys(keys...)
+	if err != nil {
+		return err
+	}
+	if err := credStore.PutJWKSPrivateKey(ctx, pemData); err != nil {
+		return err
+	}
+	if err := credStore.PutJWKSExpiry(ctx, expires); err != nil {
+		return err
+	}
+	zapctx.Debug(ctx, "set a new JWKS", zap.String("signing-key", keys[0].kid), zap.String("next-key", keys[1].kid), zap.String("expiry", expires.String()))
+	return nil
+}
+
+// putNewJWKS replaces any existing keys with a new signing key and a new
+// next key.
+func (jwks *JWKSService) putNewJWKS(ctx context.Context, expires time.Time) error {
+	keys := make([]privateKey, 2)
+	for i := range keys {
+		var err error
+		keys[i], err = generateKey(jwks.keyAlgorithm)
 		if err != nil {
 			return err
 		}
-
-		zapctx.Debug(ctx, "set a new JWKS", zap.String("expiry", expires.String()))
-		return nil
 	}
+	return jwks.putJWKS(ctx, keys, nil, expires)
+}
+
+func (jwks *JWKSService) rotateJWKS(ctx context.Context, initialExpiryTime time.Time) error {
+	credStore := jwks.credentialStore
 
 	expires, err := credStore.GetJWKSExpiry(ctx)
 	if err != nil {
 		zapctx.Debug(ctx, "failed to get expiry", zap.Error(err))
 		zapctx.Debug(ctx, "setting initial expiry", zap.Time("time", initialExpiryTime))
-		err = putJwks(initialExpiryTime)
+		err = jwks.putNewJWKS(ctx, initialExpiryTime)
 		if err != nil {
 			if jwksErr := credStore.CleanupJWKS(ctx); jwksErr != nil {
 				zapctx.Error(ctx, "failed to cleanup jwks", zap.Error(jwksErr))
 			}
 			return errors.E(err)
 		}
-	} else {
-		// Check it has expired.
-		now := time.Now().UTC()
-		if now.After(expires) {
-			// In theory, an error should not happen anymore as the necessary
-			// components exist from the previous failed expiry attempt.
-			err = putJwks(time.Now().UTC().AddDate(0, 3, 0))
-			if err != nil {
-				if jwksErr := credStore.CleanupJWKS(ctx); jwksErr != nil {
-					zapctx.Error(ctx, "failed to cleanup jwks", zap.Error(jwksErr))
-				}
-				return errors.E(err)
-			}
-			zapctx.Debug(ctx, "set a new JWKS", zap.String("expiry", expires.String()))
+		return nil
+	}
+
+	set, err := credStore.GetJWKS(ctx)
+	if err != nil {
+		return errors.E(err)
+	}
+	pemData, err := credStore.GetJWKSPrivateKey(ctx)
+	if err != nil {
+		return errors.E(err)
+	}
+	keys, err := decodePrivateKeys(pemData, set)
+	if err != nil {
+		return errors.E(err)
+	}
+
+	// Failures below leave the stored keys in place, the rotation is
+	// retried on the next check.
+	now := time.Now().UTC()
+	switch {
+	case now.After(expires):
+		// The next key, published since the last rotation, becomes the
+		// signing key. The current signing key is retired but kept in
+		// the JWKS for the grace period.
+		var signing privateKey
+		if len(keys) > 1 {
+			signing = keys[1]
+		} else if signing, err = generateKey(jwks.keyAlgorithm); err != nil {
+			return errors.E(err)
 		}
+		next, err := generateKey(jwks.keyAlgorithm)
+		if err != nil {
+			return errors.E(err)
+		}
+		if err := jwks.putJWKS(ctx, []privateKey{signing, next}, keys[:1], nextExpiry(now)); err != nil {
+			return errors.E(err)
+		}
+	case len(keys) < 2:
+		// The JWKS was created before next keys were published, publish
+		// one now so that it is known ahead of the next rotation.
+		next, err := generateKey(jwks.keyAlgorithm)
+		if err != nil {
+			return errors.E(err)
+		}
+		if err := jwks.putJWKS(ctx, []privateKey{keys[0], next}, nil, expires); err != nil {
+			return errors.E(err)
+		}
+	case set.Len() > len(keys) && now.After(lastRotation(expires).Add(retiredKeyGracePeriod)):
+		// The grace period of the retired key has passed.
+		published, err := newJWKS(keys...)
+		if err != nil {
+			return errors.E(err)
+		}
+		if err := credStore.PutJWKS(ctx, published); err != nil {
+			return errors.E(err)
+		}
+		jwks.rotated()
+		zapctx.Debug(ctx, "removed retired keys from the JWKS")
+	}
+	return nil
+}
+
+// RotateJWKS immediately replaces all of JIMM's keys, including the next
+// and any retired keys, with new ones. It is intended for use when a key
+// may have been compromised, as such controllers that have cached the
+// previous JWKS will reject JWTs signed by the new key until they fetch
+// the JWKS again. Other JIMM replicas start signing JWTs with the new key
+// within signerCheckInterval.
+func (jwks *JWKSService) RotateJWKS(ctx context.Context) error {
+	const op = errors.Op("jimmjwx.RotateJWKS")
+
+	if err := jwks.putNewJWKS(ctx, nextExpiry(time.Now().UTC())); err != nil {
+		zapctx.Error(ctx, "failed to rotate jwks", zap.Error(err))
+		return errors.E(op, err)
 	}
 	return nil
 }
@@ -94,9 +213,10 @@ func rotateJWKS(ctx context.Context, credStore credentials.CredentialStore, init
 // It is expected that this routine will be cleaned up alongside other background services sharing
 // the same cancellable context.
 //
-// TODO(ale8k)[possibly?]:
-// For now, there's a single key, and this is probably OK. But possibly extend
-// this to contain many at some point differentiated by KIDs.
+// The JWKS publishes the public key of the signing key, of the next
+// signing key and, for a grace period after each rotation, of the retired
+// signing key. This allows clients that cache the JWKS to verify JWTs
+// throughout a rotation.
 //
 // We also currently don't use x5c and x5t for validation and expect users
 // to use e and n for validation.
@@ -104,9 +224,7 @@ func rotateJWKS(ctx context.Context, credStore credentials.CredentialStore, init
 func (jwks *JWKSService) StartJWKSRotator(ctx context.Context, checkRotateRequired <-chan time.Time, initialRotateRequiredTime time.Time) error {
 	const op = errors.Op("vault.StartJWKSRotator")
 
-	credStore := jwks.credentialStore
-
-	if err := rotateJWKS(ctx, credStore, initialRotateRequiredTime); err != nil {
+	if err := jwks.rotateJWKS(ctx, initialRotateRequiredTime); err != nil {
 		zapctx.Error(ctx, "Rotate JWKS error", zap.Error(err))
 		return errors.E(op, err)
 	}
@@ -115,12 +233,13 @@ func (jwks *JWKSService) StartJWKSRotator(ctx context.Context, checkRotateRequir
 	// this is the first attempt to set the initial JWKS (or it may be subsequent from erroneous attempts).
 	// As the next attempt comes around, it is a simple check if the times is after the current.
 	//
-	// In this case we generate a new set, which should expire in 3 months.
+	// In this case the next key becomes the signing key and a new next key
+	// is generated, which should expire in 3 months.
 	go func() {
 		for {
 			select {
 			case <-checkRotateRequired:
-				if err := rotateJWKS(ctx, credStore, initialRotateRequiredTime); err != nil {
+				if err := jwks.rotateJWKS(ctx, initialRotateRequiredTime); err != nil {
 					zapctx.Error(ctx, "security failure", zap.Any("op", op), zap.NamedError("jwks-error", err))
 				}
 			case <-ctx.Done():
@@ -132,58 +251,3 @@ func (jwks *JWKSService) StartJWKSRotator(ctx context.Context, checkRotateRequir
 
 	return nil
 }
-
-// generateJWKS generates a new set of JWK using RSA256[4096]
-//
-// It will return a jwk.Set containing the public key
-// and a PEM encoded private key for JWT signing.
-func generateJWK(ctx context.Context) (jwk.Set, []byte, error) {
-	const op = errors.Op("vault.generateJWKS")
-
-	// Due to the sensitivity of controllers, it is best we allow a larger encryption bit size
-	// and accept any negligible wire cost.
-	keySet, err := rsa.GenerateKey(rand.Reader, 4096)
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	privateKeyPEM := pem.EncodeToMemory(
-		&pem.Block{
-			Type:  "RSA PRIVATE KEY",
-			Bytes: x509.MarshalPKCS1PrivateKey(keySet),
-		},
-	)
-
-	// We also use the same methodology of generating UUIDs for our KID
-	kid, err := uuid.NewRandom()
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	jwks, err := jwk.FromRaw(keySet.PublicKey)
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-	err = jwks.Set(jwk.KeyIDKey, kid.String())
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	err = jwks.Set(jwk.KeyUsageKey, "sig") // Couldn't find const for this...
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	err = jwks.Set(jwk.AlgorithmKey, jwa.RS256)
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	ks := jwk.NewSet()
-	err = ks.AddKey(jwks)
-	if err != nil {
-		return nil, nil, errors.E(op, err)
-	}
-
-	return ks, privateKeyPEM, nil
-}
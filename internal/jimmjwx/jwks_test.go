@@ -3,6 +3,7 @@ package jimmjwx_test
 
 import (
 	"context"
+	"encoding/pem"
 	"os"
 	"testing"
 	"time"
@@ -11,8 +12,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
 
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
 
 func TestMain(m *testing.M) {
@@ -24,7 +28,7 @@ func TestGenerateJWKS(t *testing.T) {
 	c := qt.New(t)
 	ctx := context.Background()
 
-	jwks, privKeyPem, err := jimmjwx.GenerateJWK(ctx)
+	jwks, privKeyPem, err := jimmjwx.GenerateJWK(ctx, jwa.RS256)
 	c.Assert(err, qt.IsNil)
 
 	jwksIter := jwks.Keys(ctx)
@@ -53,7 +57,7 @@ func TestStartJWKSRotatorWithNoJWKSInTheStore(t *testing.T) {
 	store := newStore(c)
 	err := store.CleanupJWKS(ctx)
 	c.Assert(err, qt.IsNil)
-	svc := jimmjwx.NewJWKSService(store)
+	svc := jimmjwx.NewJWKSService(store, jwa.RS256)
 	startAndTestRotator(c, ctx, store, svc)
 }
 
@@ -69,7 +73,7 @@ func TestStartJWKSRotatorRotatesAJWKS(t *testing.T) {
 	err := store.CleanupJWKS(ctx)
 	c.Assert(err, qt.IsNil)
 
-	svc := jimmjwx.NewJWKSService(store)
+	svc := jimmjwx.NewJWKSService(store, jwa.RS256)
 
 	// So, we first put a fresh JWKS in the store
 	err = store.PutJWKS(ctx, getJWKS(c))
@@ -96,3 +100,147 @@ func TestStartJWKSRotatorRotatesAJWKS(t *testing.T) {
 		}
 	}
 }
+
+func TestRotateJWKSOverlapsKeys(t *testing.T) {
+	c := qt.New(t)
+
+	for _, alg := range []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256, jwa.ES384, jwa.EdDSA} {
+		c.Run(alg.String(), func(c *qt.C) {
+			testRotateJWKSOverlapsKeys(c, alg)
+		})
+	}
+}
+
+func testRotateJWKSOverlapsKeys(c *qt.C, alg jwa.SignatureAlgorithm) {
+	ctx := context.Background()
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	svc := jimmjwx.NewJWKSService(store, alg)
+	jwtService := jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
+		Host:   "jimm.canonical.com",
+		Store:  store,
+		Expiry: time.Minute,
+	})
+	// Cached signing keys are discarded when the keys are rotated.
+	svc.OnRotate(jwtService.InvalidateCache)
+
+	kids := func() []string {
+		set, err := store.GetJWKS(ctx)
+		c.Assert(err, qt.IsNil)
+		var kids []string
+		for i := 0; i < set.Len(); i++ {
+			key, _ := set.Key(i)
+			kids = append(kids, key.KeyID())
+		}
+		return kids
+	}
+	// signingKID returns the key ID of a newly issued JWT after checking
+	// it verifies against the stored JWKS.
+	signingKID := func() string {
+		tok, err := jwtService.NewJWT(ctx, jimmjwx.JWTParams{
+			Controller: "controller-my-diglett-controller",
+			User:       "diglett@canonical.com",
+		})
+		c.Assert(err, qt.IsNil)
+		set, err := store.GetJWKS(ctx)
+		c.Assert(err, qt.IsNil)
+		_, err = jwt.Parse(tok, jwt.WithKeySet(set))
+		c.Assert(err, qt.IsNil)
+		msg, err := jws.Parse(tok)
+		c.Assert(err, qt.IsNil)
+		headers := msg.Signatures()[0].ProtectedHeaders()
+		c.Assert(headers.Algorithm(), qt.Equals, alg)
+		return headers.KeyID()
+	}
+
+	// The initial JWKS publishes the signing key and the next key.
+	err := jimmjwx.RotateJWKS(svc, ctx, time.Now().AddDate(0, 3, 0))
+	c.Assert(err, qt.IsNil)
+	initial := kids()
+	c.Assert(initial, qt.HasLen, 2)
+	c.Check(signingKID(), qt.Equals, initial[0])
+
+	// Nothing changes until the JWKS expires.
+	err = jimmjwx.RotateJWKS(svc, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Check(kids(), qt.DeepEquals, initial)
+
+	// On expiry the next key is used for signing and the retired key is
+	// kept in the JWKS.
+	err = store.PutJWKSExpiry(ctx, time.Now().Add(-time.Minute))
+	c.Assert(err, qt.IsNil)
+	err = jimmjwx.RotateJWKS(svc, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	rotated := kids()
+	c.Assert(rotated, qt.HasLen, 3)
+	c.Check(rotated[:2], qt.DeepEquals, initial)
+	c.Check(signingKID(), qt.Equals, initial[1])
+	expiry, err := store.GetJWKSExpiry(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Check(expiry.After(time.Now().AddDate(0, 2, 0)), qt.IsTrue)
+
+	// The retired key is kept for the grace period.
+	err = jimmjwx.RotateJWKS(svc, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Check(kids(), qt.DeepEquals, rotated)
+
+	// And then removed.
+	err = store.PutJWKSExpiry(ctx, time.Now().AddDate(0, 3, 0).Add(-48*time.Hour))
+	c.Assert(err, qt.IsNil)
+	err = jimmjwx.RotateJWKS(svc, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+	c.Check(kids(), qt.DeepEquals, rotated[1:])
+	c.Check(signingKID(), qt.Equals, initial[1])
+}
+
+func TestRotateJWKSPublishesNextKeyForLegacyJWKS(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	// A JWKS stored before next keys were published has a single key,
+	// whose private key has no key ID.
+	set, privKeyPem, err := jimmjwx.GenerateJWK(ctx, jwa.RS256)
+	c.Assert(err, qt.IsNil)
+	legacy, ok := set.Key(0)
+	c.Assert(ok, qt.IsTrue)
+	block, _ := pem.Decode(privKeyPem)
+	block.Headers = nil
+	c.Assert(store.PutJWKS(ctx, set), qt.IsNil)
+	c.Assert(store.PutJWKSPrivateKey(ctx, pem.EncodeToMemory(block)), qt.IsNil)
+	c.Assert(store.PutJWKSExpiry(ctx, time.Now().AddDate(0, 1, 0)), qt.IsNil)
+
+	svc := jimmjwx.NewJWKSService(store, jwa.RS256)
+	err = jimmjwx.RotateJWKS(svc, ctx, time.Now())
+	c.Assert(err, qt.IsNil)
+
+	got, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(got.Len(), qt.Equals, 2)
+	key, _ := got.Key(0)
+	c.Check(key.KeyID(), qt.Equals, legacy.KeyID())
+}
+
+func TestForcedRotateJWKSReplacesAllKeys(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := jimmtest.NewInMemoryCredentialStore()
+	svc := jimmjwx.NewJWKSService(store, jwa.RS256)
+	err := jimmjwx.RotateJWKS(svc, ctx, time.Now().AddDate(0, 3, 0))
+	c.Assert(err, qt.IsNil)
+	before, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+
+	err = svc.RotateJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+
+	after, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	c.Assert(after.Len(), qt.Equals, 2)
+	for i := 0; i < after.Len(); i++ {
+		key, _ := after.Key(i)
+		_, found := before.LookupKeyID(key.KeyID())
+		c.Check(found, qt.IsFalse)
+	}
+}
@@ -3,20 +3,18 @@
 package jimmjwx
 
 import (
+	"bytes"
 	"context"
-	"crypto/x509"
-	"encoding/pem"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/golang-lru/v2/expirable"
 	"github.com/juju/zaputil/zapctx"
-	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"go.uber.org/zap"
 
-	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jimm/credentials"
 )
 
@@ -61,6 +59,30 @@ func (v CredentialCache) Get(ctx context.Context) (jwk.Set, error) {
 	return ks, nil
 }
 
+// Purge removes the cached JWK set, the next call to Get fetches it from
+// the credential store.
+func (v CredentialCache) Purge() {
+	v.c.Purge()
+}
+
+// signerCheckInterval is the interval at which a cached signing key is
+// checked against the private keys in the credential store. Rotations
+// performed by this JIMM replica invalidate the cache immediately.
+// Rotations performed by other replicas are noticed within this interval,
+// which matters for a forced rotation as it removes the previous signing
+// key from the JWKS immediately, and controllers reject JWTs signed by it.
+// A check only fetches the private keys, they are only parsed again if
+// they have changed. It is a variable so it can be replaced in tests.
+var signerCheckInterval = 10 * time.Second
+
+// A cachedSigner is a parsed signing key along with the private keys it
+// was parsed from.
+type cachedSigner struct {
+	key     jwk.Key
+	pemData []byte
+	checked time.Time
+}
+
 // JWTService manages the creation of JWTs that are intended to be issued
 // by JIMM.
 type JWTService struct {
@@ -68,6 +90,14 @@ type JWTService struct {
 	// JWKS is the JSON Web Key Set containing the public key used for verifying
 	// signed JWT tokens.
 	JWKS JwksGetter
+
+	// signer caches the parsed key used to sign JWTs, so that the key
+	// is not fetched from the credential store and parsed for every JWT.
+	mu     sync.Mutex
+	signer *cachedSigner
+	// generation is incremented whenever the cache is invalidated, so
+	// that a key fetched before the invalidation is not cached after it.
+	generation uint64
 }
 
 // JWTParams are the necessary params to issue a ready-to-go JWT targeted
@@ -84,61 +114,106 @@ type JWTParams struct {
 // NewJWTService returns a new JWT service for handling JIMMs JWTs.
 func NewJWTService(p JWTServiceParams) *JWTService {
 	vaultCache := NewCredentialCache(p.Store)
-	return &JWTService{JWTServiceParams: p, JWKS: vaultCache}
-}
-
-// NewJWT creates a new JWT to represent a users access within a controller.
-//
-//   - The Issuer is resolved from this function.
-//   - The JWT ID should be cached and validated on each call, where the client verifies it has not been used before.
-//     Once the JWT has expired for said ID, the client can clean up their blacklist.
-//
-// The current usecase of these JWTs is expected that NO session tokens will be generated
-// and instead, a new JWT will be issued each time containing the required claims for
-// authz.
-func (j *JWTService) NewJWT(ctx context.Context, params JWTParams) ([]byte, error) {
-	jti, err := j.generateJTI()
-	if err != nil {
-		return nil, err
+	return &JWTService{
+		JWTServiceParams: p,
+		JWKS:             vaultCache,
 	}
+}
 
-	zapctx.Debug(ctx, "issuing a new JWT", zap.Any("params", params))
+// InvalidateCache discards the cached signing key and JWK set, so that
+// they are fetched from the credential store when next needed. It should
+// be called whenever the keys are rotated, see JWKSService.OnRotate.
+func (j *JWTService) InvalidateCache() {
+	j.mu.Lock()
+	j.signer = nil
+	j.generation++
+	j.mu.Unlock()
+	j.purgeJWKS()
+}
 
-	jwkSet, err := j.JWKS.Get(ctx)
-	if err != nil {
-		return nil, err
+// purgeJWKS discards the cached JWK set, if it is cached.
+func (j *JWTService) purgeJWKS() {
+	if c, ok := j.JWKS.(CredentialCache); ok {
+		c.Purge()
 	}
+}
 
-	pubKey, ok := jwkSet.Key(jwkSet.Len() - 1)
-	if !ok {
-		zapctx.Error(ctx, "no jwk found")
-		return nil, errors.E("no jwk found")
+// signingKey returns the key JWTs are currently signed with.
+func (j *JWTService) signingKey(ctx context.Context) (jwk.Key, error) {
+	j.mu.Lock()
+	signer, generation := j.signer, j.generation
+	j.mu.Unlock()
+	if signer != nil && time.Since(signer.checked) < signerCheckInterval {
+		return signer.key, nil
 	}
+
+	now := time.Now()
 	pkeyPem, err := j.Store.GetJWKSPrivateKey(ctx)
 	if err != nil {
 		zapctx.Error(ctx, "failed to retrieve private key", zap.Error(err))
 		return nil, err
 	}
+	if signer != nil && bytes.Equal(pkeyPem, signer.pemData) {
+		j.setSigner(generation, &cachedSigner{key: signer.key, pemData: signer.pemData, checked: now})
+		return signer.key, nil
+	}
+	if signer != nil {
+		// The keys have been rotated by another replica, the cached
+		// JWK set is also out of date.
+		j.purgeJWKS()
+	}
 
-	block, _ := pem.Decode([]byte(pkeyPem))
+	jwkSet, err := j.JWKS.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	pkeyDecoded, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	// The first private key is the signing key, any other is the next
+	// signing key which is published but not yet used.
+	keys, err := decodePrivateKeys(pkeyPem, jwkSet)
 	if err != nil {
-		zapctx.Error(ctx, "failed to parse private key", zap.Error(err))
+		zapctx.Error(ctx, "failed to decode private key", zap.Error(err))
 		return nil, err
 	}
 
-	signingKey, err := jwk.FromRaw(pkeyDecoded)
+	key, err := keys[0].signingKey()
 	if err != nil {
 		zapctx.Error(ctx, "failed to create signing key", zap.Error(err))
 		return nil, err
 	}
+	j.setSigner(generation, &cachedSigner{key: key, pemData: pkeyPem, checked: now})
+	return key, nil
+}
+
+// setSigner caches the given signer, unless the cache has been
+// invalidated since the given generation.
+func (j *JWTService) setSigner(generation uint64, signer *cachedSigner) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.generation == generation {
+		j.signer = signer
+	}
+}
 
-	if err := signingKey.Set(jwk.AlgorithmKey, jwa.RS256); err != nil {
+// NewJWT creates a new JWT to represent a users access within a controller.
+//
+//   - The Issuer is resolved from this function.
+//   - The JWT ID should be cached and validated on each call, where the client verifies it has not been used before.
+//     Once the JWT has expired for said ID, the client can clean up their blacklist.
+//
+// The current usecase of these JWTs is expected that NO session tokens will be generated
+// and instead, a new JWT will be issued each time containing the required claims for
+// authz.
+func (j *JWTService) NewJWT(ctx context.Context, params JWTParams) ([]byte, error) {
+	jti, err := j.generateJTI()
+	if err != nil {
 		return nil, err
 	}
 
-	if err := signingKey.Set(jwk.KeyIDKey, pubKey.KeyID()); err != nil {
+	zapctx.Debug(ctx, "issuing a new JWT", zap.Any("params", params))
+
+	signingKey, err := j.signingKey(ctx)
+	if err != nil {
 		return nil, err
 	}
 
@@ -157,7 +232,7 @@ func (j *JWTService) NewJWT(ctx context.Context, params JWTParams) ([]byte, erro
 	freshToken, err := jwt.Sign(
 		token,
 		jwt.WithKey(
-			jwa.RS256,
+			signingKey.Algorithm(),
 			signingKey,
 		),
 	)
@@ -5,15 +5,18 @@ import (
 	"context"
 	"net/url"
 	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	qt "github.com/frankban/quicktest"
 	"github.com/lestrrat-go/iter/arrayiter"
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 
 	"github.com/canonical/jimm/v3/internal/jimmjwx"
+	"github.com/canonical/jimm/v3/internal/testutils/jimmtest"
 )
 
 func TestRegisterJWKSCacheRegistersTheCacheSuccessfully(t *testing.T) {
@@ -25,7 +28,7 @@ func TestRegisterJWKSCacheRegistersTheCacheSuccessfully(t *testing.T) {
 	_, srv, store := setupService(ctx, c)
 
 	// Setup JWKSService
-	jwksService := jimmjwx.NewJWKSService(store)
+	jwksService := jimmjwx.NewJWKSService(store, jwa.RS256)
 	// Start rotator
 	startAndTestRotator(c, ctx, store, jwksService)
 	// Setup JWTService
@@ -38,7 +41,8 @@ func TestRegisterJWKSCacheRegistersTheCacheSuccessfully(t *testing.T) {
 
 	set, err := jwtService.JWKS.Get(ctx)
 	c.Assert(err, qt.IsNil)
-	c.Assert(set.Len(), qt.Equals, 1)
+	// The JWKS holds the signing key and the next signing key.
+	c.Assert(set.Len(), qt.Equals, 2)
 }
 
 func TestNewJWTIsParsableByExponent(t *testing.T) {
@@ -57,7 +61,7 @@ func TestNewJWTIsParsableByExponent(t *testing.T) {
 	_, srv, store := setupService(ctx, c)
 
 	// Setup JWKSService
-	jwksService := jimmjwx.NewJWKSService(store)
+	jwksService := jimmjwx.NewJWKSService(store, jwa.RS256)
 	// Start rotator
 	startAndTestRotator(c, ctx, store, jwksService)
 	// Setup JWTService
@@ -118,7 +122,7 @@ func TestNewJWTExpires(t *testing.T) {
 	_, srv, store := setupService(ctx, c)
 
 	// Setup JWKSService
-	jwksService := jimmjwx.NewJWKSService(store)
+	jwksService := jimmjwx.NewJWKSService(store, jwa.RS256)
 	// Start rotator
 	startAndTestRotator(c, ctx, store, jwksService)
 	// Setup JWTService
@@ -158,7 +162,7 @@ func TestCredentialCache(t *testing.T) {
 	c := qt.New(t)
 	store := newStore(c)
 	ctx := context.Background()
-	set, _, err := jimmjwx.GenerateJWK(ctx)
+	set, _, err := jimmjwx.GenerateJWK(ctx, jwa.RS256)
 	c.Assert(err, qt.IsNil)
 	err = store.PutJWKS(ctx, set)
 	c.Assert(err, qt.IsNil)
@@ -179,3 +183,143 @@ func getKeyPairs(ctx context.Context, set jwk.Set) []*arrayiter.Pair {
 	}
 	return res
 }
+
+// countingStore counts the calls made to GetJWKSPrivateKey.
+type countingStore struct {
+	*jimmtest.InMemoryCredentialStore
+	privateKeyCalls atomic.Int64
+}
+
+func (s *countingStore) GetJWKSPrivateKey(ctx context.Context) ([]byte, error) {
+	s.privateKeyCalls.Add(1)
+	return s.InMemoryCredentialStore.GetJWKSPrivateKey(ctx)
+}
+
+func TestNewJWTCachesSigningKey(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := &countingStore{InMemoryCredentialStore: jimmtest.NewInMemoryCredentialStore()}
+	jwksService := jimmjwx.NewJWKSService(store, jwa.ES256)
+	err := jimmjwx.RotateJWKS(jwksService, ctx, time.Now().AddDate(0, 3, 0))
+	c.Assert(err, qt.IsNil)
+	jwtService := jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
+		Host:   "jimm.canonical.com",
+		Store:  store,
+		Expiry: time.Minute,
+	})
+	jwksService.OnRotate(jwtService.InvalidateCache)
+
+	params := jimmjwx.JWTParams{
+		Controller: "controller-my-diglett-controller",
+		User:       "diglett@canonical.com",
+	}
+	for i := 0; i < 3; i++ {
+		_, err := jwtService.NewJWT(ctx, params)
+		c.Assert(err, qt.IsNil)
+	}
+	c.Check(store.privateKeyCalls.Load(), qt.Equals, int64(1))
+
+	// Rotating the keys invalidates the cached signing key.
+	err = jwksService.RotateJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	tok, err := jwtService.NewJWT(ctx, params)
+	c.Assert(err, qt.IsNil)
+	c.Check(store.privateKeyCalls.Load(), qt.Equals, int64(2))
+
+	set, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	_, err = jwt.Parse(tok, jwt.WithKeySet(set))
+	c.Assert(err, qt.IsNil)
+}
+
+func TestNewJWTNoticesRotationByAnotherReplica(t *testing.T) {
+	c := qt.New(t)
+	ctx := context.Background()
+
+	store := &countingStore{InMemoryCredentialStore: jimmtest.NewInMemoryCredentialStore()}
+	jwksService := jimmjwx.NewJWKSService(store, jwa.ES256)
+	err := jimmjwx.RotateJWKS(jwksService, ctx, time.Now().AddDate(0, 3, 0))
+	c.Assert(err, qt.IsNil)
+	// The JWT service is not notified of rotations, as is the case for
+	// the other replicas of JIMM.
+	jwtService := jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
+		Host:   "jimm.canonical.com",
+		Store:  store,
+		Expiry: time.Minute,
+	})
+
+	params := jimmjwx.JWTParams{
+		Controller: "controller-my-diglett-controller",
+		User:       "diglett@canonical.com",
+	}
+	_, err = jwtService.NewJWT(ctx, params)
+	c.Assert(err, qt.IsNil)
+
+	err = jwksService.RotateJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+
+	// Until the cached signing key is next checked the previous key is
+	// still used.
+	tok, err := jwtService.NewJWT(ctx, params)
+	c.Assert(err, qt.IsNil)
+	set, err := store.GetJWKS(ctx)
+	c.Assert(err, qt.IsNil)
+	_, err = jwt.Parse(tok, jwt.WithKeySet(set))
+	c.Check(err, qt.Not(qt.IsNil))
+
+	c.Patch(jimmjwx.SignerCheckInterval, time.Duration(0))
+	tok, err = jwtService.NewJWT(ctx, params)
+	c.Assert(err, qt.IsNil)
+	_, err = jwt.Parse(tok, jwt.WithKeySet(set))
+	c.Assert(err, qt.IsNil)
+}
+
+// BenchmarkNewJWT measures the cost of issuing JWTs concurrently, as
+// happens when many clients log in to models through JIMM. The "uncached"
+// benchmarks fetch and parse the signing key for every JWT, the in-memory
+// store used here does not include the latency of a remote store such as
+// vault.
+func BenchmarkNewJWT(b *testing.B) {
+	ctx := context.Background()
+	params := jimmjwx.JWTParams{
+		Controller: "controller-my-diglett-controller",
+		User:       "diglett@canonical.com",
+		Access: map[string]string{
+			"controller": "login",
+			"model":      "write",
+		},
+	}
+	for _, alg := range []jwa.SignatureAlgorithm{jwa.RS256, jwa.ES256, jwa.ES384, jwa.EdDSA} {
+		store := jimmtest.NewInMemoryCredentialStore()
+		err := jimmjwx.RotateJWKS(jimmjwx.NewJWKSService(store, alg), ctx, time.Now().AddDate(0, 3, 0))
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, cached := range []bool{true, false} {
+			name := alg.String() + "/cached"
+			if !cached {
+				name = alg.String() + "/uncached"
+			}
+			b.Run(name, func(b *testing.B) {
+				jwtService := jimmjwx.NewJWTService(jimmjwx.JWTServiceParams{
+					Host:   "jimm.canonical.com",
+					Store:  store,
+					Expiry: time.Minute,
+				})
+				b.ReportAllocs()
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						if !cached {
+							jwtService.InvalidateCache()
+						}
+						if _, err := jwtService.NewJWT(ctx, params); err != nil {
+							b.Error(err)
+						}
+					}
+				})
+			})
+		}
+	}
+}
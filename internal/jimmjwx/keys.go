@@ -0,0 +1,229 @@
+// Copyright 2024 Canonical.
+
+package jimmjwx
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+
+	"github.com/canonical/jimm/v3/internal/errors"
+)
+
+// kidHeader is the PEM header holding the ID of the public key in the JWKS
+// corresponding to a private key.
+const kidHeader = "Key-Id"
+
+// ValidateKeyAlgorithm checks that JIMM can generate keys for the given
+// signature algorithm. The supported algorithms are RS256, using 4096 bit
+// RSA keys, ES256, ES384 and EdDSA, using Ed25519 keys.
+func ValidateKeyAlgorithm(alg jwa.SignatureAlgorithm) error {
+	switch alg {
+	case jwa.RS256, jwa.ES256, jwa.ES384, jwa.EdDSA:
+		return nil
+	default:
+		return errors.E(errors.CodeServerConfiguration, fmt.Sprintf("unsupported key algorithm %q", alg))
+	}
+}
+
+// A privateKey is a private key used to sign JWTs.
+type privateKey struct {
+	// kid is the ID of the key's public key in the JWKS.
+	kid string
+	key crypto.Signer
+}
+
+// algorithm returns the signature algorithm used with the key.
+func (k privateKey) algorithm() (jwa.SignatureAlgorithm, error) {
+	switch key := k.key.(type) {
+	case *rsa.PrivateKey:
+		return jwa.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jwa.ES256, nil
+		case elliptic.P384():
+			return jwa.ES384, nil
+		}
+		return "", errors.E(fmt.Sprintf("unsupported curve %s", key.Curve.Params().Name))
+	case ed25519.PrivateKey:
+		return jwa.EdDSA, nil
+	default:
+		return "", errors.E(fmt.Sprintf("unsupported key type %T", k.key))
+	}
+}
+
+// generateKey generates a new private key for the given signature
+// algorithm with a random key ID.
+func generateKey(alg jwa.SignatureAlgorithm) (privateKey, error) {
+	const op = errors.Op("jimmjwx.generateKey")
+
+	var key crypto.Signer
+	var err error
+	switch alg {
+	case jwa.RS256:
+		// Due to the sensitivity of controllers, it is best we allow a larger encryption bit size
+		// and accept any negligible wire cost.
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+	case jwa.ES256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case jwa.ES384:
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case jwa.EdDSA:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = ValidateKeyAlgorithm(alg)
+	}
+	if err != nil {
+		return privateKey{}, errors.E(op, err)
+	}
+
+	// We also use the same methodology of generating UUIDs for our KID
+	kid, err := uuid.NewRandom()
+	if err != nil {
+		return privateKey{}, errors.E(op, err)
+	}
+	return privateKey{kid: kid.String(), key: key}, nil
+}
+
+// signingKey returns the key as a jwk.Key ready for signing JWTs.
+func (k privateKey) signingKey() (jwk.Key, error) {
+	const op = errors.Op("jimmjwx.signingKey")
+
+	alg, err := k.algorithm()
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	key, err := jwk.FromRaw(k.key)
+	if err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := key.Set(jwk.AlgorithmKey, alg); err != nil {
+		return nil, errors.E(op, err)
+	}
+	if err := key.Set(jwk.KeyIDKey, k.kid); err != nil {
+		return nil, errors.E(op, err)
+	}
+	return key, nil
+}
+
+// newJWKS returns a jwk.Set containing the public keys of the given
+// private keys, in order.
+func newJWKS(keys ...privateKey) (jwk.Set, error) {
+	const op = errors.Op("jimmjwx.newJWKS")
+
+	ks := jwk.NewSet()
+	for _, k := range keys {
+		alg, err := k.algorithm()
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		pub, err := jwk.FromRaw(k.key.Public())
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+		err = pub.Set(jwk.KeyIDKey, k.kid)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+
+		err = pub.Set(jwk.KeyUsageKey, "sig") // Couldn't find const for this...
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+
+		err = pub.Set(jwk.AlgorithmKey, alg)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+
+		err = ks.AddKey(pub)
+		if err != nil {
+			return nil, errors.E(op, err)
+		}
+	}
+	return ks, nil
+}
+
+// encodePrivateKeys PEM encodes the given private keys, recording the key
+// ID of each in its block's headers. RSA keys are encoded in PKCS #1 form,
+// as they always have been, other keys in PKCS #8 form.
+func encodePrivateKeys(keys ...privateKey) ([]byte, error) {
+	const op = errors.Op("jimmjwx.encodePrivateKeys")
+
+	var buf []byte
+	for _, k := range keys {
+		block := pem.Block{
+			Headers: map[string]string{kidHeader: k.kid},
+		}
+		if key, ok := k.key.(*rsa.PrivateKey); ok {
+			block.Type = "RSA PRIVATE KEY"
+			block.Bytes = x509.MarshalPKCS1PrivateKey(key)
+		} else {
+			der, err := x509.MarshalPKCS8PrivateKey(k.key)
+			if err != nil {
+				return nil, errors.E(op, err)
+			}
+			block.Type = "PRIVATE KEY"
+			block.Bytes = der
+		}
+		buf = append(buf, pem.EncodeToMemory(&block)...)
+	}
+	return buf, nil
+}
+
+// decodePrivateKeys decodes the PEM encoded private keys in data. Keys
+// stored before key IDs were recorded hold a single key without a key ID,
+// that key corresponds to the last key in the given JWKS.
+func decodePrivateKeys(data []byte, set jwk.Set) ([]privateKey, error) {
+	const op = errors.Op("jimmjwx.decodePrivateKeys")
+
+	var keys []privateKey
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		var key any
+		var err error
+		switch block.Type {
+		case "RSA PRIVATE KEY":
+			key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "PRIVATE KEY":
+			key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+		default:
+			err = errors.E(fmt.Sprintf("unexpected PEM block type %q", block.Type))
+		}
+		if err != nil {
+			return nil, errors.E(op, err, "failed to parse private key")
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.E(op, fmt.Sprintf("unsupported key type %T", key))
+		}
+		kid := block.Headers[kidHeader]
+		if kid == "" {
+			pub, ok := set.Key(set.Len() - 1)
+			if !ok {
+				return nil, errors.E(op, "no jwk found")
+			}
+			kid = pub.KeyID()
+		}
+		keys = append(keys, privateKey{kid: kid, key: signer})
+	}
+	if len(keys) == 0 {
+		return nil, errors.E(op, "no private key found")
+	}
+	return keys, nil
+}
@@ -35,6 +35,16 @@ type GroupService interface {
 	RemoveGroup(ctx context.Context, user *openfga.User, name string) error
 }
 
+type RoleService interface {
+	AddRole(ctx context.Context, user *openfga.User, name string) (*dbmodel.RoleEntry, error)
+	CountRoles(ctx context.Context, user *openfga.User) (int, error)
+	GetRoleByUUID(ctx context.Context, user *openfga.User, uuid string) (*dbmodel.RoleEntry, error)
+	GetRoleByName(ctx context.Context, user *openfga.User, name string) (*dbmodel.RoleEntry, error)
+	ListRoles(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]dbmodel.RoleEntry, error)
+	RenameRole(ctx context.Context, user *openfga.User, oldName, newName string) error
+	RemoveRole(ctx context.Context, user *openfga.User, name string) error
+}
+
 // AddGroup creates a group within JIMMs DB for reference by OpenFGA.
 func (r *controllerRoot) AddGroup(ctx context.Context, req apiparams.AddGroupRequest) (apiparams.AddGroupResponse, error) {
 	const op = errors.Op("jujuapi.AddGroup")
@@ -81,10 +91,11 @@ func (r *controllerRoot) GetGroup(ctx context.Context, req apiparams.GetGroupReq
 	}
 
 	return apiparams.Group{
-		UUID:      groupEntry.UUID,
-		Name:      groupEntry.Name,
-		CreatedAt: groupEntry.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: groupEntry.UpdatedAt.Format(time.RFC3339),
+		UUID:              groupEntry.UUID,
+		Name:              groupEntry.Name,
+		CreatedAt:         groupEntry.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         groupEntry.UpdatedAt.Format(time.RFC3339),
+		ExternallyManaged: groupEntry.ExternallyManaged,
 	}, nil
 }
 
@@ -126,16 +137,99 @@ func (r *controllerRoot) ListGroups(ctx context.Context, req apiparams.ListGroup
 	groupsResponse := make([]apiparams.Group, len(groups))
 	for i, g := range groups {
 		groupsResponse[i] = apiparams.Group{
-			UUID:      g.UUID,
-			Name:      g.Name,
-			CreatedAt: g.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: g.UpdatedAt.Format(time.RFC3339),
+			UUID:              g.UUID,
+			Name:              g.Name,
+			CreatedAt:         g.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:         g.UpdatedAt.Format(time.RFC3339),
+			ExternallyManaged: g.ExternallyManaged,
 		}
 	}
 
 	return apiparams.ListGroupResponse{Groups: groupsResponse}, nil
 }
 
+// AddRole creates a role within JIMMs DB for reference by OpenFGA.
+func (r *controllerRoot) AddRole(ctx context.Context, req apiparams.AddRoleRequest) (apiparams.AddRoleResponse, error) {
+	const op = errors.Op("jujuapi.AddRole")
+
+	if !jimmnames.IsValidRoleName(req.Name) {
+		return apiparams.AddRoleResponse{}, errors.E(op, errors.CodeBadRequest, "invalid role name")
+	}
+
+	roleEntry, err := r.jimm.AddRole(ctx, r.user, req.Name)
+	if err != nil {
+		zapctx.Error(ctx, "failed to add role", zaputil.Error(err))
+		return apiparams.AddRoleResponse{}, errors.E(op, err)
+	}
+	return apiparams.AddRoleResponse{Role: roleEntry.ToAPIRoleEntry()}, nil
+}
+
+// GetRole returns role information based on a UUID or name.
+func (r *controllerRoot) GetRole(ctx context.Context, req apiparams.GetRoleRequest) (apiparams.Role, error) {
+	const op = errors.Op("jujuapi.GetRole")
+
+	var roleEntry *dbmodel.RoleEntry
+	var err error
+	switch {
+	case req.UUID != "" && req.Name != "":
+		return apiparams.Role{}, errors.E(op, errors.CodeBadRequest, "only one of UUID or Name should be provided")
+	case req.UUID != "":
+		roleEntry, err = r.jimm.GetRoleByUUID(ctx, r.user, req.UUID)
+	case req.Name != "":
+		roleEntry, err = r.jimm.GetRoleByName(ctx, r.user, req.Name)
+	default:
+		return apiparams.Role{}, errors.E(op, errors.CodeBadRequest, "no UUID or Name provided")
+	}
+	if err != nil {
+		zapctx.Error(ctx, "failed to get role", zaputil.Error(err))
+		return apiparams.Role{}, errors.E(op, err)
+	}
+	return roleEntry.ToAPIRoleEntry(), nil
+}
+
+// RenameRole renames a role within JIMMs DB for reference by OpenFGA.
+func (r *controllerRoot) RenameRole(ctx context.Context, req apiparams.RenameRoleRequest) error {
+	const op = errors.Op("jujuapi.RenameRole")
+
+	if !jimmnames.IsValidRoleName(req.NewName) {
+		return errors.E(op, errors.CodeBadRequest, "invalid role name")
+	}
+
+	if err := r.jimm.RenameRole(ctx, r.user, req.Name, req.NewName); err != nil {
+		zapctx.Error(ctx, "failed to rename role", zaputil.Error(err))
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// RemoveRole removes a role within JIMMs DB for reference by OpenFGA.
+func (r *controllerRoot) RemoveRole(ctx context.Context, req apiparams.RemoveRoleRequest) error {
+	const op = errors.Op("jujuapi.RemoveRole")
+
+	if err := r.jimm.RemoveRole(ctx, r.user, req.Name); err != nil {
+		zapctx.Error(ctx, "failed to remove role", zaputil.Error(err))
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// ListRoles lists roles within JIMMs DB.
+func (r *controllerRoot) ListRoles(ctx context.Context, req apiparams.ListRolesRequest) (apiparams.ListRolesResponse, error) {
+	const op = errors.Op("jujuapi.ListRoles")
+
+	filter := pagination.NewOffsetFilter(req.Limit, req.Offset)
+	roles, err := r.jimm.ListRoles(ctx, r.user, filter)
+	if err != nil {
+		return apiparams.ListRolesResponse{}, errors.E(op, err)
+	}
+	rolesResponse := make([]apiparams.Role, len(roles))
+	for i, role := range roles {
+		rolesResponse[i] = role.ToAPIRoleEntry()
+	}
+
+	return apiparams.ListRolesResponse{Roles: rolesResponse}, nil
+}
+
 // AddRelation creates a tuple between two objects [if applicable]
 // within OpenFGA.
 func (r *controllerRoot) AddRelation(ctx context.Context, req apiparams.AddRelationRequest) error {
@@ -22,9 +22,9 @@ type LoginService interface {
 	// AuthenticateBrowserSession authenticates a session cookie is valid.
 	AuthenticateBrowserSession(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, error)
 	// LoginDevice is step 1 in the device flow and returns the OIDC server that the client should use for login.
-	LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error)
+	LoginDevice(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error)
 	// GetDeviceSessionToken polls the OIDC server waiting for the client to login and return a user scoped session token.
-	GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
+	GetDeviceSessionToken(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
 	// LoginWithClientCredentials verifies a user by their client credentials.
 	LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error)
 	// LoginWithSessionToken verifies a user based on their session token.
@@ -49,11 +49,14 @@ var facadeInit = make(map[string]func(r *controllerRoot) []int)
 //
 // Upon successful login, the user is then expected to retrieve an access token using
 // GetDeviceAccessToken.
-func (r *controllerRoot) LoginDevice(ctx context.Context) (params.LoginDeviceResponse, error) {
+//
+// The client may choose the identity provider to log in with, otherwise the
+// default identity provider is used.
+func (r *controllerRoot) LoginDevice(ctx context.Context, req params.LoginDeviceRequest) (params.LoginDeviceResponse, error) {
 	const op = errors.Op("jujuapi.LoginDevice")
 	response := params.LoginDeviceResponse{}
 
-	deviceResponse, err := r.jimm.LoginDevice(ctx)
+	deviceResponse, err := r.jimm.LoginDevice(ctx, req.Provider)
 	if err != nil {
 		return response, errors.E(op, err, errors.CodeUnauthorized)
 	}
@@ -61,6 +64,7 @@ func (r *controllerRoot) LoginDevice(ctx context.Context) (params.LoginDeviceRes
 	// is created per WS, it is EXPECTED that the subsequent call to GetDeviceSessionToken
 	// happens on the SAME websocket.
 	r.deviceOAuthResponse = deviceResponse
+	r.deviceProvider = req.Provider
 
 	response.UserCode = deviceResponse.UserCode
 	response.VerificationURI = deviceResponse.VerificationURI
@@ -77,7 +81,7 @@ func (r *controllerRoot) GetDeviceSessionToken(ctx context.Context) (params.GetD
 	const op = errors.Op("jujuapi.GetDeviceSessionToken")
 	response := params.GetDeviceSessionTokenResponse{}
 
-	token, err := r.jimm.GetDeviceSessionToken(ctx, r.deviceOAuthResponse)
+	token, err := r.jimm.GetDeviceSessionToken(ctx, r.deviceProvider, r.deviceOAuthResponse)
 	if err != nil {
 		return response, errors.E(op, err, errors.CodeUnauthorized)
 	}
@@ -100,9 +104,7 @@ func (r *controllerRoot) LoginWithSessionCookie(ctx context.Context) (jujuparams
 		return jujuparams.LoginResult{}, errors.E(op, err, errors.CodeUnauthorized)
 	}
 
-	r.mu.Lock()
-	r.user = user
-	r.mu.Unlock()
+	r.setUser(user)
 
 	// Get server version for LoginResult
 	srvVersion, err := r.jimm.EarliestControllerVersion(ctx)
@@ -133,11 +135,7 @@ func (r *controllerRoot) LoginWithSessionToken(ctx context.Context, req params.L
 		return jujuparams.LoginResult{}, errors.E(op, err)
 	}
 
-	// TODO(ale8k): This isn't needed I don't think as controller roots are unique
-	// per WS, but if anyone knows different please let me know.
-	r.mu.Lock()
-	r.user = user
-	r.mu.Unlock()
+	r.setUser(user)
 
 	// Get server version for LoginResult
 	srvVersion, err := r.jimm.EarliestControllerVersion(ctx)
@@ -164,9 +162,7 @@ func (r *controllerRoot) LoginWithClientCredentials(ctx context.Context, req par
 		return jujuparams.LoginResult{}, errors.E(err, errors.CodeUnauthorized)
 	}
 
-	r.mu.Lock()
-	r.user = user
-	r.mu.Unlock()
+	r.setUser(user)
 
 	// Get server version for LoginResult
 	srvVersion, err := r.jimm.EarliestControllerVersion(ctx)
@@ -0,0 +1,237 @@
+// Copyright 2024 Canonical.
+
+package jujuapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/juju/zaputil"
+	"github.com/juju/zaputil/zapctx"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	apiparams "github.com/canonical/jimm/v3/pkg/api/params"
+)
+
+func init() {
+	facadeInit["AuditEventWatcher"] = func(r *controllerRoot) []int {
+		nextMethod := rpc.Method(r.AuditEventWatcherNext)
+		stopMethod := rpc.Method(r.AuditEventWatcherStop)
+
+		r.AddMethod("AuditEventWatcher", 1, "Next", nextMethod)
+		r.AddMethod("AuditEventWatcher", 1, "Stop", stopMethod)
+
+		return []int{1}
+	}
+}
+
+// AuditEventWatcherNext implements the Next method on the
+// AuditEventWatcher facade. It blocks until new audit events matching
+// the watcher's filter are available and then returns them.
+func (r *controllerRoot) AuditEventWatcherNext(ctx context.Context, objID string) (apiparams.AuditEvents, error) {
+	const op = errors.Op("jujuapi.AuditEventWatcherNext")
+
+	w, err := r.auditWatchers.get(objID)
+	if err != nil {
+		return apiparams.AuditEvents{}, errors.E(op, err)
+	}
+	events, err := w.Next(ctx)
+	if err != nil {
+		return apiparams.AuditEvents{}, errors.E(op, err)
+	}
+	return events, nil
+}
+
+// AuditEventWatcherStop implements the Stop method on the
+// AuditEventWatcher facade.
+func (r *controllerRoot) AuditEventWatcherStop(ctx context.Context, objID string) error {
+	const op = errors.Op("jujuapi.AuditEventWatcherStop")
+
+	w, err := r.auditWatchers.remove(objID)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	return w.Stop()
+}
+
+var (
+	// defaultAuditEventWatcherPeriod is the period with which an audit
+	// event watcher polls the audit log for new entries.
+	defaultAuditEventWatcherPeriod = time.Second
+
+	// defaultAuditEventWatcherWindow is the time for which an audit
+	// event watcher re-reads the audit log below the ID of an entry it
+	// has reported. Audit log IDs are allocated before the entries are
+	// committed, so an entry with a lower ID may become visible after
+	// one with a higher ID has been read.
+	defaultAuditEventWatcherWindow = 30 * time.Second
+)
+
+type auditEventWatcherRegistry struct {
+	mu       sync.RWMutex
+	watchers map[string]*auditEventWatcher
+}
+
+func (r *auditEventWatcherRegistry) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, w := range r.watchers {
+		err := w.Stop()
+		if err != nil {
+			zapctx.Error(context.Background(), "failed to stop an audit event watcher", zaputil.Error(err))
+		}
+	}
+	r.watchers = nil
+}
+
+func (r *auditEventWatcherRegistry) register(w *auditEventWatcher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.watchers == nil {
+		r.watchers = make(map[string]*auditEventWatcher)
+	}
+	r.watchers[w.id] = w
+}
+
+func (r *auditEventWatcherRegistry) get(id string) (*auditEventWatcher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	w, ok := r.watchers[id]
+	if !ok {
+		return nil, errors.E(errors.CodeNotFound)
+	}
+	return w, nil
+}
+
+func (r *auditEventWatcherRegistry) remove(id string) (*auditEventWatcher, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.watchers[id]
+	if !ok {
+		return nil, errors.E(errors.CodeNotFound)
+	}
+	delete(r.watchers, id)
+	return w, nil
+}
+
+// newAuditEventWatcher returns a watcher that reports audit log entries,
+// matching the given filter, that are added after lastID. The findEvents
+// function is called for every poll of the audit log so that any access
+// checks are reevaluated for each batch of events. Each poll re-reads the
+// entries reported within the watcher's window so that entries committed
+// out of ID order are not missed, reported entries are not reported again.
+func newAuditEventWatcher(id string, filter db.AuditLogFilter, lastID uint, findEvents func(context.Context, db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)) *auditEventWatcher {
+	filter.Start = time.Time{}
+	filter.End = time.Time{}
+	filter.Offset = 0
+	filter.SortTime = false
+	filter.SortID = true
+	return &auditEventWatcher{
+		id:         id,
+		filter:     filter,
+		findEvents: findEvents,
+		period:     defaultAuditEventWatcherPeriod,
+		window:     defaultAuditEventWatcherWindow,
+		floorID:    lastID,
+		seen:       make(map[uint]time.Time),
+		done:       make(chan struct{}),
+	}
+}
+
+type auditEventWatcher struct {
+	id         string
+	filter     db.AuditLogFilter
+	findEvents func(context.Context, db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
+	period     time.Duration
+	window     time.Duration
+
+	stopOnce sync.Once
+	done     chan struct{}
+
+	// mu serialises calls to Next and protects floorID and seen.
+	mu sync.Mutex
+	// floorID is the ID at or below which no more entries are expected.
+	floorID uint
+	// seen holds the time each reported entry with an ID above floorID
+	// was first read.
+	seen map[uint]time.Time
+}
+
+// Next waits until there are audit log entries newer than those
+// previously returned and returns them. Next returns an error with
+// the code CodeStopped if the watcher is stopped while waiting.
+func (w *auditEventWatcher) Next(ctx context.Context) (apiparams.AuditEvents, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for {
+		select {
+		case <-w.done:
+			return apiparams.AuditEvents{}, errors.E(errors.CodeStopped, "watcher stopped")
+		default:
+		}
+
+		filter := w.filter
+		filter.AfterID = w.floorID
+		entries, err := w.findEvents(ctx, filter)
+		if err != nil {
+			return apiparams.AuditEvents{}, err
+		}
+		now := time.Now()
+		var events []apiparams.AuditEvent
+		for _, ent := range entries {
+			if _, ok := w.seen[ent.ID]; ok {
+				continue
+			}
+			w.seen[ent.ID] = now
+			events = append(events, ent.ToAPIAuditEvent())
+		}
+		w.advance(now)
+		if len(events) > 0 {
+			return apiparams.AuditEvents{
+				Events: events,
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return apiparams.AuditEvents{}, ctx.Err()
+		case <-w.done:
+			return apiparams.AuditEvents{}, errors.E(errors.CodeStopped, "watcher stopped")
+		case <-time.After(w.period):
+		}
+	}
+}
+
+// advance raises floorID to the highest ID of the entries first read
+// longer than the watcher's window ago, after which entries with lower IDs
+// are no longer expected to be committed, and forgets the entries at or
+// below it.
+func (w *auditEventWatcher) advance(now time.Time) {
+	for id, t := range w.seen {
+		if id > w.floorID && now.Sub(t) >= w.window {
+			w.floorID = id
+		}
+	}
+	for id := range w.seen {
+		if id <= w.floorID {
+			delete(w.seen, id)
+		}
+	}
+}
+
+// Stop stops the watcher, any pending calls to Next will return.
+func (w *auditEventWatcher) Stop() error {
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
+	return nil
+}
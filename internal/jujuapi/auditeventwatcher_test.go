@@ -0,0 +1,262 @@
+// Copyright 2024 Canonical.
+
+package jujuapi_test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/juju/rpc"
+	"github.com/juju/names/v5"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
+	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
+	"github.com/canonical/jimm/v3/internal/jujuapi"
+)
+
+type auditEventWatcherSuite struct{}
+
+var _ = gc.Suite(&auditEventWatcherSuite{})
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcher(c *gc.C) {
+	log := &testAuditLog{}
+	log.add(dbmodel.AuditLogEntry{FacadeMethod: "Old"})
+
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{Method: "Deploy", SortTime: true, Offset: 5}, 1, 10*time.Millisecond, time.Hour, log.find)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		log.add(
+			dbmodel.AuditLogEntry{FacadeMethod: "Deploy"},
+			dbmodel.AuditLogEntry{FacadeMethod: "Status"},
+			dbmodel.AuditLogEntry{FacadeMethod: "Deploy"},
+		)
+	}()
+
+	events, err := watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 2)
+	c.Check(events.Events[0].FacadeMethod, gc.Equals, "Deploy")
+	c.Check(events.Events[1].FacadeMethod, gc.Equals, "Deploy")
+
+	log.add(dbmodel.AuditLogEntry{FacadeMethod: "Deploy"})
+	events, err = watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 1)
+
+	// Entries reported within the window are re-read.
+	filters := log.filters()
+	for _, f := range filters {
+		c.Check(f.AfterID, gc.Equals, uint(1))
+		c.Check(f.Method, gc.Equals, "Deploy")
+		c.Check(f.SortID, jc.IsTrue)
+		c.Check(f.SortTime, jc.IsFalse)
+		c.Check(f.Offset, gc.Equals, 0)
+	}
+}
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcherLateCommit(c *gc.C) {
+	log := &testAuditLog{}
+	log.insert(dbmodel.AuditLogEntry{ID: 1, FacadeMethod: "Old"})
+
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{}, 1, 10*time.Millisecond, time.Hour, log.find)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+
+	// Entry 2 is committed after entry 3 has been read.
+	log.insert(dbmodel.AuditLogEntry{ID: 3, FacadeMethod: "Deploy"})
+	events, err := watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 1)
+	c.Check(events.Events[0].FacadeMethod, gc.Equals, "Deploy")
+
+	log.insert(dbmodel.AuditLogEntry{ID: 2, FacadeMethod: "Status"})
+	events, err = watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 1)
+	c.Check(events.Events[0].FacadeMethod, gc.Equals, "Status")
+
+	// Nothing is reported twice.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = watcher.Next(ctx)
+	c.Check(err, gc.Equals, context.DeadlineExceeded)
+}
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcherWindowExpires(c *gc.C) {
+	log := &testAuditLog{}
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{}, 0, 10*time.Millisecond, 20*time.Millisecond, log.find)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+
+	log.add(dbmodel.AuditLogEntry{FacadeMethod: "Deploy"}, dbmodel.AuditLogEntry{FacadeMethod: "Status"})
+	events, err := watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 2)
+
+	time.Sleep(30 * time.Millisecond)
+	log.add(dbmodel.AuditLogEntry{FacadeMethod: "Destroy"})
+	events, err = watcher.Next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events.Events, gc.HasLen, 1)
+	c.Check(events.Events[0].FacadeMethod, gc.Equals, "Destroy")
+
+	// Once the window has passed the reported entries are no longer
+	// re-read.
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Millisecond)
+	defer cancel()
+	_, err = watcher.Next(ctx)
+	c.Check(err, gc.Equals, context.DeadlineExceeded)
+	filters := log.filters()
+	c.Check(filters[len(filters)-1].AfterID, gc.Equals, uint(2))
+}
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcherStop(c *gc.C) {
+	log := &testAuditLog{}
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{}, 0, 10*time.Millisecond, time.Hour, log.find)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		err := watcher.Stop()
+		c.Check(err, gc.IsNil)
+	}()
+
+	_, err := watcher.Next(context.Background())
+	c.Assert(errors.ErrorCode(err), gc.Equals, errors.CodeStopped)
+
+	// Stopping a watcher more than once is not an error.
+	err = watcher.Stop()
+	c.Assert(err, gc.IsNil)
+}
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcherError(c *gc.C) {
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{}, 0, 10*time.Millisecond, time.Hour, func(context.Context, db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error) {
+		return nil, errors.E(errors.CodeUnauthorized, "unauthorized")
+	})
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+
+	_, err := watcher.Next(context.Background())
+	c.Assert(err, gc.ErrorMatches, "unauthorized")
+	c.Assert(errors.ErrorCode(err), gc.Equals, errors.CodeUnauthorized)
+}
+
+func (s *auditEventWatcherSuite) TestAuditEventWatcherDoesNotReportItself(c *gc.C) {
+	log := &testAuditLog{}
+	logger := jimm.NewDbAuditLogger(log, func() names.UserTag {
+		return names.NewUserTag("alice@canonical.com")
+	})
+
+	watcher := jujuapi.NewAuditEventWatcher(db.AuditLogFilter{}, 0, 10*time.Millisecond, time.Hour, log.find)
+	defer func() {
+		err := watcher.Stop()
+		c.Assert(err, gc.IsNil)
+	}()
+
+	// next calls Next on the watcher recording the request and response
+	// in the same way as the RPC server.
+	var requestID uint64
+	next := func(ctx context.Context) ([]string, error) {
+		requestID++
+		req := rpc.Request{Type: "AuditEventWatcher", Version: 1, Id: "test", Action: "Next"}
+		hdr := rpc.Header{RequestId: requestID, Request: req}
+		recorder := jimm.NewRecorder(logger)
+		err := recorder.HandleRequest(&hdr, nil)
+		c.Assert(err, gc.IsNil)
+		events, err := watcher.Next(ctx)
+		err1 := recorder.HandleReply(req, &hdr, events)
+		c.Assert(err1, gc.IsNil)
+		var methods []string
+		for _, ev := range events.Events {
+			methods = append(methods, ev.FacadeMethod)
+		}
+		return methods, err
+	}
+
+	err := jimm.NewRecorder(logger).HandleRequest(&rpc.Header{
+		RequestId: 1,
+		Request:   rpc.Request{Type: "ModelManager", Version: 9, Action: "CreateModel"},
+	}, nil)
+	c.Assert(err, gc.IsNil)
+
+	methods, err := next(context.Background())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(methods, jc.DeepEquals, []string{"CreateModel"})
+
+	// The watcher's own requests are not in the audit log so there is
+	// nothing more to report.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = next(ctx)
+	c.Check(err, gc.Equals, context.DeadlineExceeded)
+}
+
+// testAuditLog is an in-memory audit log that implements the subset of
+// filtering used by the audit event watcher.
+type testAuditLog struct {
+	mu      sync.Mutex
+	entries []dbmodel.AuditLogEntry
+	calls   []db.AuditLogFilter
+}
+
+func (l *testAuditLog) add(ales ...dbmodel.AuditLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, ale := range ales {
+		ale.ID = uint(len(l.entries) + 1)
+		l.entries = append(l.entries, ale)
+	}
+}
+
+// insert adds an entry with a preset ID, keeping the entries sorted by
+// ID.
+func (l *testAuditLog) insert(ale dbmodel.AuditLogEntry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, ale)
+	sort.Slice(l.entries, func(i, j int) bool { return l.entries[i].ID < l.entries[j].ID })
+}
+
+// AddAuditLogEntry implements jimm.AuditLoggerBackend.
+func (l *testAuditLog) AddAuditLogEntry(ale *dbmodel.AuditLogEntry) {
+	l.add(*ale)
+}
+
+func (l *testAuditLog) filters() []db.AuditLogFilter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]db.AuditLogFilter(nil), l.calls...)
+}
+
+func (l *testAuditLog) find(_ context.Context, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, filter)
+	var entries []dbmodel.AuditLogEntry
+	for _, ale := range l.entries {
+		if ale.ID <= filter.AfterID {
+			continue
+		}
+		if filter.Method != "" && ale.FacadeMethod != filter.Method {
+			continue
+		}
+		entries = append(entries, ale)
+	}
+	return entries, nil
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	jimmversion "github.com/canonical/jimm/v3/version"
@@ -54,13 +55,18 @@ func init() {
 // ControllerService defines the methods used to manage controllers.
 type ControllerService interface {
 	AddController(ctx context.Context, user *openfga.User, ctl *dbmodel.Controller) error
+	ControllerDrainStatus(ctx context.Context, user *openfga.User, controllerName string) (*jimm.ControllerDrain, error)
 	ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error)
+	DrainController(ctx context.Context, user *openfga.User, controllerName string, args jimm.DrainControllerArgs) (*jimm.ControllerDrain, error)
 	EarliestControllerVersion(ctx context.Context) (version.Number, error)
 	ListControllers(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
 	GetControllerConfig(ctx context.Context, user *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	SetControllerConfig(ctx context.Context, user *openfga.User, args jujuparams.ControllerConfigSet) error
 	RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	SetControllerDeprecated(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	SetControllerDrainState(ctx context.Context, user *openfga.User, controllerName string, state string) (*jimm.ControllerDrain, error)
+	SetControllerMaxModels(ctx context.Context, user *openfga.User, controllerName string, maxModels uint) error
+	SetPlacementPolicy(ctx context.Context, user *openfga.User, name string) error
 }
 
 // ConfigSet changes the value of specified controller configuration
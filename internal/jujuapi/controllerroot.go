@@ -29,6 +29,7 @@ import (
 
 type JIMM interface {
 	GroupService
+	RoleService
 	RelationService
 	ControllerService
 	LoginService
@@ -40,8 +41,14 @@ type JIMM interface {
 	CopyServiceAccountCredential(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
 	CountIdentities(ctx context.Context, user *openfga.User) (int, error)
 	DestroyOffer(ctx context.Context, user *openfga.User, offerURL string, force bool) error
+	DisableIdentity(ctx context.Context, user *openfga.User, identityName string) error
+	EnableIdentity(ctx context.Context, user *openfga.User, identityName string) error
 	FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	FindApplications(ctx context.Context, user *openfga.User, filter db.ModelApplicationFilter) ([]dbmodel.ModelApplication, error)
 	FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
+	FindCorrelatedAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.CorrelatedAuditLogEntry, error)
+	FindMachines(ctx context.Context, user *openfga.User, filter db.ModelMachineFilter) ([]dbmodel.ModelMachine, error)
+	FindUnits(ctx context.Context, user *openfga.User, filter db.ModelUnitFilter) ([]dbmodel.ModelUnit, error)
 	ForEachCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
 	ForEachUserCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
 	ForEachUserCloudCredential(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error
@@ -66,19 +73,27 @@ type JIMM interface {
 	InitiateMigration(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
 	ListIdentities(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination) ([]openfga.User, error)
+	ListMigrations(ctx context.Context, user *openfga.User, modelTag *names.ModelTag, active bool) ([]dbmodel.ModelMigration, error)
 	ListResources(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error)
+	ListServiceAccountAdministrators(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) ([]string, error)
+	ListServiceAccounts(ctx context.Context, u *openfga.User) ([]string, error)
+	MigrationStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag) (*dbmodel.ModelMigration, error)
 	Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	PubSubHub() *pubsub.Hub
 	PurgeLogs(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
+	RegisterSession(identityName string, closeF func()) (unregister func())
 	RemoveCloud(ctx context.Context, u *openfga.User, ct names.CloudTag) error
 	RemoveCloudFromController(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
 	RemoveController(ctx context.Context, user *openfga.User, controllerName string, force bool) error
+	RemoveIdentity(ctx context.Context, user *openfga.User, identityName string) error
+	RemoveServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error
 	ResourceTag() names.ControllerTag
 	RevokeAuditLogAccess(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	RevokeCloudAccess(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
 	RevokeCloudCredential(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
 	RevokeModelAccess(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	RotateJWKS(ctx context.Context, user *openfga.User) error
 	ToJAASTag(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
 	UpdateApplicationOffer(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
 	UpdateCloud(ctx context.Context, u *openfga.User, ct names.CloudTag, cloud jujuparams.Cloud) error
@@ -90,10 +105,11 @@ type JIMM interface {
 type controllerRoot struct {
 	rpc.Root
 
-	params   Params
-	jimm     JIMM
-	watchers *watcherRegistry
-	pingF    func()
+	params        Params
+	jimm          JIMM
+	watchers      *watcherRegistry
+	auditWatchers *auditEventWatcherRegistry
+	pingF         func()
 
 	// mu protects the fields below it
 	mu                    sync.Mutex
@@ -110,8 +126,19 @@ type controllerRoot struct {
 	// happens on the SAME websocket.
 	deviceOAuthResponse *oauth2.DeviceAuthResponse
 
+	// deviceProvider holds the name of the identity provider the device
+	// code flow was started with.
+	deviceProvider string
+
 	// identityId is the id of the identity attempting to login via a session cookie.
 	identityId string
+
+	// closeF closes the connection this root is served on.
+	closeF func()
+
+	// unregisterSession removes the registration of the connection
+	// for the logged in user, see JIMM.RegisterSession.
+	unregisterSession func()
 }
 
 func newControllerRoot(j JIMM, p Params, identityId string) *controllerRoot {
@@ -122,6 +149,7 @@ func newControllerRoot(j JIMM, p Params, identityId string) *controllerRoot {
 		params:                p,
 		jimm:                  j,
 		watchers:              watcherRegistry,
+		auditWatchers:         &auditEventWatcherRegistry{},
 		pingF:                 func() {},
 		controllerUUIDMasking: true,
 		identityId:            identityId,
@@ -182,9 +210,45 @@ func (r *controllerRoot) setPingF(f func()) {
 	r.pingF = f
 }
 
+// setCloseF configures the function to call to close the connection
+// the root is served on.
+func (r *controllerRoot) setCloseF(f func()) {
+	r.closeF = f
+}
+
+// setUser sets the authenticated user of the connection and registers
+// the connection as a session of that user, so that it is closed if the
+// user is disabled.
+func (r *controllerRoot) setUser(user *openfga.User) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.user = user
+	if r.unregisterSession != nil {
+		r.unregisterSession()
+		r.unregisterSession = nil
+	}
+	if r.closeF != nil {
+		r.unregisterSession = r.jimm.RegisterSession(user.Name, r.closeF)
+	}
+}
+
+// endSession removes the registration of the connection as a session of
+// the logged in user. It should be called once the connection has closed.
+func (r *controllerRoot) endSession() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.unregisterSession != nil {
+		r.unregisterSession()
+		r.unregisterSession = nil
+	}
+}
+
 // cleanup releases all resources used by the controllerRoot.
 func (r *controllerRoot) cleanup() {
 	r.watchers.stop()
+	r.auditWatchers.stop()
 }
 
 func (r *controllerRoot) setupUUIDGenerator() error {
@@ -4,10 +4,12 @@ package jujuapi
 
 import (
 	"context"
+	"time"
 
 	jujuparams "github.com/juju/juju/rpc/params"
 
 	"github.com/canonical/jimm/v3/internal/db"
+	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
@@ -59,3 +61,10 @@ var SetUser = func(r *controllerRoot, u *openfga.User) {
 	r.user = u
 	r.mu.Unlock()
 }
+
+func NewAuditEventWatcher(filter db.AuditLogFilter, lastID uint, period, window time.Duration, findEvents func(context.Context, db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)) *auditEventWatcher {
+	w := newAuditEventWatcher("test", filter, lastID, findEvents)
+	w.period = period
+	w.window = window
+	return w
+}
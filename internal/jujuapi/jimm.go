@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sort"
 	"strings"
 	"time"
 
@@ -18,6 +19,7 @@ import (
 	"github.com/canonical/jimm/v3/internal/db"
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
 	ofganames "github.com/canonical/jimm/v3/internal/openfga/names"
 	"github.com/canonical/jimm/v3/pkg/api/params"
@@ -29,13 +31,19 @@ func init() {
 	facadeInit["JIMM"] = func(r *controllerRoot) []int {
 		addControllerMethod := rpc.Method(r.AddController)
 		disableControllerUUIDMaskingMethod := rpc.Method(r.DisableControllerUUIDMasking)
+		disableIdentityMethod := rpc.Method(r.DisableIdentity)
+		enableIdentityMethod := rpc.Method(r.EnableIdentity)
 		findAuditEventsMethod := rpc.Method(r.FindAuditEvents)
+		watchAuditEventsMethod := rpc.Method(r.WatchAuditEvents)
 		grantAuditLogAccessMethod := rpc.Method(r.GrantAuditLogAccess)
 		importModelMethod := rpc.Method(r.ImportModel)
 		listControllersMethod := rpc.Method(r.ListControllers)
 		removeControllerMethod := rpc.Method(r.RemoveController)
 		revokeAuditLogAccessMethod := rpc.Method(r.RevokeAuditLogAccess)
 		setControllerDeprecatedMethod := rpc.Method(r.SetControllerDeprecated)
+		setControllerMaxModelsMethod := rpc.Method(r.SetControllerMaxModels)
+		setPlacementPolicyMethod := rpc.Method(r.SetPlacementPolicy)
+		placeModelMethod := rpc.Method(r.PlaceModel)
 		fullModelStatusMethod := rpc.Method(r.FullModelStatus)
 		updateMigratedModelMethod := rpc.Method(r.UpdateMigratedModel)
 		addCloudToControllerMethod := rpc.Method(r.AddCloudToController)
@@ -45,24 +53,45 @@ func init() {
 		renameGroupMethod := rpc.Method(r.RenameGroup)
 		removeGroupMethod := rpc.Method(r.RemoveGroup)
 		listGroupsMethod := rpc.Method(r.ListGroups)
+		addRoleMethod := rpc.Method(r.AddRole)
+		getRoleMethod := rpc.Method(r.GetRole)
+		renameRoleMethod := rpc.Method(r.RenameRole)
+		removeRoleMethod := rpc.Method(r.RemoveRole)
+		listRolesMethod := rpc.Method(r.ListRoles)
 		addRelationMethod := rpc.Method(r.AddRelation)
 		removeRelationMethod := rpc.Method(r.RemoveRelation)
 		checkRelationMethod := rpc.Method(r.CheckRelation)
 		listRelationshipTuplesMethod := rpc.Method(r.ListRelationshipTuples)
 		crossModelQueryMethod := rpc.Method(r.CrossModelQuery)
+		charmReportMethod := rpc.Method(r.CharmReport)
 		purgeLogsMethod := rpc.Method(r.PurgeLogs)
+		rotateJWKSMethod := rpc.Method(r.RotateJWKS)
 		migrateModel := rpc.Method(r.MigrateModel)
+		listMigrationsMethod := rpc.Method(r.ListMigrations)
+		migrationStatusMethod := rpc.Method(r.MigrationStatus)
+		findApplicationsMethod := rpc.Method(r.FindApplications)
+		findUnitsMethod := rpc.Method(r.FindUnits)
+		findMachinesMethod := rpc.Method(r.FindMachines)
+		drainControllerMethod := rpc.Method(r.DrainController)
+		controllerDrainStatusMethod := rpc.Method(r.ControllerDrainStatus)
+		setControllerDrainStateMethod := rpc.Method(r.SetControllerDrainState)
 		addServiceAccountMethod := rpc.Method(r.AddServiceAccount)
 		copyServiceAccountCredentialMethod := rpc.Method(r.CopyServiceAccountCredential)
 		updateServiceAccountCredentials := rpc.Method(r.UpdateServiceAccountCredentials)
 		listServiceAccountCredentials := rpc.Method(r.ListServiceAccountCredentials)
 		grantServiceAccountAccess := rpc.Method(r.GrantServiceAccountAccess)
+		listServiceAccounts := rpc.Method(r.ListServiceAccounts)
+		removeServiceAccount := rpc.Method(r.RemoveServiceAccount)
+		listServiceAccountAdministrators := rpc.Method(r.ListServiceAccountAdministrators)
 		version := rpc.Method(r.Version)
 
 		// JIMM Generic RPC
 		r.AddMethod("JIMM", 4, "AddController", addControllerMethod)
 		r.AddMethod("JIMM", 4, "DisableControllerUUIDMasking", disableControllerUUIDMaskingMethod)
+		r.AddMethod("JIMM", 4, "DisableIdentity", disableIdentityMethod)
+		r.AddMethod("JIMM", 4, "EnableIdentity", enableIdentityMethod)
 		r.AddMethod("JIMM", 4, "FindAuditEvents", findAuditEventsMethod)
+		r.AddMethod("JIMM", 4, "WatchAuditEvents", watchAuditEventsMethod)
 		r.AddMethod("JIMM", 4, "FullModelStatus", fullModelStatusMethod)
 		r.AddMethod("JIMM", 4, "GrantAuditLogAccess", grantAuditLogAccessMethod)
 		r.AddMethod("JIMM", 4, "ImportModel", importModelMethod)
@@ -70,29 +99,50 @@ func init() {
 		r.AddMethod("JIMM", 4, "RemoveController", removeControllerMethod)
 		r.AddMethod("JIMM", 4, "RevokeAuditLogAccess", revokeAuditLogAccessMethod)
 		r.AddMethod("JIMM", 4, "SetControllerDeprecated", setControllerDeprecatedMethod)
+		r.AddMethod("JIMM", 4, "SetControllerMaxModels", setControllerMaxModelsMethod)
+		r.AddMethod("JIMM", 4, "SetPlacementPolicy", setPlacementPolicyMethod)
+		r.AddMethod("JIMM", 4, "PlaceModel", placeModelMethod)
 		r.AddMethod("JIMM", 4, "UpdateMigratedModel", updateMigratedModelMethod)
 		r.AddMethod("JIMM", 4, "AddCloudToController", addCloudToControllerMethod)
 		r.AddMethod("JIMM", 4, "RemoveCloudFromController", removeCloudFromControllerMethod)
 		r.AddMethod("JIMM", 4, "PurgeLogs", purgeLogsMethod)
+		r.AddMethod("JIMM", 4, "RotateJWKS", rotateJWKSMethod)
 		r.AddMethod("JIMM", 4, "MigrateModel", migrateModel)
+		r.AddMethod("JIMM", 4, "ListMigrations", listMigrationsMethod)
+		r.AddMethod("JIMM", 4, "MigrationStatus", migrationStatusMethod)
+		r.AddMethod("JIMM", 4, "FindApplications", findApplicationsMethod)
+		r.AddMethod("JIMM", 4, "FindUnits", findUnitsMethod)
+		r.AddMethod("JIMM", 4, "FindMachines", findMachinesMethod)
+		r.AddMethod("JIMM", 4, "DrainController", drainControllerMethod)
+		r.AddMethod("JIMM", 4, "ControllerDrainStatus", controllerDrainStatusMethod)
+		r.AddMethod("JIMM", 4, "SetControllerDrainState", setControllerDrainStateMethod)
 		// JIMM ReBAC RPC
 		r.AddMethod("JIMM", 4, "AddGroup", addGroupMethod)
 		r.AddMethod("JIMM", 4, "GetGroup", getGroupMethod)
 		r.AddMethod("JIMM", 4, "RenameGroup", renameGroupMethod)
 		r.AddMethod("JIMM", 4, "RemoveGroup", removeGroupMethod)
 		r.AddMethod("JIMM", 4, "ListGroups", listGroupsMethod)
+		r.AddMethod("JIMM", 4, "AddRole", addRoleMethod)
+		r.AddMethod("JIMM", 4, "GetRole", getRoleMethod)
+		r.AddMethod("JIMM", 4, "RenameRole", renameRoleMethod)
+		r.AddMethod("JIMM", 4, "RemoveRole", removeRoleMethod)
+		r.AddMethod("JIMM", 4, "ListRoles", listRolesMethod)
 		r.AddMethod("JIMM", 4, "AddRelation", addRelationMethod)
 		r.AddMethod("JIMM", 4, "RemoveRelation", removeRelationMethod)
 		r.AddMethod("JIMM", 4, "CheckRelation", checkRelationMethod)
 		r.AddMethod("JIMM", 4, "ListRelationshipTuples", listRelationshipTuplesMethod)
 		// JIMM Cross-model queries
 		r.AddMethod("JIMM", 4, "CrossModelQuery", crossModelQueryMethod)
+		r.AddMethod("JIMM", 4, "CharmReport", charmReportMethod)
 		// JIMM Service Accounts
 		r.AddMethod("JIMM", 4, "AddServiceAccount", addServiceAccountMethod)
 		r.AddMethod("JIMM", 4, "CopyServiceAccountCredential", copyServiceAccountCredentialMethod)
 		r.AddMethod("JIMM", 4, "UpdateServiceAccountCredentials", updateServiceAccountCredentials)
 		r.AddMethod("JIMM", 4, "ListServiceAccountCredentials", listServiceAccountCredentials)
 		r.AddMethod("JIMM", 4, "GrantServiceAccountAccess", grantServiceAccountAccess)
+		r.AddMethod("JIMM", 4, "ListServiceAccounts", listServiceAccounts)
+		r.AddMethod("JIMM", 4, "RemoveServiceAccount", removeServiceAccount)
+		r.AddMethod("JIMM", 4, "ListServiceAccountAdministrators", listServiceAccountAdministrators)
 		r.AddMethod("JIMM", 4, "Version", version)
 
 		return []int{4}
@@ -278,6 +328,73 @@ func (r *controllerRoot) SetControllerDeprecated(ctx context.Context, req apipar
 	return ctl.ToAPIControllerInfo(), nil
 }
 
+// SetControllerMaxModels sets the maximum number of models that JIMM will
+// place on a controller.
+func (r *controllerRoot) SetControllerMaxModels(ctx context.Context, req apiparams.SetControllerMaxModelsRequest) (apiparams.ControllerInfo, error) {
+	const op = errors.Op("jujuapi.SetControllerMaxModels")
+
+	if err := r.jimm.SetControllerMaxModels(ctx, r.user, req.Name, req.MaxModels); err != nil {
+		return apiparams.ControllerInfo{}, errors.E(op, err)
+	}
+	ctl, err := r.jimm.ControllerInfo(ctx, req.Name)
+	if err != nil {
+		return apiparams.ControllerInfo{}, errors.E(op, err)
+	}
+	return ctl.ToAPIControllerInfo(), nil
+}
+
+// SetPlacementPolicy sets the policy JIMM uses to select the controller
+// that hosts a new model.
+func (r *controllerRoot) SetPlacementPolicy(ctx context.Context, req apiparams.SetPlacementPolicyRequest) error {
+	const op = errors.Op("jujuapi.SetPlacementPolicy")
+
+	if err := r.jimm.SetPlacementPolicy(ctx, r.user, req.Policy); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// PlaceModel returns the controller that a model created with the given
+// arguments would be placed on, without creating the model.
+func (r *controllerRoot) PlaceModel(ctx context.Context, args jujuparams.ModelCreateArgs) (apiparams.ModelPlacement, error) {
+	const op = errors.Op("jujuapi.PlaceModel")
+
+	var mca jimm.ModelCreateArgs
+	if err := mca.FromJujuModelCreateArgs(&args); err != nil {
+		return apiparams.ModelPlacement{}, errors.E(op, err)
+	}
+	p, err := r.jimm.PlaceModel(ctx, r.user, &mca)
+	if err != nil {
+		return apiparams.ModelPlacement{}, errors.E(op, err)
+	}
+	return apiparams.ModelPlacement{
+		Policy:      p.Policy,
+		Controller:  p.Controller.Name,
+		CloudRegion: p.CloudRegion,
+	}, nil
+}
+
+// DisableIdentity disables an identity, preventing it from logging in to
+// JIMM and closing any connections it currently has open.
+func (r *controllerRoot) DisableIdentity(ctx context.Context, req apiparams.DisableIdentityRequest) error {
+	const op = errors.Op("jujuapi.DisableIdentity")
+
+	if err := r.jimm.DisableIdentity(ctx, r.user, req.Name); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
+// EnableIdentity re-enables a disabled identity.
+func (r *controllerRoot) EnableIdentity(ctx context.Context, req apiparams.EnableIdentityRequest) error {
+	const op = errors.Op("jujuapi.EnableIdentity")
+
+	if err := r.jimm.EnableIdentity(ctx, r.user, req.Name); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // maxLimit is the maximum number of audit-log entries that will be
 // returned from the audit log, no matter how many are requested.
 const maxLimit = 1000
@@ -289,6 +406,28 @@ func auditParamsToFilter(req apiparams.FindAuditEventsRequest) (db.AuditLogFilte
 	filter.Method = req.Method
 	filter.Model = req.Model
 	filter.SortTime = req.SortTime
+	filter.FacadeName = req.FacadeName
+	filter.ConversationId = req.ConversationId
+	filter.ErrorsOnly = req.ErrorsOnly
+	filter.ParamsPath = req.ParamsPath
+	filter.ParamsSearch = req.ParamsSearch
+
+	switch req.Type {
+	case "":
+	case apiparams.AuditEventTypeRequest:
+		filter.IsResponse = new(bool)
+	case apiparams.AuditEventTypeResponse:
+		isResponse := true
+		filter.IsResponse = &isResponse
+	default:
+		return filter, errors.E(errors.CodeBadRequest, fmt.Sprintf(`invalid "type" filter %q`, req.Type))
+	}
+	if req.Correlate && req.Type != "" {
+		return filter, errors.E(errors.CodeBadRequest, `"type" filter cannot be used with correlated events`)
+	}
+	if req.ErrorsOnly && req.Type == apiparams.AuditEventTypeRequest {
+		return filter, errors.E(errors.CodeBadRequest, `"errors-only" filter cannot be used with requests`)
+	}
 
 	if req.After != "" {
 		filter.Start, err = time.Parse(time.RFC3339, req.After)
@@ -333,6 +472,19 @@ func (r *controllerRoot) FindAuditEvents(ctx context.Context, req apiparams.Find
 	if err != nil {
 		return apiparams.AuditEvents{}, errors.E(op, err)
 	}
+	if req.Correlate {
+		entries, err := r.jimm.FindCorrelatedAuditEvents(ctx, r.user, filter)
+		if err != nil {
+			return apiparams.AuditEvents{}, errors.E(op, err)
+		}
+		events := make([]apiparams.AuditEvent, len(entries))
+		for i, ent := range entries {
+			events[i] = ent.ToAPIAuditEvent()
+		}
+		return apiparams.AuditEvents{
+			Events: events,
+		}, nil
+	}
 	entries, err := r.jimm.FindAuditEvents(ctx, r.user, filter)
 	if err != nil {
 		return apiparams.AuditEvents{}, errors.E(op, err)
@@ -347,6 +499,45 @@ func (r *controllerRoot) FindAuditEvents(ctx context.Context, req apiparams.Find
 	}, nil
 }
 
+// WatchAuditEvents starts a watcher that returns new audit-log entries
+// matching the model, method and user-tag of the given filter as they are
+// added. The time range, offset and sort order of the filter are ignored.
+// Only users with access to the audit log can watch audit events.
+func (r *controllerRoot) WatchAuditEvents(ctx context.Context, req apiparams.FindAuditEventsRequest) (apiparams.WatchAuditEventsResponse, error) {
+	const op = errors.Op("jujuapi.WatchAuditEvents")
+
+	filter, err := auditParamsToFilter(req)
+	if err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+
+	// Find the entry with the highest ID, this also checks the user can
+	// view the audit log. The watcher reports entries by ID so the entry
+	// with the most recent time may not be the last one added.
+	latest, err := r.jimm.FindAuditEvents(ctx, r.user, db.AuditLogFilter{Limit: 1, SortIDDesc: true})
+	if err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+	var lastID uint
+	if len(latest) > 0 {
+		lastID = latest[0].ID
+	}
+
+	if err := r.setupUUIDGenerator(); err != nil {
+		return apiparams.WatchAuditEventsResponse{}, errors.E(op, err)
+	}
+	id := fmt.Sprintf("%v", r.generator.Next())
+
+	findEvents := func(ctx context.Context, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error) {
+		return r.jimm.FindAuditEvents(ctx, r.user, filter)
+	}
+	r.auditWatchers.register(newAuditEventWatcher(id, filter, lastID, findEvents))
+
+	return apiparams.WatchAuditEventsResponse{
+		WatcherID: id,
+	}, nil
+}
+
 // GrantAuditLogAccess grants access to the audit log at the specified
 // level to the specified user. The only currently supported level is
 // "read". Only controller admin users can grant access to the audit log.
@@ -460,15 +651,50 @@ func (r *controllerRoot) CrossModelQuery(ctx context.Context, req apiparams.Cros
 	if err != nil {
 		return apiparams.CrossModelQueryResponse{}, errors.E(op, errors.Code("failed to list user's model access"))
 	}
+	modelUUIDs, err = r.jimm.SelectModels(ctx, modelUUIDs, req.Selector)
+	if err != nil {
+		return apiparams.CrossModelQueryResponse{}, errors.E(op, err)
+	}
+
+	total := len(modelUUIDs)
+	if req.Limit > 0 {
+		// Paginated queries work over a stable ordering of the
+		// models so that successive pages do not overlap.
+		sort.Strings(modelUUIDs)
+		start := min(max(req.Offset, 0), total)
+		end := min(start+req.Limit, total)
+		modelUUIDs = modelUUIDs[start:end]
+	}
 
-	switch strings.TrimSpace(strings.ToLower(req.Type)) {
-	case "jq":
-		return r.jimm.QueryModelsJq(ctx, modelUUIDs, req.Query)
-	case "jimmsql":
+	queryType := strings.TrimSpace(strings.ToLower(req.Type))
+	if queryType == "jimmsql" {
 		return apiparams.CrossModelQueryResponse{}, errors.E(op, errors.CodeNotImplemented)
-	default:
-		return apiparams.CrossModelQueryResponse{}, errors.E(op, errors.Code("invalid query type"), "unable to query models")
 	}
+	resp, err := r.jimm.QueryModels(ctx, modelUUIDs, queryType, req.Query)
+	if err != nil {
+		return resp, errors.E(op, err)
+	}
+	if req.Limit > 0 {
+		resp.Total = total
+	}
+	return resp, nil
+}
+
+// CharmReport reports the charms deployed across all controllers,
+// flagging applications that are behind the newest revision of their charm
+// or deployed from a deprecated channel. Only JIMM administrators can
+// produce a charm report.
+func (r *controllerRoot) CharmReport(ctx context.Context, req apiparams.CharmReportRequest) (apiparams.CharmReport, error) {
+	const op = errors.Op("jujuapi.CharmReport")
+
+	report, err := r.jimm.CharmReport(ctx, r.user, jimm.CharmReportArgs{
+		Charm:              req.Charm,
+		DeprecatedChannels: req.DeprecatedChannels,
+	})
+	if err != nil {
+		return apiparams.CharmReport{}, errors.E(op, err)
+	}
+	return report, nil
 }
 
 // PurgeLogs removes all audit log entries older than the specified date.
@@ -484,6 +710,17 @@ func (r *controllerRoot) PurgeLogs(ctx context.Context, req apiparams.PurgeLogsR
 	}, nil
 }
 
+// RotateJWKS immediately replaces all of the keys JIMM signs JWTs with.
+// It is intended for use when a key may have been compromised.
+func (r *controllerRoot) RotateJWKS(ctx context.Context) error {
+	const op = errors.Op("jujuapi.RotateJWKS")
+
+	if err := r.jimm.RotateJWKS(ctx, r.user); err != nil {
+		return errors.E(op, err)
+	}
+	return nil
+}
+
 // MigrateModel is a JIMM specific method for migrating models between two controllers that
 // are already attached to JIMM. See InitiateMigration in controller.go to migrate a model
 // in a controller attached to JIMM to one not managed by JIMM.
@@ -509,6 +746,211 @@ func (r *controllerRoot) MigrateModel(ctx context.Context, args apiparams.Migrat
 	}, nil
 }
 
+// ListMigrations returns the model migrations JIMM has initiated, most
+// recently started first. Only JIMM administrators can list migrations.
+func (r *controllerRoot) ListMigrations(ctx context.Context, req apiparams.ListMigrationsRequest) (apiparams.ListMigrationsResponse, error) {
+	const op = errors.Op("jujuapi.ListMigrations")
+
+	var modelTag *names.ModelTag
+	if req.ModelTag != "" {
+		mt, err := names.ParseModelTag(req.ModelTag)
+		if err != nil {
+			return apiparams.ListMigrationsResponse{}, errors.E(op, err, errors.CodeBadRequest)
+		}
+		modelTag = &mt
+	}
+
+	migrations, err := r.jimm.ListMigrations(ctx, r.user, modelTag, req.Active)
+	if err != nil {
+		return apiparams.ListMigrationsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListMigrationsResponse{
+		Migrations: make([]apiparams.MigrationInfo, len(migrations)),
+	}
+	for i, m := range migrations {
+		resp.Migrations[i] = m.ToAPIMigrationInfo()
+	}
+	return resp, nil
+}
+
+// MigrationStatus returns the most recent migration of a model.
+func (r *controllerRoot) MigrationStatus(ctx context.Context, req apiparams.MigrationStatusRequest) (apiparams.MigrationInfo, error) {
+	const op = errors.Op("jujuapi.MigrationStatus")
+
+	mt, err := names.ParseModelTag(req.ModelTag)
+	if err != nil {
+		return apiparams.MigrationInfo{}, errors.E(op, err, errors.CodeBadRequest)
+	}
+	migration, err := r.jimm.MigrationStatus(ctx, r.user, mt)
+	if err != nil {
+		return apiparams.MigrationInfo{}, errors.E(op, err)
+	}
+	return migration.ToAPIMigrationInfo(), nil
+}
+
+// inventoryParamsToFilter converts the model tag and pagination
+// parameters of an inventory search into a db.InventoryFilter.
+func inventoryParamsToFilter(modelTag string, limit, offset int) (db.InventoryFilter, error) {
+	var filter db.InventoryFilter
+	if modelTag != "" {
+		mt, err := names.ParseModelTag(modelTag)
+		if err != nil {
+			return filter, errors.E(err, errors.CodeBadRequest, `invalid "model-tag" filter`)
+		}
+		filter.ModelUUIDs = []string{mt.Id()}
+	}
+	if limit < 1 {
+		limit = limitDefault
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+	filter.Limit = limit
+	if offset > 0 {
+		filter.Offset = offset
+	}
+	return filter, nil
+}
+
+// FindApplications finds the applications in JIMM's inventory that
+// match the given filter. Only applications in models the user can read
+// are returned.
+func (r *controllerRoot) FindApplications(ctx context.Context, req apiparams.FindApplicationsRequest) (apiparams.FindApplicationsResponse, error) {
+	const op = errors.Op("jujuapi.FindApplications")
+
+	inventoryFilter, err := inventoryParamsToFilter(req.ModelTag, req.Limit, req.Offset)
+	if err != nil {
+		return apiparams.FindApplicationsResponse{}, errors.E(op, err)
+	}
+	apps, err := r.jimm.FindApplications(ctx, r.user, db.ModelApplicationFilter{
+		InventoryFilter:    inventoryFilter,
+		Name:               req.Name,
+		CharmName:          req.CharmName,
+		CharmRevisionBelow: req.CharmRevisionBelow,
+		Status:             req.Status,
+	})
+	if err != nil {
+		return apiparams.FindApplicationsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.FindApplicationsResponse{
+		Applications: make([]apiparams.ApplicationInfo, len(apps)),
+	}
+	for i, app := range apps {
+		resp.Applications[i] = app.ToAPIApplicationInfo()
+	}
+	return resp, nil
+}
+
+// FindUnits finds the units in JIMM's inventory that match the given
+// filter. Only units in models the user can read are returned.
+func (r *controllerRoot) FindUnits(ctx context.Context, req apiparams.FindUnitsRequest) (apiparams.FindUnitsResponse, error) {
+	const op = errors.Op("jujuapi.FindUnits")
+
+	inventoryFilter, err := inventoryParamsToFilter(req.ModelTag, req.Limit, req.Offset)
+	if err != nil {
+		return apiparams.FindUnitsResponse{}, errors.E(op, err)
+	}
+	units, err := r.jimm.FindUnits(ctx, r.user, db.ModelUnitFilter{
+		InventoryFilter: inventoryFilter,
+		Application:     req.Application,
+		MachineID:       req.MachineID,
+		WorkloadStatus:  req.WorkloadStatus,
+		AgentStatus:     req.AgentStatus,
+	})
+	if err != nil {
+		return apiparams.FindUnitsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.FindUnitsResponse{
+		Units: make([]apiparams.UnitInfo, len(units)),
+	}
+	for i, u := range units {
+		resp.Units[i] = u.ToAPIUnitInfo()
+	}
+	return resp, nil
+}
+
+// FindMachines finds the machines in JIMM's inventory that match the
+// given filter. Only machines in models the user can read are returned.
+func (r *controllerRoot) FindMachines(ctx context.Context, req apiparams.FindMachinesRequest) (apiparams.FindMachinesResponse, error) {
+	const op = errors.Op("jujuapi.FindMachines")
+
+	inventoryFilter, err := inventoryParamsToFilter(req.ModelTag, req.Limit, req.Offset)
+	if err != nil {
+		return apiparams.FindMachinesResponse{}, errors.E(op, err)
+	}
+	machines, err := r.jimm.FindMachines(ctx, r.user, db.ModelMachineFilter{
+		InventoryFilter: inventoryFilter,
+		Base:            req.Base,
+		InstanceID:      req.InstanceID,
+		AgentStatus:     req.AgentStatus,
+	})
+	if err != nil {
+		return apiparams.FindMachinesResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.FindMachinesResponse{
+		Machines: make([]apiparams.MachineInfo, len(machines)),
+	}
+	for i, m := range machines {
+		resp.Machines[i] = m.ToAPIMachineInfo()
+	}
+	return resp, nil
+}
+
+// DrainController migrates all models off a controller. Only JIMM
+// administrators can drain a controller.
+func (r *controllerRoot) DrainController(ctx context.Context, req apiparams.DrainControllerRequest) (apiparams.ControllerDrain, error) {
+	const op = errors.Op("jujuapi.DrainController")
+
+	d, err := r.jimm.DrainController(ctx, r.user, req.Controller, jimm.DrainControllerArgs{
+		Concurrency: req.Concurrency,
+		DryRun:      req.DryRun,
+	})
+	if err != nil {
+		return apiparams.ControllerDrain{}, errors.E(op, err)
+	}
+	return toAPIControllerDrain(d), nil
+}
+
+// ControllerDrainStatus returns the progress of a controller drain.
+func (r *controllerRoot) ControllerDrainStatus(ctx context.Context, req apiparams.ControllerDrainStatusRequest) (apiparams.ControllerDrain, error) {
+	const op = errors.Op("jujuapi.ControllerDrainStatus")
+
+	d, err := r.jimm.ControllerDrainStatus(ctx, r.user, req.Controller)
+	if err != nil {
+		return apiparams.ControllerDrain{}, errors.E(op, err)
+	}
+	return toAPIControllerDrain(d), nil
+}
+
+// SetControllerDrainState pauses, resumes or aborts a controller drain.
+func (r *controllerRoot) SetControllerDrainState(ctx context.Context, req apiparams.SetControllerDrainStateRequest) (apiparams.ControllerDrain, error) {
+	const op = errors.Op("jujuapi.SetControllerDrainState")
+
+	d, err := r.jimm.SetControllerDrainState(ctx, r.user, req.Controller, req.State)
+	if err != nil {
+		return apiparams.ControllerDrain{}, errors.E(op, err)
+	}
+	return toAPIControllerDrain(d), nil
+}
+
+func toAPIControllerDrain(d *jimm.ControllerDrain) apiparams.ControllerDrain {
+	resp := apiparams.ControllerDrain{
+		Controller: d.Controller,
+		State:      d.State,
+		Models:     make([]apiparams.DrainModel, len(d.Models)),
+	}
+	for i, m := range d.Models {
+		resp.Models[i] = apiparams.DrainModel{
+			ModelTag:         m.ModelTag.String(),
+			ModelName:        m.ModelName,
+			TargetController: m.TargetController,
+			Status:           m.Status,
+			Error:            m.Error,
+		}
+	}
+	return resp
+}
+
 // Version is a method on the JIMM facade that returns information on the version of JIMM.
 func (r *controllerRoot) Version(ctx context.Context) (apiparams.VersionResponse, error) {
 	versionInfo := apiparams.VersionResponse{
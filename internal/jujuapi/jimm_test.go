@@ -503,6 +503,34 @@ func TestAuditLogAPIParamsConversion(t *testing.T) {
 			result: db.AuditLogFilter{
 				Limit: jujuapi.AuditLogUpperLimit,
 			},
+		}, {
+			about: "Test extended filters",
+			request: apiparams.FindAuditEventsRequest{
+				FacadeName:     "Application",
+				ConversationId: "0123456789abcdef",
+				Type:           apiparams.AuditEventTypeResponse,
+				ErrorsOnly:     true,
+				ParamsPath:     `$.applications[*].application == "postgresql"`,
+				ParamsSearch:   "postgresql",
+			},
+			result: db.AuditLogFilter{
+				FacadeName:     "Application",
+				ConversationId: "0123456789abcdef",
+				IsResponse:     &[]bool{true}[0],
+				ErrorsOnly:     true,
+				ParamsPath:     `$.applications[*].application == "postgresql"`,
+				ParamsSearch:   "postgresql",
+				Limit:          jujuapi.AuditLogDefaultLimit,
+			},
+		}, {
+			about: "Test requests only",
+			request: apiparams.FindAuditEventsRequest{
+				Type: apiparams.AuditEventTypeRequest,
+			},
+			result: db.AuditLogFilter{
+				IsResponse: &[]bool{false}[0],
+				Limit:      jujuapi.AuditLogDefaultLimit,
+			},
 		},
 	}
 	for _, test := range testCases {
@@ -517,6 +545,42 @@ func TestAuditLogAPIParamsConversion(t *testing.T) {
 	}
 }
 
+func TestAuditLogAPIParamsConversionErrors(t *testing.T) {
+	c := qt.New(t)
+	testCases := []struct {
+		about   string
+		request apiparams.FindAuditEventsRequest
+		err     string
+	}{{
+		about: "Test invalid type",
+		request: apiparams.FindAuditEventsRequest{
+			Type: "reply",
+		},
+		err: `invalid "type" filter "reply"`,
+	}, {
+		about: "Test errors only requests",
+		request: apiparams.FindAuditEventsRequest{
+			Type:       apiparams.AuditEventTypeRequest,
+			ErrorsOnly: true,
+		},
+		err: `"errors-only" filter cannot be used with requests`,
+	}, {
+		about: "Test correlated type",
+		request: apiparams.FindAuditEventsRequest{
+			Type:      apiparams.AuditEventTypeResponse,
+			Correlate: true,
+		},
+		err: `"type" filter cannot be used with correlated events`,
+	}}
+	for _, test := range testCases {
+		c.Run(test.about, func(c *qt.C) {
+			_, err := jujuapi.AuditParamsToFilter(test.request)
+			c.Assert(err, qt.ErrorMatches, test.err)
+			c.Assert(errors.ErrorCode(err), qt.Equals, errors.CodeBadRequest)
+		})
+	}
+}
+
 func (s *jimmSuite) TestFullModelStatus(c *gc.C) {
 	s.AddController(c, "controller-2", s.APIInfo(c))
 	mt := s.AddModel(c, names.NewUserTag("charlie@canonical.com"), "model-1", names.NewCloudTag(jimmtest.TestCloudName), jimmtest.TestCloudRegionName, s.Model2.CloudCredential.ResourceTag())
@@ -834,6 +898,32 @@ func (s *jimmSuite) TestCrossModelQuery(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 	c.Assert(res.Results, gc.HasLen, 2)
 	c.Assert(res.Errors, gc.HasLen, 0)
+
+	// Query using the other query languages.
+	res, err = client.CrossModelQuery(&apiparams.CrossModelQueryRequest{
+		Type:  "jmespath",
+		Query: "model.name",
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(res.Results, gc.HasLen, 5)
+	c.Assert(res.Errors, gc.HasLen, 0)
+
+	res, err = client.CrossModelQuery(&apiparams.CrossModelQueryRequest{
+		Type:  "filter",
+		Query: `model.name == "model-21"`,
+	})
+	c.Assert(err, gc.IsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Assert(res.Errors, gc.HasLen, 0)
+	for _, v := range res.Results {
+		c.Assert(v, gc.DeepEquals, []any{map[string]any{"model": "model-21"}})
+	}
+
+	_, err = client.CrossModelQuery(&apiparams.CrossModelQueryRequest{
+		Type:  "filter",
+		Query: `model.name ==`,
+	})
+	c.Assert(err, gc.ErrorMatches, `failed to parse filter query: unexpected end of query at position 13 \(bad request\)`)
 }
 
 // TestJimmModelMigration tests that a migration request makes it through to the Juju controller.
@@ -146,3 +146,52 @@ func (r *controllerRoot) GrantServiceAccountAccess(ctx context.Context, req apip
 
 	return r.jimm.GrantServiceAccountAccess(ctx, r.user, svcAccTag, req.Entities)
 }
+
+// ListServiceAccounts lists the service accounts administered by the
+// authenticated user.
+func (r *controllerRoot) ListServiceAccounts(ctx context.Context) (apiparams.ListServiceAccountsResponse, error) {
+	const op = errors.Op("jujuapi.ListServiceAccounts")
+
+	clientIDs, err := r.jimm.ListServiceAccounts(ctx, r.user)
+	if err != nil {
+		return apiparams.ListServiceAccountsResponse{}, errors.E(op, err)
+	}
+	resp := apiparams.ListServiceAccountsResponse{
+		ServiceAccounts: make([]apiparams.ServiceAccount, len(clientIDs)),
+	}
+	for i, clientID := range clientIDs {
+		resp.ServiceAccounts[i] = apiparams.ServiceAccount{ClientID: clientID}
+	}
+	return resp, nil
+}
+
+// RemoveServiceAccount removes a service account. The user must be an
+// administrator of the service account in order to do this.
+func (r *controllerRoot) RemoveServiceAccount(ctx context.Context, req apiparams.RemoveServiceAccountRequest) error {
+	const op = errors.Op("jujuapi.RemoveServiceAccount")
+
+	clientIdWithDomain, err := jimmnames.EnsureValidServiceAccountId(req.ClientID)
+	if err != nil {
+		return errors.E(op, errors.CodeBadRequest, err)
+	}
+
+	return r.jimm.RemoveServiceAccount(ctx, r.user, jimmnames.NewServiceAccountTag(clientIdWithDomain))
+}
+
+// ListServiceAccountAdministrators lists the identities and groups that
+// administer a service account. The user must be an administrator of the
+// service account in order to do this.
+func (r *controllerRoot) ListServiceAccountAdministrators(ctx context.Context, req apiparams.ListServiceAccountAdministratorsRequest) (apiparams.ListServiceAccountAdministratorsResponse, error) {
+	const op = errors.Op("jujuapi.ListServiceAccountAdministrators")
+
+	clientIdWithDomain, err := jimmnames.EnsureValidServiceAccountId(req.ClientID)
+	if err != nil {
+		return apiparams.ListServiceAccountAdministratorsResponse{}, errors.E(op, errors.CodeBadRequest, err)
+	}
+
+	administrators, err := r.jimm.ListServiceAccountAdministrators(ctx, r.user, jimmnames.NewServiceAccountTag(clientIdWithDomain))
+	if err != nil {
+		return apiparams.ListServiceAccountAdministratorsResponse{}, errors.E(op, err)
+	}
+	return apiparams.ListServiceAccountAdministratorsResponse{Administrators: administrators}, nil
+}
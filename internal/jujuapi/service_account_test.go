@@ -726,6 +726,88 @@ func TestGrantServiceAccountAccess(t *testing.T) {
 	}
 }
 
+func TestListServiceAccounts(t *testing.T) {
+	c := qt.New(t)
+
+	jimm := &jimmtest.JIMM{
+		ListServiceAccounts_: func(_ context.Context, _ *openfga.User) ([]string, error) {
+			return []string{"a@serviceaccount", "b@serviceaccount"}, nil
+		},
+	}
+	cr := jujuapi.NewControllerRoot(jimm, jujuapi.Params{})
+
+	resp, err := cr.ListServiceAccounts(context.Background())
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp, qt.DeepEquals, params.ListServiceAccountsResponse{
+		ServiceAccounts: []params.ServiceAccount{{
+			ClientID: "a@serviceaccount",
+		}, {
+			ClientID: "b@serviceaccount",
+		}},
+	})
+}
+
+func TestRemoveServiceAccount(t *testing.T) {
+	c := qt.New(t)
+
+	tests := []struct {
+		about           string
+		clientID        string
+		removedClientID string
+		expectedError   string
+	}{{
+		about:           "Valid client ID without domain",
+		clientID:        "fca1f605-736e-4d1f-bcd2-aecc726923be",
+		removedClientID: "fca1f605-736e-4d1f-bcd2-aecc726923be@serviceaccount",
+	}, {
+		about:           "Valid client ID with correct domain",
+		clientID:        "fca1f605-736e-4d1f-bcd2-aecc726923be@serviceaccount",
+		removedClientID: "fca1f605-736e-4d1f-bcd2-aecc726923be@serviceaccount",
+	}, {
+		about:         "Invalid client ID",
+		clientID:      "_123_@not-serviceaccount",
+		expectedError: "invalid client ID",
+	}}
+
+	for _, test := range tests {
+		test := test
+		c.Run(test.about, func(c *qt.C) {
+			jimm := &jimmtest.JIMM{
+				RemoveServiceAccount_: func(_ context.Context, _ *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+					c.Assert(svcAccTag.Id(), qt.Equals, test.removedClientID)
+					return nil
+				},
+			}
+			cr := jujuapi.NewControllerRoot(jimm, jujuapi.Params{})
+
+			err := cr.RemoveServiceAccount(context.Background(), params.RemoveServiceAccountRequest{ClientID: test.clientID})
+			if test.expectedError == "" {
+				c.Assert(err, qt.IsNil)
+			} else {
+				c.Assert(err, qt.ErrorMatches, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestListServiceAccountAdministrators(t *testing.T) {
+	c := qt.New(t)
+
+	jimm := &jimmtest.JIMM{
+		ListServiceAccountAdministrators_: func(_ context.Context, _ *openfga.User, svcAccTag jimmnames.ServiceAccountTag) ([]string, error) {
+			c.Assert(svcAccTag.Id(), qt.Equals, "fca1f605-736e-4d1f-bcd2-aecc726923be@serviceaccount")
+			return []string{"group-admins#member", "user-alice@canonical.com"}, nil
+		},
+	}
+	cr := jujuapi.NewControllerRoot(jimm, jujuapi.Params{})
+
+	resp, err := cr.ListServiceAccountAdministrators(context.Background(), params.ListServiceAccountAdministratorsRequest{
+		ClientID: "fca1f605-736e-4d1f-bcd2-aecc726923be",
+	})
+	c.Assert(err, qt.IsNil)
+	c.Assert(resp.Administrators, qt.DeepEquals, []string{"group-admins#member", "user-alice@canonical.com"})
+}
+
 // Integration tests below.
 type serviceAccountSuite struct {
 	websocketSuite
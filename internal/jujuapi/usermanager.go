@@ -6,16 +6,18 @@ import (
 	"context"
 
 	jujuparams "github.com/juju/juju/rpc/params"
+	"github.com/juju/names/v5"
 
 	"github.com/canonical/jimm/v3/internal/errors"
 	"github.com/canonical/jimm/v3/internal/jujuapi/rpc"
+	"github.com/canonical/jimm/v3/internal/openfga"
 )
 
 func init() {
 	facadeInit["UserManager"] = func(r *controllerRoot) []int {
 		addUserMethod := rpc.Method(r.AddUser)
-		disableUserMethod := rpc.Method(r.EnableUser)
-		enableUserMethod := rpc.Method(r.DisableUser)
+		disableUserMethod := rpc.Method(r.DisableUser)
+		enableUserMethod := rpc.Method(r.EnableUser)
 		removeUserMethod := rpc.Method(r.RemoveUser)
 		setPasswordMethod := rpc.Method(r.SetPassword)
 		userInfoMethod := rpc.Method(r.UserInfo)
@@ -41,23 +43,74 @@ func (r *controllerRoot) AddUser(args jujuparams.AddUsers) (jujuparams.AddUserRe
 }
 
 // RemoveUser implements the UserManager facade's RemoveUser method.
-func (r *controllerRoot) RemoveUser(jujuparams.Entities) (jujuparams.ErrorResults, error) {
-	return jujuparams.ErrorResults{}, errors.E(errors.CodeUnauthorized, "unauthorized")
+func (r *controllerRoot) RemoveUser(ctx context.Context, args jujuparams.Entities) (jujuparams.ErrorResults, error) {
+	return r.forEachUser(ctx, args, errors.Op("jujuapi.RemoveUser"), r.jimm.RemoveIdentity)
 }
 
 // EnableUser implements the UserManager facade's EnableUser method.
-func (r *controllerRoot) EnableUser(jujuparams.Entities) (jujuparams.ErrorResults, error) {
-	return jujuparams.ErrorResults{}, errors.E(errors.CodeUnauthorized, "unauthorized")
+func (r *controllerRoot) EnableUser(ctx context.Context, args jujuparams.Entities) (jujuparams.ErrorResults, error) {
+	return r.forEachUser(ctx, args, errors.Op("jujuapi.EnableUser"), r.jimm.EnableIdentity)
 }
 
 // DisableUser implements the UserManager facade's DisableUser method.
-func (r *controllerRoot) DisableUser(jujuparams.Entities) (jujuparams.ErrorResults, error) {
-	return jujuparams.ErrorResults{}, errors.E(errors.CodeUnauthorized, "unauthorized")
+func (r *controllerRoot) DisableUser(ctx context.Context, args jujuparams.Entities) (jujuparams.ErrorResults, error) {
+	return r.forEachUser(ctx, args, errors.Op("jujuapi.DisableUser"), r.jimm.DisableIdentity)
+}
+
+// forEachUser calls f for each user tag in args, returning the result of
+// each call.
+func (r *controllerRoot) forEachUser(ctx context.Context, args jujuparams.Entities, op errors.Op, f func(context.Context, *openfga.User, string) error) (jujuparams.ErrorResults, error) {
+	results := make([]jujuparams.ErrorResult, len(args.Entities))
+	for i, ent := range args.Entities {
+		ut, err := parseUserTag(ent.Tag)
+		if err != nil {
+			results[i].Error = mapError(errors.E(op, err))
+			continue
+		}
+		if err := f(ctx, r.user, ut.Id()); err != nil {
+			results[i].Error = mapError(errors.E(op, err))
+		}
+	}
+	return jujuparams.ErrorResults{Results: results}, nil
 }
 
-// ModelUserInfo returns information on all users in the model.
-func (r *controllerRoot) ModelUserInfo(args jujuparams.Entities) (jujuparams.ModelUserInfoResults, error) {
-	return jujuparams.ModelUserInfoResults{}, errors.E(errors.CodeNotImplemented, "not implements")
+// ModelUserInfo implements the UserManager facade's ModelUserInfo method.
+// It returns information on all users with access to the given models.
+func (r *controllerRoot) ModelUserInfo(ctx context.Context, args jujuparams.Entities) (jujuparams.ModelUserInfoResults, error) {
+	const op = errors.Op("jujuapi.ModelUserInfo")
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	var results []jujuparams.ModelUserInfoResult
+	for _, arg := range args.Entities {
+		mt, err := names.ParseModelTag(arg.Tag)
+		if err != nil {
+			results = append(results, jujuparams.ModelUserInfoResult{
+				Error: mapError(errors.E(op, err, errors.CodeBadRequest)),
+			})
+			continue
+		}
+		users, err := r.jimm.ModelUserInfo(ctx, r.user, mt)
+		if err != nil {
+			if errors.ErrorCode(err) == errors.CodeNotFound {
+				// Map not-found errors to unauthorized, this is what juju
+				// does.
+				err = errors.E(op, errors.CodeUnauthorized, "unauthorized")
+			}
+			results = append(results, jujuparams.ModelUserInfoResult{
+				Error: mapError(errors.E(op, err)),
+			})
+			continue
+		}
+		for i := range users {
+			results = append(results, jujuparams.ModelUserInfoResult{
+				Result: &users[i],
+			})
+		}
+	}
+	return jujuparams.ModelUserInfoResults{
+		Results: results,
+	}, nil
 }
 
 // UserInfo implements the UserManager facade's UserInfo method.
@@ -27,30 +27,85 @@ func (s *userManagerSuite) TestAddUser(c *gc.C) {
 }
 
 func (s *userManagerSuite) TestRemoveUser(c *gc.C) {
-	conn := s.open(c, nil, "alice")
+	conn := s.open(c, nil, "bob")
+	conn.Close()
+
+	conn = s.open(c, nil, "alice")
 	defer conn.Close()
 
 	client := usermanager.NewClient(conn)
 	err := client.RemoveUser("bob")
-	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+	c.Assert(err, gc.ErrorMatches, `unsupported local user; if this is a service account add @serviceaccount domain`)
+
+	err = client.RemoveUser("bob@canonical.com")
+	c.Assert(err, gc.Equals, nil)
+
+	_, err = s.openNoAssert(c, loginDetails{username: "bob"})
+	c.Assert(err, gc.ErrorMatches, `.*identity has been removed.*`)
 }
 
-func (s *userManagerSuite) TestEnableUser(c *gc.C) {
-	conn := s.open(c, nil, "alice")
+func (s *userManagerSuite) TestRemoveUserUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
 	defer conn.Close()
 
 	client := usermanager.NewClient(conn)
-	err := client.EnableUser("bob")
-	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+	err := client.RemoveUser("alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized`)
 }
 
-func (s *userManagerSuite) TestDisableUser(c *gc.C) {
-	conn := s.open(c, nil, "alice")
+func (s *userManagerSuite) TestDisableAndEnableUser(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	conn.Close()
+
+	conn = s.open(c, nil, "alice")
 	defer conn.Close()
 
 	client := usermanager.NewClient(conn)
-	err := client.DisableUser("bob")
-	c.Assert(err, gc.ErrorMatches, `unauthorized \(unauthorized access\)`)
+	err := client.DisableUser("bob@canonical.com")
+	c.Assert(err, gc.Equals, nil)
+
+	_, err = s.openNoAssert(c, loginDetails{username: "bob"})
+	c.Assert(err, gc.ErrorMatches, `.*identity disabled.*`)
+
+	err = client.EnableUser("bob@canonical.com")
+	c.Assert(err, gc.Equals, nil)
+
+	conn2 := s.open(c, nil, "bob")
+	conn2.Close()
+}
+
+func (s *userManagerSuite) TestDisableUserUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+
+	client := usermanager.NewClient(conn)
+	err := client.DisableUser("alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized`)
+
+	err = client.EnableUser("alice@canonical.com")
+	c.Assert(err, gc.ErrorMatches, `unauthorized`)
+}
+
+func (s *userManagerSuite) TestModelUserInfo(c *gc.C) {
+	conn := s.open(c, nil, "bob")
+	defer conn.Close()
+
+	client := usermanager.NewClient(conn)
+	users, err := client.ModelUserInfo(s.Model.UUID.String)
+	c.Assert(err, gc.Equals, nil)
+	c.Assert(users, gc.HasLen, 1)
+	c.Check(users[0].UserName, gc.Equals, "bob@canonical.com")
+	c.Check(users[0].Access, gc.Equals, jujuparams.UserAccessPermission("admin"))
+}
+
+func (s *userManagerSuite) TestModelUserInfoUnauthorized(c *gc.C) {
+	conn := s.open(c, nil, "charlie")
+	defer conn.Close()
+
+	client := usermanager.NewClient(conn)
+	// The juju client discards the error on each result.
+	_, err := client.ModelUserInfo(s.Model.UUID.String)
+	c.Assert(err, gc.ErrorMatches, `unexpected nil result at position 0`)
 }
 
 func (s *userManagerSuite) TestUserInfoAllUsers(c *gc.C) {
@@ -35,6 +35,7 @@ const (
 type root interface {
 	rpc.Root
 	setPingF(func())
+	setCloseF(func())
 }
 
 // An apiServer is a jimmhttp.WSServer that serves the controller API.
@@ -89,6 +90,7 @@ func (s *apiServer) ServeWS(ctx context.Context, conn *websocket.Conn) {
 	s.cleanup = controllerRoot.cleanup
 	Dblogger := controllerRoot.newAuditLogger()
 	serveRoot(ctx, controllerRoot, Dblogger, conn)
+	controllerRoot.endSession()
 }
 
 // Kill implements the rpc.Killer interface.
@@ -121,6 +123,10 @@ func serveRoot(ctx context.Context, root root, logger jimm.DbAuditLogger, wsConn
 	})
 	defer t.Stop()
 	root.setPingF(func() { t.Reset(pingTimeout) })
+	// Closing the underlying websocket makes the RPC connection die
+	// without waiting for in-flight requests, which may include the one
+	// requesting the close.
+	root.setCloseF(func() { wsConn.Close() })
 	conn.Start(ctx)
 	<-conn.Dead()
 }
@@ -185,6 +191,7 @@ func (s apiProxier) ServeWS(ctx context.Context, clientConn *websocket.Conn) {
 		AuditLog:                auditLogger,
 		LoginService:            s.jimm,
 		AuthenticatedIdentityID: auth.SessionIdentityFromContext(ctx),
+		RegisterSession:         s.jimm.RegisterSession,
 	}
 	if err := jimmRPC.ProxySockets(ctx, proxyHelpers); err != nil {
 		zapctx.Error(ctx, "failed to start jimm model proxy", zap.Error(err))
@@ -64,6 +64,10 @@ func AuthenticateRebac(baseURL string, next http.Handler, jimm JIMMAuthner) http
 		user, err := jimm.UserLogin(ctx, identity)
 		if err != nil {
 			zapctx.Error(ctx, "failed to get openfga user", zap.Error(err))
+			if errors.ErrorCode(err) == errors.CodeUnauthorized {
+				http.Error(w, "failed to authenticate", http.StatusUnauthorized)
+				return
+			}
 			http.Error(w, "internal authentication error", http.StatusInternalServerError)
 			return
 		}
@@ -33,6 +33,7 @@ func TestAuthenticateRebac(t *testing.T) {
 		setupRequest           func() *http.Request
 		setupHandler           func() http.Handler
 		mockAuthBrowserSession func(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error)
+		userLoginErr           error
 		jimmAdmin              bool
 		expectedStatus         int
 		expectedBody           string
@@ -67,6 +68,15 @@ func TestAuthenticateRebac(t *testing.T) {
 			jimmAdmin:      false,
 			expectedStatus: http.StatusUnauthorized,
 		},
+		{
+			name: "disabled identity",
+			mockAuthBrowserSession: func(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error) {
+				return auth.ContextWithSessionIdentity(ctx, testUser), nil
+			},
+			userLoginErr:   jimm_errors.E(jimm_errors.CodeUnauthorized, "identity disabled"),
+			jimmAdmin:      true,
+			expectedStatus: http.StatusUnauthorized,
+		},
 		{
 			name: "should skip auth for /swagger.json",
 			setupRequest: func() *http.Request {
@@ -94,6 +104,9 @@ func TestAuthenticateRebac(t *testing.T) {
 					},
 				},
 				UserLogin_: func(ctx context.Context, username string) (*openfga.User, error) {
+					if tt.userLoginErr != nil {
+						return nil, tt.userLoginErr
+					}
 					user := dbmodel.Identity{Name: username}
 					return &openfga.User{Identity: &user, JimmAdmin: tt.jimmAdmin}, nil
 				},
@@ -52,3 +52,22 @@ func AuthorizeUserForModelAccess(next http.Handler, accessNeeded cofga.Relation)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// AuthorizeJIMMAdmin extracts the user from the context and checks that
+// they are a JIMM administrator.
+func AuthorizeJIMMAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := IdentityFromContext(r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+		if !user.JimmAdmin {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("user is not an admin"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
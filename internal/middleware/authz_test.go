@@ -96,3 +96,55 @@ func TestAuthorizeUserForModelAccess(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorizeJIMMAdmin(t *testing.T) {
+	c := qt.New(t)
+
+	handler := middleware.AuthorizeJIMMAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	alice, err := dbmodel.NewIdentity("alice@canonical.com")
+	c.Assert(err, qt.IsNil)
+	admin := openfga.NewUser(alice, nil)
+	admin.JimmAdmin = true
+	bob, err := dbmodel.NewIdentity("bob@canonical.com")
+	c.Assert(err, qt.IsNil)
+
+	tests := []struct {
+		name         string
+		user         *openfga.User
+		expectStatus int
+		expectBody   string
+	}{{
+		name:         "admin",
+		user:         admin,
+		expectStatus: http.StatusOK,
+		expectBody:   "ok",
+	}, {
+		name:         "not an admin",
+		user:         openfga.NewUser(bob, nil),
+		expectStatus: http.StatusForbidden,
+		expectBody:   "user is not an admin",
+	}, {
+		name:         "no user",
+		expectStatus: http.StatusUnauthorized,
+		expectBody:   "cannot extract user from context",
+	}}
+	for _, test := range tests {
+		c.Run(test.name, func(c *qt.C) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if test.user != nil {
+				req = req.WithContext(middleware.WithIdentity(req.Context(), test.user))
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			resp := w.Result()
+			defer resp.Body.Close()
+			c.Check(resp.StatusCode, qt.Equals, test.expectStatus)
+			body, err := io.ReadAll(resp.Body)
+			c.Assert(err, qt.IsNil)
+			c.Check(string(body), qt.Equals, test.expectBody)
+		})
+	}
+}
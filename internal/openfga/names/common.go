@@ -11,3 +11,9 @@ import (
 func WithMemberRelation(groupTag names.GroupTag) string {
 	return groupTag.String() + "#" + MemberRelation.String()
 }
+
+// WithAssigneeRelation is a convenience function for role tags to return the tag's string
+// with an assignee relation, commonly used when granting entitlements to a role.
+func WithAssigneeRelation(roleTag names.RoleTag) string {
+	return roleTag.String() + "#" + AssigneeRelation.String()
+}
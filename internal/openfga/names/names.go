@@ -35,13 +35,15 @@ var (
 	CanAddModelRelation cofga.Relation = "can_addmodel"
 	// AuditLogViewer represents an audit_log_viewer relation between entities.
 	AuditLogViewerRelation cofga.Relation = "audit_log_viewer"
+	// AssigneeRelation represents an assignee relation between entities.
+	AssigneeRelation cofga.Relation = "assignee"
 	// NoRelation is returned when there is no relation.
 	NoRelation cofga.Relation = ""
 )
 
 // allRelations contains a slice of all valid relations.
 // NB: Add any new relations from the above to this slice.
-var allRelations = []cofga.Relation{MemberRelation, AdministratorRelation, ControllerRelation, ModelRelation, ConsumerRelation, ReaderRelation, WriterRelation, CanAddModelRelation, AuditLogViewerRelation, NoRelation}
+var allRelations = []cofga.Relation{MemberRelation, AdministratorRelation, ControllerRelation, ModelRelation, ConsumerRelation, ReaderRelation, WriterRelation, CanAddModelRelation, AuditLogViewerRelation, AssigneeRelation, NoRelation}
 
 // EveryoneUser is the username representing all users and is treated uniquely when used in OpenFGA tuples.
 const EveryoneUser = "everyone@external"
@@ -59,7 +61,8 @@ type ResourceTagger interface {
 		names.ModelTag |
 		names.ApplicationOfferTag |
 		names.CloudTag |
-		jimmnames.ServiceAccountTag
+		jimmnames.ServiceAccountTag |
+		jimmnames.RoleTag
 
 	Id() string
 	Kind() string
@@ -105,7 +108,7 @@ func BlankKindTag(kind string) (*Tag, error) {
 	case names.UserTagKind, jimmnames.GroupTagKind,
 		names.ControllerTagKind, names.ModelTagKind,
 		names.ApplicationOfferTagKind, names.CloudTagKind,
-		jimmnames.ServiceAccountTagKind:
+		jimmnames.ServiceAccountTagKind, jimmnames.RoleTagKind:
 		return &Tag{
 			Kind: cofga.Kind(kind),
 		}, nil
@@ -164,6 +167,8 @@ func ParseRelation(relationString string) (cofga.Relation, error) {
 		return CanAddModelRelation, nil
 	case AuditLogViewerRelation.String():
 		return AuditLogViewerRelation, nil
+	case AssigneeRelation.String():
+		return AssigneeRelation, nil
 	default:
 		return cofga.Relation(""), errors.E(op, fmt.Sprintf("unknown relation %s", relationString))
 
@@ -17,7 +17,7 @@ import (
 
 var (
 	// resourceTypes contains a list of all resource kinds (i.e. tags) used throughout JIMM.
-	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind}
+	resourceTypes = [...]string{names.UserTagKind, names.ModelTagKind, names.ControllerTagKind, names.ApplicationOfferTagKind, jimmnames.GroupTagKind, jimmnames.ServiceAccountTagKind, jimmnames.RoleTagKind}
 )
 
 // Tuple represents a relation between an object and a target.
@@ -53,6 +53,8 @@ var (
 	UserType Kind = names.UserTagKind
 	// ServiceAccountType represents a service account.
 	ServiceAccountType Kind = jimmnames.ServiceAccountTagKind
+	// RoleType represents a role object.
+	RoleType Kind = jimmnames.RoleTagKind
 )
 
 // OFGAClient contains convenient utility methods for interacting
@@ -340,6 +342,72 @@ func (o *OFGAClient) RemoveGroup(ctx context.Context, group jimmnames.GroupTag)
 	return nil
 }
 
+// RemoveRole removes a role. All assignments of the role to users and
+// groups are removed, along with all the entitlements granted to the
+// role's assignees.
+func (o *OFGAClient) RemoveRole(ctx context.Context, role jimmnames.RoleTag) error {
+	// Remove all assignments of the role. I.e. user->role, group->role
+	if err := o.removeTuples(
+		ctx,
+		Tuple{
+			Relation: ofganames.AssigneeRelation,
+			Target:   ofganames.ConvertTag(role),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	// Next remove all entitlements that the role granted. I.e. role->model
+	for _, kind := range append(resourceTypes[:], names.CloudTagKind) {
+		kt, err := ofganames.BlankKindTag(kind)
+		if err != nil {
+			return errors.E(err)
+		}
+		err = o.removeTuples(ctx, Tuple{
+			Object: ofganames.ConvertTagWithRelation(role, ofganames.AssigneeRelation),
+			Target: kt,
+		})
+		if err != nil {
+			return errors.E(err)
+		}
+	}
+	return nil
+}
+
+// RemoveServiceAccount removes all administrator relations on a service
+// account. I.e. user->serviceaccount, group->serviceaccount.
+func (o *OFGAClient) RemoveServiceAccount(ctx context.Context, svcAcc jimmnames.ServiceAccountTag) error {
+	if err := o.removeTuples(
+		ctx,
+		Tuple{
+			Target: ofganames.ConvertTag(svcAcc),
+		},
+	); err != nil {
+		return errors.E(err)
+	}
+	return nil
+}
+
+// RemoveUser removes all access that a user has. I.e. user->model,
+// user->group.
+func (o *OFGAClient) RemoveUser(ctx context.Context, user names.UserTag) error {
+	// We need to loop through all resource types because the OpenFGA Read API does not provide
+	// means for only specifying a user resource, it must be paired with an object type.
+	for _, kind := range append(resourceTypes[:], names.CloudTagKind) {
+		kt, err := ofganames.BlankKindTag(kind)
+		if err != nil {
+			return errors.E(err)
+		}
+		err = o.removeTuples(ctx, Tuple{
+			Object: ofganames.ConvertTag(user),
+			Target: kt,
+		})
+		if err != nil {
+			return errors.E(err)
+		}
+	}
+	return nil
+}
+
 // RemoveCloud removes a cloud.
 func (o *OFGAClient) RemoveCloud(ctx context.Context, cloud names.CloudTag) error {
 	if err := o.removeTuples(
@@ -360,6 +360,68 @@ func (s *openFGATestSuite) TestRemoveGroup(c *gc.C) {
 	c.Assert(allowed, gc.Equals, false)
 }
 
+func (s *openFGATestSuite) TestRemoveRole(c *gc.C) {
+	role := jimmnames.NewRoleTag(uuid.NewString())
+	group := jimmnames.NewGroupTag(uuid.NewString())
+	alice := names.NewUserTag("alice@canonical.com")
+	adam := names.NewUserTag("adam@canonical.com")
+	model := names.NewModelTag(uuid.NewString())
+
+	tuples := []openfga.Tuple{{
+		Object:   ofganames.ConvertTag(alice),
+		Relation: ofganames.AssigneeRelation,
+		Target:   ofganames.ConvertTag(role),
+	}, {
+		Object:   ofganames.ConvertTag(adam),
+		Relation: ofganames.MemberRelation,
+		Target:   ofganames.ConvertTag(group),
+	}, {
+		Object:   ofganames.ConvertTagWithRelation(group, ofganames.MemberRelation),
+		Relation: ofganames.AssigneeRelation,
+		Target:   ofganames.ConvertTag(role),
+	}, {
+		Object:   ofganames.ConvertTagWithRelation(role, ofganames.AssigneeRelation),
+		Relation: ofganames.ReaderRelation,
+		Target:   ofganames.ConvertTag(model),
+	}}
+
+	err := s.ofgaClient.AddRelation(context.Background(), tuples...)
+	c.Assert(err, gc.Equals, nil)
+
+	for _, user := range []names.UserTag{alice, adam} {
+		allowed, err := s.ofgaClient.CheckRelation(
+			context.TODO(),
+			openfga.Tuple{
+				Object:   ofganames.ConvertTag(user),
+				Relation: ofganames.ReaderRelation,
+				Target:   ofganames.ConvertTag(model),
+			},
+			false,
+		)
+		c.Assert(err, gc.Equals, nil)
+		c.Assert(allowed, gc.Equals, true)
+	}
+
+	err = s.ofgaClient.RemoveRole(context.Background(), role)
+	c.Assert(err, gc.Equals, nil)
+
+	err = s.ofgaClient.RemoveRole(context.Background(), role)
+	c.Assert(err, gc.Equals, nil)
+
+	for _, user := range []names.UserTag{alice, adam} {
+		allowed, err := s.ofgaClient.CheckRelation(
+			context.TODO(),
+			openfga.Tuple{
+				Object:   ofganames.ConvertTag(user),
+				Relation: ofganames.ReaderRelation,
+				Target:   ofganames.ConvertTag(model),
+			},
+			false,
+		)
+		c.Assert(err, gc.Equals, nil)
+		c.Assert(allowed, gc.Equals, false)
+	}
+}
 func (s *openFGATestSuite) TestRemoveCloud(c *gc.C) {
 	cloud1 := names.NewCloudTag("cloud-1")
 
@@ -62,8 +62,8 @@ type WebsocketConnectionWithMetadata struct {
 // LoginService represents the LoginService interface used by the proxy.
 // Currently this is a duplicate of the [jujuapi.LoginService].
 type LoginService interface {
-	LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error)
-	GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
+	LoginDevice(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error)
+	GetDeviceSessionToken(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
 	LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error)
 	LoginWithSessionToken(ctx context.Context, sessionToken string) (*openfga.User, error)
 	LoginWithSessionCookie(ctx context.Context, identityID string) (*openfga.User, error)
@@ -78,6 +78,11 @@ type ProxyHelpers struct {
 	AuditLog                func(*dbmodel.AuditLogEntry)
 	LoginService            LoginService
 	AuthenticatedIdentityID string
+	// RegisterSession, if set, is called once the client has logged in to
+	// register the connection as a session of the logged in identity. The
+	// given close function tears down the client connection and the
+	// returned function is called when the proxy exits.
+	RegisterSession func(identityName string, closeF func()) (unregister func())
 }
 
 // ProxySockets will proxy requests from a client connection through to a controller
@@ -111,6 +116,7 @@ func ProxySockets(ctx context.Context, helpers ProxyHelpers) error {
 			conversationId:          utils.NewConversationID(),
 			loginService:            helpers.LoginService,
 			authenticatedIdentityID: helpers.AuthenticatedIdentityID,
+			registerSession:         helpers.RegisterSession,
 		},
 		errChan:              errChan,
 		createControllerConn: helpers.ConnectController,
@@ -135,6 +141,9 @@ func ProxySockets(ctx context.Context, helpers ProxyHelpers) error {
 	// connection to the controller fails and we want to trigger cleanup.
 	helpers.ConnClient.Close()
 	clProxy.wg.Wait()
+	if clProxy.unregisterSession != nil {
+		clProxy.unregisterSession()
+	}
 	return err
 }
 
@@ -247,8 +256,11 @@ type modelProxy struct {
 	modelName               string
 	conversationId          string
 	authenticatedIdentityID string
+	registerSession         func(identityName string, closeF func()) func()
+	unregisterSession       func()
 
 	deviceOAuthResponse *oauth2.DeviceAuthResponse
+	deviceProvider      string
 }
 
 func (p *modelProxy) sendError(socket *writeLockConn, req *message, err error) {
@@ -623,6 +635,12 @@ func (p *clientProxy) handleAdminFacade(ctx context.Context, msg *message) (clie
 		if err != nil {
 			return errorFnc(err)
 		}
+		if p.registerSession != nil {
+			if p.unregisterSession != nil {
+				p.unregisterSession()
+			}
+			p.unregisterSession = p.registerSession(user.Name, func() { p.src.conn.Close() })
+		}
 		data, err := json.Marshal(params.LoginRequest{
 			AuthTag: names.NewUserTag(user.Name).String(),
 			Token:   base64.StdEncoding.EncodeToString(jwt),
@@ -639,11 +657,18 @@ func (p *clientProxy) handleAdminFacade(ctx context.Context, msg *message) (clie
 	}
 	switch msg.Request {
 	case "LoginDevice":
-		deviceResponse, err := p.loginService.LoginDevice(ctx)
+		var request apiparams.LoginDeviceRequest
+		if len(msg.Params) > 0 {
+			if err := json.Unmarshal(msg.Params, &request); err != nil {
+				return errorFnc(err)
+			}
+		}
+		deviceResponse, err := p.loginService.LoginDevice(ctx, request.Provider)
 		if err != nil {
 			return errorFnc(err)
 		}
 		p.deviceOAuthResponse = deviceResponse
+		p.deviceProvider = request.Provider
 
 		data, err := json.Marshal(apiparams.LoginDeviceResponse{
 			VerificationURI: deviceResponse.VerificationURI,
@@ -655,7 +680,7 @@ func (p *clientProxy) handleAdminFacade(ctx context.Context, msg *message) (clie
 		msg.Response = data
 		return msg, nil, nil
 	case "GetDeviceSessionToken":
-		sessionToken, err := p.loginService.GetDeviceSessionToken(ctx, p.deviceOAuthResponse)
+		sessionToken, err := p.loginService.GetDeviceSessionToken(ctx, p.deviceProvider, p.deviceOAuthResponse)
 		if err != nil {
 			return errorFnc(err)
 		}
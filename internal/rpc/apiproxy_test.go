@@ -300,7 +300,7 @@ type mockLoginService struct {
 	clientSecret string
 }
 
-func (j *mockLoginService) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+func (j *mockLoginService) LoginDevice(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error) {
 	if j.err != nil {
 		return nil, j.err
 	}
@@ -313,7 +313,7 @@ func (j *mockLoginService) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthR
 		Interval:                int64(time.Minute.Seconds()),
 	}, nil
 }
-func (j *mockLoginService) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
+func (j *mockLoginService) GetDeviceSessionToken(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
 	if j.err != nil {
 		return "", j.err
 	}
@@ -12,6 +12,18 @@ import (
 )
 
 var (
+	AuditSinkDroppedCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "audit_sink",
+		Name:      "dropped_total",
+		Help:      "The number of audit log entries dropped by an audit sink.",
+	}, []string{"sink"})
+	AuditSinkErrorCount = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "jimm",
+		Subsystem: "audit_sink",
+		Name:      "error_total",
+		Help:      "The number of audit sink write errors.",
+	}, []string{"sink"})
 	AuthenticationFailCount = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: "jimm",
 		Subsystem: "auth",
@@ -131,6 +143,36 @@ var (
 		Name:      "controller",
 		Help:      "The number of controllers managed by JIMM.",
 	})
+	Leader = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "system",
+		Name:      "leader",
+		Help:      "Whether this JIMM replica is the leader, 1 if it is, 0 otherwise.",
+	})
+	ControllerHealthy = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "controller",
+		Name:      "healthy",
+		Help:      "Whether the controller is being watched and responding, 1 if healthy, 0 otherwise.",
+	}, []string{"controller"})
+	ControllerErrorStreak = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "controller",
+		Name:      "error_streak",
+		Help:      "The number of consecutive errors contacting the controller.",
+	}, []string{"controller"})
+	ControllerDialLatency = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "controller",
+		Name:      "dial_latency_seconds",
+		Help:      "The time taken to make the most recent connection to the controller.",
+	}, []string{"controller"})
+	ControllerLastPing = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "jimm",
+		Subsystem: "controller",
+		Name:      "last_ping_timestamp_seconds",
+		Help:      "The unix time of the most recent successful ping of the controller.",
+	}, []string{"controller"})
 	ResponseTimeHistogram = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Namespace: "jimm",
 		Name:      "http",
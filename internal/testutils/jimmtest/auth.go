@@ -85,7 +85,7 @@ func NewMockOAuthAuthenticator(c SimpleTester, testChan <-chan string) mockOAuth
 }
 
 // Device is a mock implementation for the start of the device flow, returning dummy polling data.
-func (m *mockOAuthAuthenticator) Device(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+func (m *mockOAuthAuthenticator) Device(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error) {
 	return &oauth2.DeviceAuthResponse{
 		DeviceCode:              "test-device-code",
 		UserCode:                "test-user-code",
@@ -98,7 +98,7 @@ func (m *mockOAuthAuthenticator) Device(ctx context.Context) (*oauth2.DeviceAuth
 
 // DeviceAccessToken is a mock implementation of the second step in the device flow where JIMM
 // polls an OIDC server for the device code.
-func (m *mockOAuthAuthenticator) DeviceAccessToken(ctx context.Context, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
+func (m *mockOAuthAuthenticator) DeviceAccessToken(ctx context.Context, provider string, res *oauth2.DeviceAuthResponse) (*oauth2.Token, error) {
 	select {
 	case username := <-m.PollingChan:
 		m.polledUsername = username
@@ -139,7 +139,7 @@ func (m *mockOAuthAuthenticator) VerifySessionToken(token string) (jwt.Token, er
 // ExtractAndVerifyIDToken returns an ID token where the subject is equal to the username obtained during the device flow.
 // The auth token must match the one returned during the device flow.
 // If the polled username is empty it indicates an error that the device flow was not run prior to calling this function.
-func (m *mockOAuthAuthenticator) ExtractAndVerifyIDToken(ctx context.Context, oauth2Token *oauth2.Token) (*oidc.IDToken, error) {
+func (m *mockOAuthAuthenticator) ExtractAndVerifyIDToken(ctx context.Context, provider string, oauth2Token *oauth2.Token) (*oidc.IDToken, error) {
 	if m.polledUsername == "" {
 		return &oidc.IDToken{}, errors.New("unknown user for mock auth login")
 	}
@@ -150,7 +150,7 @@ func (m *mockOAuthAuthenticator) ExtractAndVerifyIDToken(ctx context.Context, oa
 }
 
 // Email returns the subject from an ID token.
-func (m *mockOAuthAuthenticator) Email(idToken *oidc.IDToken) (string, error) {
+func (m *mockOAuthAuthenticator) Email(provider string, idToken *oidc.IDToken) (string, error) {
 	return idToken.Subject, nil
 }
 
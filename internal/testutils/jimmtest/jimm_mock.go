@@ -31,6 +31,7 @@ import (
 type JIMM struct {
 	mocks.RelationService
 	mocks.GroupService
+	mocks.RoleService
 	mocks.ControllerService
 	mocks.LoginService
 	mocks.ModelManager
@@ -42,8 +43,14 @@ type JIMM struct {
 	CheckPermission_                   func(ctx context.Context, user *openfga.User, cachedPerms map[string]string, desiredPerms map[string]interface{}) (map[string]string, error)
 	CopyServiceAccountCredential_      func(ctx context.Context, u *openfga.User, svcAcc *openfga.User, cloudCredentialTag names.CloudCredentialTag) (names.CloudCredentialTag, []jujuparams.UpdateCredentialModelResult, error)
 	DestroyOffer_                      func(ctx context.Context, user *openfga.User, offerURL string, force bool) error
+	DisableIdentity_                   func(ctx context.Context, user *openfga.User, identityName string) error
+	EnableIdentity_                    func(ctx context.Context, user *openfga.User, identityName string) error
 	FindApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	FindApplications_                  func(ctx context.Context, user *openfga.User, filter db.ModelApplicationFilter) ([]dbmodel.ModelApplication, error)
 	FindAuditEvents_                   func(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error)
+	FindCorrelatedAuditEvents_         func(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.CorrelatedAuditLogEntry, error)
+	FindMachines_                      func(ctx context.Context, user *openfga.User, filter db.ModelMachineFilter) ([]dbmodel.ModelMachine, error)
+	FindUnits_                         func(ctx context.Context, user *openfga.User, filter db.ModelUnitFilter) ([]dbmodel.ModelUnit, error)
 	ForEachCloud_                      func(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
 	ForEachUserCloud_                  func(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error
 	ForEachUserCloudCredential_        func(ctx context.Context, u *dbmodel.Identity, ct names.CloudTag, f func(cred *dbmodel.CloudCredential) error) error
@@ -68,18 +75,26 @@ type JIMM struct {
 	InitiateMigration_                 func(ctx context.Context, user *openfga.User, spec jujuparams.MigrationSpec) (jujuparams.InitiateMigrationResult, error)
 	InitiateInternalMigration_         func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetController string) (jujuparams.InitiateMigrationResult, error)
 	ListApplicationOffers_             func(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error)
+	ListMigrations_                    func(ctx context.Context, user *openfga.User, modelTag *names.ModelTag, active bool) ([]dbmodel.ModelMigration, error)
 	ListResources_                     func(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error)
+	ListServiceAccountAdministrators_  func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) ([]string, error)
+	ListServiceAccounts_               func(ctx context.Context, u *openfga.User) ([]string, error)
+	MigrationStatus_                   func(ctx context.Context, user *openfga.User, modelTag names.ModelTag) (*dbmodel.ModelMigration, error)
 	Offer_                             func(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error
 	PubSubHub_                         func() *pubsub.Hub
 	PurgeLogs_                         func(ctx context.Context, user *openfga.User, before time.Time) (int64, error)
+	RegisterSession_                   func(identityName string, closeF func()) func()
 	RemoveCloud_                       func(ctx context.Context, u *openfga.User, ct names.CloudTag) error
 	RemoveCloudFromController_         func(ctx context.Context, u *openfga.User, controllerName string, ct names.CloudTag) error
+	RemoveIdentity_                    func(ctx context.Context, user *openfga.User, identityName string) error
+	RemoveServiceAccount_              func(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error
 	ResourceTag_                       func() names.ControllerTag
 	RevokeAuditLogAccess_              func(ctx context.Context, user *openfga.User, targetUserTag names.UserTag) error
 	RevokeCloudAccess_                 func(ctx context.Context, user *openfga.User, ct names.CloudTag, ut names.UserTag, access string) error
 	RevokeCloudCredential_             func(ctx context.Context, user *dbmodel.Identity, tag names.CloudCredentialTag, force bool) error
 	RevokeModelAccess_                 func(ctx context.Context, user *openfga.User, mt names.ModelTag, ut names.UserTag, access jujuparams.UserAccessPermission) error
 	RevokeOfferAccess_                 func(ctx context.Context, user *openfga.User, offerURL string, ut names.UserTag, access jujuparams.OfferAccessPermission) (err error)
+	RotateJWKS_                        func(ctx context.Context, user *openfga.User) error
 	SetIdentityModelDefaults_          func(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error
 	ToJAASTag_                         func(ctx context.Context, tag *ofganames.Tag, resolveUUIDs bool) (string, error)
 	UpdateApplicationOffer_            func(ctx context.Context, controller *dbmodel.Controller, offerUUID string, removed bool) error
@@ -140,18 +155,54 @@ func (j *JIMM) DestroyOffer(ctx context.Context, user *openfga.User, offerURL st
 	}
 	return j.DestroyOffer_(ctx, user, offerURL, force)
 }
+func (j *JIMM) DisableIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	if j.DisableIdentity_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.DisableIdentity_(ctx, user, identityName)
+}
+func (j *JIMM) EnableIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	if j.EnableIdentity_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.EnableIdentity_(ctx, user, identityName)
+}
 func (j *JIMM) FindApplicationOffers(ctx context.Context, user *openfga.User, filters ...jujuparams.OfferFilter) ([]jujuparams.ApplicationOfferAdminDetailsV5, error) {
 	if j.FindApplicationOffers_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.FindApplicationOffers_(ctx, user, filters...)
 }
+func (j *JIMM) FindApplications(ctx context.Context, user *openfga.User, filter db.ModelApplicationFilter) ([]dbmodel.ModelApplication, error) {
+	if j.FindApplications_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.FindApplications_(ctx, user, filter)
+}
 func (j *JIMM) FindAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.AuditLogEntry, error) {
 	if j.FindAuditEvents_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.FindAuditEvents_(ctx, user, filter)
 }
+func (j *JIMM) FindCorrelatedAuditEvents(ctx context.Context, user *openfga.User, filter db.AuditLogFilter) ([]dbmodel.CorrelatedAuditLogEntry, error) {
+	if j.FindCorrelatedAuditEvents_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.FindCorrelatedAuditEvents_(ctx, user, filter)
+}
+func (j *JIMM) FindMachines(ctx context.Context, user *openfga.User, filter db.ModelMachineFilter) ([]dbmodel.ModelMachine, error) {
+	if j.FindMachines_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.FindMachines_(ctx, user, filter)
+}
+func (j *JIMM) FindUnits(ctx context.Context, user *openfga.User, filter db.ModelUnitFilter) ([]dbmodel.ModelUnit, error) {
+	if j.FindUnits_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.FindUnits_(ctx, user, filter)
+}
 func (j *JIMM) ForEachCloud(ctx context.Context, user *openfga.User, f func(*dbmodel.Cloud) error) error {
 	if j.ForEachCloud_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -301,12 +352,36 @@ func (j *JIMM) ListApplicationOffers(ctx context.Context, user *openfga.User, fi
 	}
 	return j.ListApplicationOffers_(ctx, user, filters...)
 }
+func (j *JIMM) ListMigrations(ctx context.Context, user *openfga.User, modelTag *names.ModelTag, active bool) ([]dbmodel.ModelMigration, error) {
+	if j.ListMigrations_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListMigrations_(ctx, user, modelTag, active)
+}
 func (j *JIMM) ListResources(ctx context.Context, user *openfga.User, filter pagination.LimitOffsetPagination, namePrefixFilter, typeFilter string) ([]db.Resource, error) {
 	if j.ListResources_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
 	return j.ListResources_(ctx, user, filter, namePrefixFilter, typeFilter)
 }
+func (j *JIMM) ListServiceAccountAdministrators(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) ([]string, error) {
+	if j.ListServiceAccountAdministrators_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListServiceAccountAdministrators_(ctx, u, svcAccTag)
+}
+func (j *JIMM) ListServiceAccounts(ctx context.Context, u *openfga.User) ([]string, error) {
+	if j.ListServiceAccounts_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ListServiceAccounts_(ctx, u)
+}
+func (j *JIMM) MigrationStatus(ctx context.Context, user *openfga.User, modelTag names.ModelTag) (*dbmodel.ModelMigration, error) {
+	if j.MigrationStatus_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.MigrationStatus_(ctx, user, modelTag)
+}
 func (j *JIMM) Offer(ctx context.Context, user *openfga.User, offer jimm.AddApplicationOfferParams) error {
 	if j.Offer_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -325,6 +400,12 @@ func (j *JIMM) PurgeLogs(ctx context.Context, user *openfga.User, before time.Ti
 	}
 	return j.PurgeLogs_(ctx, user, before)
 }
+func (j *JIMM) RegisterSession(identityName string, closeF func()) func() {
+	if j.RegisterSession_ == nil {
+		return func() {}
+	}
+	return j.RegisterSession_(identityName, closeF)
+}
 func (j *JIMM) RemoveCloud(ctx context.Context, u *openfga.User, ct names.CloudTag) error {
 	if j.RemoveCloud_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -337,6 +418,20 @@ func (j *JIMM) RemoveCloudFromController(ctx context.Context, u *openfga.User, c
 	}
 	return j.RemoveCloudFromController_(ctx, u, controllerName, ct)
 }
+func (j *JIMM) RemoveIdentity(ctx context.Context, user *openfga.User, identityName string) error {
+	if j.RemoveIdentity_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveIdentity_(ctx, user, identityName)
+}
+
+func (j *JIMM) RemoveServiceAccount(ctx context.Context, u *openfga.User, svcAccTag jimmnames.ServiceAccountTag) error {
+	if j.RemoveServiceAccount_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RemoveServiceAccount_(ctx, u, svcAccTag)
+}
+
 func (j *JIMM) ResourceTag() names.ControllerTag {
 	if j.ResourceTag_ == nil {
 		return names.NewControllerTag(uuid.NewString())
@@ -373,6 +468,12 @@ func (j *JIMM) RevokeOfferAccess(ctx context.Context, user *openfga.User, offerU
 	}
 	return j.RevokeOfferAccess_(ctx, user, offerURL, ut, access)
 }
+func (j *JIMM) RotateJWKS(ctx context.Context, user *openfga.User) error {
+	if j.RotateJWKS_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.RotateJWKS_(ctx, user)
+}
 func (j *JIMM) SetIdentityModelDefaults(ctx context.Context, user *dbmodel.Identity, configs map[string]interface{}) error {
 	if j.SetIdentityModelDefaults_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -9,19 +9,25 @@ import (
 
 	"github.com/canonical/jimm/v3/internal/dbmodel"
 	"github.com/canonical/jimm/v3/internal/errors"
+	"github.com/canonical/jimm/v3/internal/jimm"
 	"github.com/canonical/jimm/v3/internal/openfga"
 )
 
 // ControllerService is an implementation of the jujuapi.ControllerService interface.
 type ControllerService struct {
 	AddController_             func(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error
+	ControllerDrainStatus_     func(ctx context.Context, user *openfga.User, controllerName string) (*jimm.ControllerDrain, error)
 	ControllerInfo_            func(ctx context.Context, name string) (*dbmodel.Controller, error)
+	DrainController_           func(ctx context.Context, user *openfga.User, controllerName string, args jimm.DrainControllerArgs) (*jimm.ControllerDrain, error)
 	GetControllerConfig_       func(ctx context.Context, u *dbmodel.Identity) (*dbmodel.ControllerConfig, error)
 	EarliestControllerVersion_ func(ctx context.Context) (version.Number, error)
 	ListControllers_           func(ctx context.Context, user *openfga.User) ([]dbmodel.Controller, error)
 	RemoveController_          func(ctx context.Context, user *openfga.User, controllerName string, force bool) error
 	SetControllerConfig_       func(ctx context.Context, u *openfga.User, args jujuparams.ControllerConfigSet) error
 	SetControllerDeprecated_   func(ctx context.Context, user *openfga.User, controllerName string, deprecated bool) error
+	SetControllerDrainState_   func(ctx context.Context, user *openfga.User, controllerName string, state string) (*jimm.ControllerDrain, error)
+	SetControllerMaxModels_    func(ctx context.Context, user *openfga.User, controllerName string, maxModels uint) error
+	SetPlacementPolicy_        func(ctx context.Context, user *openfga.User, name string) error
 }
 
 func (j *ControllerService) AddController(ctx context.Context, u *openfga.User, ctl *dbmodel.Controller) error {
@@ -31,6 +37,13 @@ func (j *ControllerService) AddController(ctx context.Context, u *openfga.User,
 	return j.AddController_(ctx, u, ctl)
 }
 
+func (j *ControllerService) ControllerDrainStatus(ctx context.Context, user *openfga.User, controllerName string) (*jimm.ControllerDrain, error) {
+	if j.ControllerDrainStatus_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ControllerDrainStatus_(ctx, user, controllerName)
+}
+
 func (j *ControllerService) ControllerInfo(ctx context.Context, name string) (*dbmodel.Controller, error) {
 	if j.ControllerInfo_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
@@ -38,6 +51,13 @@ func (j *ControllerService) ControllerInfo(ctx context.Context, name string) (*d
 	return j.ControllerInfo_(ctx, name)
 }
 
+func (j *ControllerService) DrainController(ctx context.Context, user *openfga.User, controllerName string, args jimm.DrainControllerArgs) (*jimm.ControllerDrain, error) {
+	if j.DrainController_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.DrainController_(ctx, user, controllerName, args)
+}
+
 func (j *ControllerService) EarliestControllerVersion(ctx context.Context) (version.Number, error) {
 	if j.EarliestControllerVersion_ == nil {
 		return version.Number{}, errors.E(errors.CodeNotImplemented)
@@ -79,3 +99,24 @@ func (j *ControllerService) SetControllerDeprecated(ctx context.Context, user *o
 	}
 	return j.SetControllerDeprecated_(ctx, user, controllerName, deprecated)
 }
+
+func (j *ControllerService) SetControllerDrainState(ctx context.Context, user *openfga.User, controllerName string, state string) (*jimm.ControllerDrain, error) {
+	if j.SetControllerDrainState_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetControllerDrainState_(ctx, user, controllerName, state)
+}
+
+func (j *ControllerService) SetControllerMaxModels(ctx context.Context, user *openfga.User, controllerName string, maxModels uint) error {
+	if j.SetControllerMaxModels_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetControllerMaxModels_(ctx, user, controllerName, maxModels)
+}
+
+func (j *ControllerService) SetPlacementPolicy(ctx context.Context, user *openfga.User, name string) error {
+	if j.SetPlacementPolicy_ == nil {
+		return errors.E(errors.CodeNotImplemented)
+	}
+	return j.SetPlacementPolicy_(ctx, user, name)
+}
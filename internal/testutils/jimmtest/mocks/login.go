@@ -13,8 +13,8 @@ import (
 
 type LoginService struct {
 	AuthenticateBrowserSession_ func(ctx context.Context, w http.ResponseWriter, req *http.Request) (context.Context, error)
-	LoginDevice_                func(ctx context.Context) (*oauth2.DeviceAuthResponse, error)
-	GetDeviceSessionToken_      func(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
+	LoginDevice_                func(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error)
+	GetDeviceSessionToken_      func(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error)
 	LoginClientCredentials_     func(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error)
 	LoginWithSessionToken_      func(ctx context.Context, sessionToken string) (*openfga.User, error)
 	LoginWithSessionCookie_     func(ctx context.Context, identityID string) (*openfga.User, error)
@@ -27,18 +27,18 @@ func (j *LoginService) AuthenticateBrowserSession(ctx context.Context, w http.Re
 	return j.AuthenticateBrowserSession_(ctx, w, req)
 }
 
-func (j *LoginService) LoginDevice(ctx context.Context) (*oauth2.DeviceAuthResponse, error) {
+func (j *LoginService) LoginDevice(ctx context.Context, provider string) (*oauth2.DeviceAuthResponse, error) {
 	if j.LoginDevice_ == nil {
 		return nil, errors.E(errors.CodeNotImplemented)
 	}
-	return j.LoginDevice_(ctx)
+	return j.LoginDevice_(ctx, provider)
 }
 
-func (j *LoginService) GetDeviceSessionToken(ctx context.Context, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
+func (j *LoginService) GetDeviceSessionToken(ctx context.Context, provider string, deviceOAuthResponse *oauth2.DeviceAuthResponse) (string, error) {
 	if j.GetDeviceSessionToken_ == nil {
 		return "", errors.E(errors.CodeNotImplemented)
 	}
-	return j.GetDeviceSessionToken_(ctx, deviceOAuthResponse)
+	return j.GetDeviceSessionToken_(ctx, provider, deviceOAuthResponse)
 }
 
 func (j *LoginService) LoginClientCredentials(ctx context.Context, clientID string, clientSecret string) (*openfga.User, error) {
@@ -19,6 +19,7 @@ import (
 type ModelManager struct {
 	AddModel_               func(ctx context.Context, u *openfga.User, args *jimm.ModelCreateArgs) (*jujuparams.ModelInfo, error)
 	ChangeModelCredential_  func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, cloudCredentialTag names.CloudCredentialTag) error
+	CharmReport_            func(ctx context.Context, user *openfga.User, args jimm.CharmReportArgs) (params.CharmReport, error)
 	DestroyModel_           func(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error
 	DumpModel_              func(ctx context.Context, u *openfga.User, mt names.ModelTag, simplified bool) (string, error)
 	DumpModelDB_            func(ctx context.Context, u *openfga.User, mt names.ModelTag) (map[string]interface{}, error)
@@ -31,7 +32,10 @@ type ModelManager struct {
 	ModelDefaultsForCloud_  func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag) (jujuparams.ModelDefaultsResult, error)
 	ModelInfo_              func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelInfo, error)
 	ModelStatus_            func(ctx context.Context, u *openfga.User, mt names.ModelTag) (*jujuparams.ModelStatus, error)
-	QueryModelsJq_          func(ctx context.Context, models []string, jqQuery string) (params.CrossModelQueryResponse, error)
+	ModelUserInfo_          func(ctx context.Context, u *openfga.User, mt names.ModelTag) ([]jujuparams.ModelUserInfo, error)
+	PlaceModel_             func(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (*jimm.ModelPlacement, error)
+	QueryModels_            func(ctx context.Context, models []string, queryType, query string) (params.CrossModelQueryResponse, error)
+	SelectModels_           func(ctx context.Context, models []string, selector params.ModelSelector) ([]string, error)
 	SetModelDefaults_       func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) error
 	UnsetModelDefaults_     func(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, keys []string) error
 	UpdateMigratedModel_    func(ctx context.Context, user *openfga.User, modelTag names.ModelTag, targetControllerName string) error
@@ -53,6 +57,13 @@ func (j *ModelManager) ChangeModelCredential(ctx context.Context, user *openfga.
 	return j.ChangeModelCredential_(ctx, user, modelTag, cloudCredentialTag)
 }
 
+func (j *ModelManager) CharmReport(ctx context.Context, user *openfga.User, args jimm.CharmReportArgs) (params.CharmReport, error) {
+	if j.CharmReport_ == nil {
+		return params.CharmReport{}, errors.E(errors.CodeNotImplemented)
+	}
+	return j.CharmReport_(ctx, user, args)
+}
+
 func (j *ModelManager) DestroyModel(ctx context.Context, u *openfga.User, mt names.ModelTag, destroyStorage *bool, force *bool, maxWait *time.Duration, timeout *time.Duration) error {
 	if j.DestroyModel_ == nil {
 		return errors.E(errors.CodeNotImplemented)
@@ -128,11 +139,32 @@ func (j *ModelManager) ModelStatus(ctx context.Context, u *openfga.User, mt name
 	return j.ModelStatus_(ctx, u, mt)
 }
 
-func (j *ModelManager) QueryModelsJq(ctx context.Context, models []string, jqQuery string) (params.CrossModelQueryResponse, error) {
-	if j.QueryModelsJq_ == nil {
+func (j *ModelManager) ModelUserInfo(ctx context.Context, u *openfga.User, mt names.ModelTag) ([]jujuparams.ModelUserInfo, error) {
+	if j.ModelUserInfo_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.ModelUserInfo_(ctx, u, mt)
+}
+
+func (j *ModelManager) PlaceModel(ctx context.Context, user *openfga.User, args *jimm.ModelCreateArgs) (*jimm.ModelPlacement, error) {
+	if j.PlaceModel_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.PlaceModel_(ctx, user, args)
+}
+
+func (j *ModelManager) QueryModels(ctx context.Context, models []string, queryType, query string) (params.CrossModelQueryResponse, error) {
+	if j.QueryModels_ == nil {
 		return params.CrossModelQueryResponse{}, errors.E(errors.CodeNotImplemented)
 	}
-	return j.QueryModelsJq_(ctx, models, jqQuery)
+	return j.QueryModels_(ctx, models, queryType, query)
+}
+
+func (j *ModelManager) SelectModels(ctx context.Context, models []string, selector params.ModelSelector) ([]string, error) {
+	if j.SelectModels_ == nil {
+		return nil, errors.E(errors.CodeNotImplemented)
+	}
+	return j.SelectModels_(ctx, models, selector)
 }
 
 func (j *ModelManager) SetModelDefaults(ctx context.Context, user *dbmodel.Identity, cloudTag names.CloudTag, region string, configs map[string]interface{}) error {
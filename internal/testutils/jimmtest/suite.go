@@ -17,6 +17,7 @@ import (
 	corejujutesting "github.com/juju/juju/juju/testing"
 	jujuparams "github.com/juju/juju/rpc/params"
 	"github.com/juju/names/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
 	gc "gopkg.in/check.v1"
 
 	"github.com/canonical/jimm/v3/internal/db"
@@ -127,7 +128,7 @@ func (s *JIMMSuite) SetUpTest(c *gc.C) {
 
 	s.Server = httptest.NewServer(mux)
 
-	s.JIMM.JWKService = jimmjwx.NewJWKSService(s.JIMM.CredentialStore)
+	s.JIMM.JWKService = jimmjwx.NewJWKSService(s.JIMM.CredentialStore, jwa.RS256)
 	err = s.JIMM.JWKService.StartJWKSRotator(ctx, time.NewTicker(time.Hour).C, time.Now().UTC().AddDate(0, 3, 0))
 	c.Assert(err, gc.Equals, nil)
 
@@ -138,6 +139,7 @@ func (s *JIMMSuite) SetUpTest(c *gc.C) {
 		Store:  s.JIMM.CredentialStore,
 		Expiry: time.Minute,
 	})
+	s.JIMM.JWKService.OnRotate(s.JIMM.JWTService.InvalidateCache)
 	s.JIMM.Dialer = &jujuclient.Dialer{
 		ControllerCredentialsStore: s.JIMM.CredentialStore,
 		JWTService:                 s.JIMM.JWTService,
@@ -460,6 +460,30 @@ func (s *VaultStore) deleteControllerCredentials(ctx context.Context, controller
 	return nil
 }
 
+// Check checks that the vault server can be used, by looking up the
+// token JIMM is using and reading the JWKS expiry. A missing JWKS expiry
+// is not considered an error.
+func (s *VaultStore) Check(ctx context.Context) (err error) {
+	const op = errors.Op("vault.Check")
+
+	durationObserver := servermon.DurationObserver(servermon.VaultCallDurationHistogram, string(op))
+	defer durationObserver()
+	defer servermon.ErrorCounter(servermon.VaultCallErrorCount, &err, string(op))
+
+	client, err := s.client(ctx)
+	if err != nil {
+		return errors.E(op, err)
+	}
+	if _, err := client.Auth().Token().LookupSelfWithContext(ctx); err != nil {
+		return errors.E(op, err, "token lookup failed")
+	}
+	_, err = client.KVv2(s.KVPath).Get(ctx, s.getJWKSExpiryPath())
+	if err != nil && goerr.Unwrap(err) != api.ErrSecretNotFound {
+		return errors.E(op, err, "kv read failed")
+	}
+	return nil
+}
+
 const ttlLeeway time.Duration = 5 * time.Second
 
 func (s *VaultStore) client(ctx context.Context) (*api.Client, error) {
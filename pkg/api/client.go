@@ -53,6 +53,32 @@ func (c *Client) FindAuditEvents(req *params.FindAuditEventsRequest) (params.Aud
 	return resp, nil
 }
 
+// WatchAuditEvents starts a watcher for new audit events that match the
+// requested filters. The returned watcher ID should be used with
+// AuditEventWatcherNext and AuditEventWatcherStop.
+func (c *Client) WatchAuditEvents(req *params.FindAuditEventsRequest) (params.WatchAuditEventsResponse, error) {
+	var resp params.WatchAuditEventsResponse
+	if err := c.caller.APICall("JIMM", 4, "", "WatchAuditEvents", req, &resp); err != nil {
+		return params.WatchAuditEventsResponse{}, err
+	}
+	return resp, nil
+}
+
+// AuditEventWatcherNext waits for, and returns, the next set of audit
+// events from the watcher with the given ID.
+func (c *Client) AuditEventWatcherNext(watcherID string) (params.AuditEvents, error) {
+	var resp params.AuditEvents
+	if err := c.caller.APICall("AuditEventWatcher", 1, watcherID, "Next", nil, &resp); err != nil {
+		return params.AuditEvents{}, err
+	}
+	return resp, nil
+}
+
+// AuditEventWatcherStop stops the audit event watcher with the given ID.
+func (c *Client) AuditEventWatcherStop(watcherID string) error {
+	return c.caller.APICall("AuditEventWatcher", 1, watcherID, "Stop", nil, nil)
+}
+
 // GrantAuditLogAccess grants the given access to the audit log to the
 // given user.
 func (c *Client) GrantAuditLogAccess(req *params.AuditLogAccessRequest) error {
@@ -95,6 +121,48 @@ func (c *Client) SetControllerDeprecated(req *params.SetControllerDeprecatedRequ
 	return info, err
 }
 
+// SetControllerMaxModels sets the maximum number of models JIMM will place
+// on a controller.
+func (c *Client) SetControllerMaxModels(req *params.SetControllerMaxModelsRequest) (params.ControllerInfo, error) {
+	var info params.ControllerInfo
+	err := c.caller.APICall("JIMM", 4, "", "SetControllerMaxModels", req, &info)
+	return info, err
+}
+
+// SetPlacementPolicy sets the policy used to place new models.
+func (c *Client) SetPlacementPolicy(req *params.SetPlacementPolicyRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "SetPlacementPolicy", req, nil)
+}
+
+// DrainController migrates all models off a controller.
+func (c *Client) DrainController(req *params.DrainControllerRequest) (params.ControllerDrain, error) {
+	var drain params.ControllerDrain
+	err := c.caller.APICall("JIMM", 4, "", "DrainController", req, &drain)
+	return drain, err
+}
+
+// ControllerDrainStatus returns the progress of a controller drain.
+func (c *Client) ControllerDrainStatus(req *params.ControllerDrainStatusRequest) (params.ControllerDrain, error) {
+	var drain params.ControllerDrain
+	err := c.caller.APICall("JIMM", 4, "", "ControllerDrainStatus", req, &drain)
+	return drain, err
+}
+
+// SetControllerDrainState pauses, resumes or aborts a controller drain.
+func (c *Client) SetControllerDrainState(req *params.SetControllerDrainStateRequest) (params.ControllerDrain, error) {
+	var drain params.ControllerDrain
+	err := c.caller.APICall("JIMM", 4, "", "SetControllerDrainState", req, &drain)
+	return drain, err
+}
+
+// PlaceModel returns the controller that a model created with the given
+// arguments would be placed on, without creating the model.
+func (c *Client) PlaceModel(req *jujuparams.ModelCreateArgs) (params.ModelPlacement, error) {
+	var placement params.ModelPlacement
+	err := c.caller.APICall("JIMM", 4, "", "PlaceModel", req, &placement)
+	return placement, err
+}
+
 // FullModelStatus returns the full status of the juju model.
 func (c *Client) FullModelStatus(req *params.FullModelStatusRequest) (jujuparams.FullStatus, error) {
 	var status jujuparams.FullStatus
@@ -147,6 +215,49 @@ func (c *Client) ListGroups(req *params.ListGroupsRequest) ([]params.Group, erro
 	return resp.Groups, err
 }
 
+// Roles
+// AddRole adds the role to JIMM.
+func (c *Client) AddRole(req *params.AddRoleRequest) (params.AddRoleResponse, error) {
+	var resp params.AddRoleResponse
+	err := c.caller.APICall("JIMM", 4, "", "AddRole", req, &resp)
+	return resp, err
+}
+
+// GetRole returns the role with the given UUID or name. Only one should be provided.
+func (c *Client) GetRole(req *params.GetRoleRequest) (params.GetRoleResponse, error) {
+	var resp params.GetRoleResponse
+	err := c.caller.APICall("JIMM", 4, "", "GetRole", req, &resp)
+	return resp, err
+}
+
+// RenameRole renames a role in JIMM.
+func (c *Client) RenameRole(req *params.RenameRoleRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RenameRole", req, nil)
+}
+
+// RemoveRole removes a role in JIMM.
+func (c *Client) RemoveRole(req *params.RemoveRoleRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveRole", req, nil)
+}
+
+// ListRoles lists the roles in JIMM.
+func (c *Client) ListRoles(req *params.ListRolesRequest) ([]params.Role, error) {
+	var resp params.ListRolesResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListRoles", req, &resp)
+	return resp.Roles, err
+}
+
+// DisableIdentity disables an identity in JIMM, preventing it from
+// logging in.
+func (c *Client) DisableIdentity(req *params.DisableIdentityRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "DisableIdentity", req, nil)
+}
+
+// EnableIdentity re-enables a disabled identity in JIMM.
+func (c *Client) EnableIdentity(req *params.EnableIdentityRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "EnableIdentity", req, nil)
+}
+
 // Tuple management
 
 // AddRelation adds a relational tuple in JIMM.
@@ -193,6 +304,11 @@ func (c *Client) PurgeLogs(req *params.PurgeLogsRequest) (*params.PurgeLogsRespo
 	return &response, err
 }
 
+// RotateJWKS immediately replaces all of the keys JIMM signs JWTs with.
+func (c *Client) RotateJWKS() error {
+	return c.caller.APICall("JIMM", 4, "", "RotateJWKS", nil, nil)
+}
+
 // MigrateModel migrates a model between two controllers that are attached to JIMM.
 func (c *Client) MigrateModel(req *params.MigrateModelRequest) (*jujuparams.InitiateMigrationResults, error) {
 	var response jujuparams.InitiateMigrationResults
@@ -200,6 +316,48 @@ func (c *Client) MigrateModel(req *params.MigrateModelRequest) (*jujuparams.Init
 	return &response, err
 }
 
+// ListMigrations lists the model migrations initiated by JIMM.
+func (c *Client) ListMigrations(req *params.ListMigrationsRequest) (params.ListMigrationsResponse, error) {
+	var response params.ListMigrationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListMigrations", req, &response)
+	return response, err
+}
+
+// MigrationStatus returns the most recent migration of a model.
+func (c *Client) MigrationStatus(req *params.MigrationStatusRequest) (params.MigrationInfo, error) {
+	var response params.MigrationInfo
+	err := c.caller.APICall("JIMM", 4, "", "MigrationStatus", req, &response)
+	return response, err
+}
+
+// CharmReport reports the charms deployed across all controllers.
+func (c *Client) CharmReport(req *params.CharmReportRequest) (params.CharmReport, error) {
+	var response params.CharmReport
+	err := c.caller.APICall("JIMM", 4, "", "CharmReport", req, &response)
+	return response, err
+}
+
+// FindApplications finds applications in JIMM's inventory.
+func (c *Client) FindApplications(req *params.FindApplicationsRequest) (params.FindApplicationsResponse, error) {
+	var response params.FindApplicationsResponse
+	err := c.caller.APICall("JIMM", 4, "", "FindApplications", req, &response)
+	return response, err
+}
+
+// FindUnits finds units in JIMM's inventory.
+func (c *Client) FindUnits(req *params.FindUnitsRequest) (params.FindUnitsResponse, error) {
+	var response params.FindUnitsResponse
+	err := c.caller.APICall("JIMM", 4, "", "FindUnits", req, &response)
+	return response, err
+}
+
+// FindMachines finds machines in JIMM's inventory.
+func (c *Client) FindMachines(req *params.FindMachinesRequest) (params.FindMachinesResponse, error) {
+	var response params.FindMachinesResponse
+	err := c.caller.APICall("JIMM", 4, "", "FindMachines", req, &response)
+	return response, err
+}
+
 // AddServiceAccount binds a service account to a user allowing them to manage it.
 func (c *Client) AddServiceAccount(req *params.AddServiceAccountRequest) error {
 	return c.caller.APICall("JIMM", 4, "", "AddServiceAccount", req, nil)
@@ -231,6 +389,28 @@ func (c *Client) GrantServiceAccountAccess(req *params.GrantServiceAccountAccess
 	return c.caller.APICall("JIMM", 4, "", "GrantServiceAccountAccess", req, nil)
 }
 
+// ListServiceAccounts lists the service accounts administered by the
+// authenticated user.
+func (c *Client) ListServiceAccounts() ([]params.ServiceAccount, error) {
+	var response params.ListServiceAccountsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListServiceAccounts", nil, &response)
+	return response.ServiceAccounts, err
+}
+
+// RemoveServiceAccount removes a service account along with its cloud
+// credentials and relations.
+func (c *Client) RemoveServiceAccount(req *params.RemoveServiceAccountRequest) error {
+	return c.caller.APICall("JIMM", 4, "", "RemoveServiceAccount", req, nil)
+}
+
+// ListServiceAccountAdministrators lists the identities and groups that
+// administer a service account.
+func (c *Client) ListServiceAccountAdministrators(req *params.ListServiceAccountAdministratorsRequest) ([]string, error) {
+	var response params.ListServiceAccountAdministratorsResponse
+	err := c.caller.APICall("JIMM", 4, "", "ListServiceAccountAdministrators", req, &response)
+	return response.Administrators, err
+}
+
 // Version returns version info of the controller.
 func (c *Client) Version() (params.VersionResponse, error) {
 	var response params.VersionResponse
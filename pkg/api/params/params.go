@@ -132,6 +132,15 @@ type AuditEvent struct {
 
 	// Errors contains error info received from the controller.
 	Errors map[string]any `json:"errors,omitempty" yaml:"errors,omitempty"`
+
+	// ResponseTime is the time of the response to a request. It is only
+	// set on correlated events that have a recorded response.
+	ResponseTime *time.Time `json:"response-time,omitempty" yaml:"response-time,omitempty"`
+
+	// Latency is the time taken to respond to a request, formatted as
+	// a Go duration. It is only set on correlated events that have a
+	// recorded response.
+	Latency string `json:"latency,omitempty" yaml:"latency,omitempty"`
 }
 
 // An AuditEvents contains events from the audit log.
@@ -176,8 +185,75 @@ type ControllerInfo struct {
 	AgentVersion string `json:"agent-version"`
 
 	// Status contains the current status of the controller. The status
-	// will either be "available", "deprecated", or "unavailable".
+	// will either be "available", "degraded", "deprecated", or
+	// "unavailable".
 	Status jujuparams.EntityStatus `json:"status"`
+
+	// Health contains the most recently observed health of the
+	// controller, if it has been recorded.
+	Health *ControllerHealth `json:"health,omitempty"`
+}
+
+// ControllerHealth holds the health of a controller as observed by
+// JIMM's controller watcher.
+type ControllerHealth struct {
+	// Healthy is true if the controller is being watched and the most
+	// recent contact with it succeeded.
+	Healthy bool `json:"healthy" yaml:"healthy"`
+
+	// WatcherState is the state of JIMM's watcher for the controller,
+	// either "connecting", "watching" or "stopped".
+	WatcherState string `json:"watcher-state,omitempty" yaml:"watcher-state,omitempty"`
+
+	// AgentVersion is the agent version reported by the controller the
+	// last time JIMM connected to it.
+	AgentVersion string `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
+
+	// DialLatencyMS is the time, in milliseconds, taken to make the most
+	// recent successful connection to the controller.
+	DialLatencyMS int64 `json:"dial-latency-ms" yaml:"dial-latency-ms"`
+
+	// LastDial is the time of the most recent successful connection.
+	LastDial *time.Time `json:"last-dial,omitempty" yaml:"last-dial,omitempty"`
+
+	// PingLatencyMS is the round-trip time, in milliseconds, of the most
+	// recent successful ping.
+	PingLatencyMS int64 `json:"ping-latency-ms" yaml:"ping-latency-ms"`
+
+	// LastPing is the time of the most recent successful ping.
+	LastPing *time.Time `json:"last-ping,omitempty" yaml:"last-ping,omitempty"`
+
+	// ErrorStreak is the number of consecutive failed connections, pings
+	// or watcher errors.
+	ErrorStreak int `json:"error-streak" yaml:"error-streak"`
+
+	// DegradedSince is the time of the first failure in the current
+	// error streak.
+	DegradedSince *time.Time `json:"degraded-since,omitempty" yaml:"degraded-since,omitempty"`
+
+	// LastError is the most recent error.
+	LastError string `json:"last-error,omitempty" yaml:"last-error,omitempty"`
+
+	// UpdatedAt is the time the health was last updated.
+	UpdatedAt time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// ControllerHealthEvent records a change in the health of a controller.
+type ControllerHealthEvent struct {
+	Time         time.Time `json:"time" yaml:"time"`
+	WatcherState string    `json:"watcher-state,omitempty" yaml:"watcher-state,omitempty"`
+	Healthy      bool      `json:"healthy" yaml:"healthy"`
+	Error        string    `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ControllerHealthReport holds the health of a controller along with
+// its recent health history.
+type ControllerHealthReport struct {
+	Name    string                  `json:"name"`
+	UUID    string                  `json:"uuid"`
+	Status  string                  `json:"status"`
+	Health  *ControllerHealth       `json:"health,omitempty"`
+	History []ControllerHealthEvent `json:"history,omitempty"`
 }
 
 // A FindAuditEventsRequest finds audit events that match the specified
@@ -205,6 +281,33 @@ type FindAuditEventsRequest struct {
 	// called a specific facade method.
 	Method string `json:"method,omitempty"`
 
+	// FacadeName is used to filter the event log to only contain events
+	// that called a method on a specific facade.
+	FacadeName string `json:"facade-name,omitempty"`
+
+	// ConversationId is used to filter the event log to only contain
+	// events from a specific conversation.
+	ConversationId string `json:"conversation-id,omitempty"`
+
+	// Type is used to filter the event log to only contain requests or
+	// responses. Valid values are "request" and "response", if this is
+	// empty both requests and responses are returned.
+	Type string `json:"type,omitempty"`
+
+	// ErrorsOnly is used to filter the event log to only contain
+	// responses that contain at least one error.
+	ErrorsOnly bool `json:"errors-only,omitempty"`
+
+	// ParamsPath is used to filter the event log to only contain events
+	// whose parameters match the given SQL/JSON path predicate, for
+	// example `$.applications[*].application == "postgresql"`.
+	ParamsPath string `json:"params-path,omitempty"`
+
+	// ParamsSearch is used to filter the event log to only contain
+	// events where the string values in the parameters match all the
+	// words in the given text.
+	ParamsSearch string `json:"params-search,omitempty"`
+
 	// Offset is the number of items to offset the set of returned results.
 	Offset int `json:"offset,omitempty"`
 
@@ -214,6 +317,31 @@ type FindAuditEventsRequest struct {
 	// SortTime will sort by most recent (time descending) when true.
 	// When false no explicit ordering will be applied.
 	SortTime bool `json:"sortTime,omitempty"`
+
+	// Correlate combines each request with its response, if any, into
+	// a single event containing the request parameters, the response
+	// errors and the latency of the call. Correlated events cannot be
+	// filtered by Type and ErrorsOnly matches requests whose response
+	// contains an error.
+	Correlate bool `json:"correlate,omitempty"`
+}
+
+const (
+	// AuditEventTypeRequest is the FindAuditEventsRequest Type used to
+	// find only request events.
+	AuditEventTypeRequest = "request"
+
+	// AuditEventTypeResponse is the FindAuditEventsRequest Type used to
+	// find only response events.
+	AuditEventTypeResponse = "response"
+)
+
+// A WatchAuditEventsResponse contains the ID of an AuditEventWatcher
+// created by WatchAuditEvents.
+type WatchAuditEventsResponse struct {
+	// WatcherID is the ID of the created watcher, it is used as the
+	// object ID in calls to the AuditEventWatcher facade.
+	WatcherID string `json:"watcher-id"`
 }
 
 // A ListControllersResponse is the response that is sent in a
@@ -240,6 +368,36 @@ type SetControllerDeprecatedRequest struct {
 	Deprecated bool `json:"deprecated"`
 }
 
+// A SetControllerMaxModelsRequest is the request sent in a
+// SetControllerMaxModels method.
+type SetControllerMaxModelsRequest struct {
+	// Name is the name of the controller.
+	Name string `json:"name"`
+
+	// MaxModels is the maximum number of models JIMM will place on the
+	// controller. A value of 0 removes the limit.
+	MaxModels uint `json:"max-models"`
+}
+
+// A SetPlacementPolicyRequest is the request sent in a SetPlacementPolicy
+// method.
+type SetPlacementPolicyRequest struct {
+	// Policy is the name of the placement policy to use.
+	Policy string `json:"policy"`
+}
+
+// A ModelPlacement describes where a new model would be placed.
+type ModelPlacement struct {
+	// Policy is the name of the placement policy that was used.
+	Policy string `json:"policy" yaml:"policy"`
+
+	// Controller is the name of the controller that would host the model.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// CloudRegion is the cloud region the model would be created in.
+	CloudRegion string `json:"cloud-region" yaml:"cloud-region"`
+}
+
 // FullModelStatusRequest is the request that is sent in a FullModelStatus method.
 type FullModelStatusRequest struct {
 	ModelTag string
@@ -272,6 +430,20 @@ type ImportModelRequest struct {
 	Owner string `json:"owner"`
 }
 
+// A DisableIdentityRequest is the request that is sent in a
+// DisableIdentity method.
+type DisableIdentityRequest struct {
+	// Name holds the name of the identity to disable.
+	Name string `json:"name"`
+}
+
+// An EnableIdentityRequest is the request that is sent in an
+// EnableIdentity method.
+type EnableIdentityRequest struct {
+	// Name holds the name of the identity to enable.
+	Name string `json:"name"`
+}
+
 // Authorisation request parameters / responses:
 
 // AddGroupRequest holds a request to add a group.
@@ -324,6 +496,10 @@ type Group struct {
 	Name      string `json:"name" yaml:"name"`
 	CreatedAt string `json:"created_at" yaml:"created_at"`
 	UpdatedAt string `json:"updated_at" yaml:"updated_at"`
+
+	// ExternallyManaged is true if the group's members are managed by
+	// the identity provider.
+	ExternallyManaged bool `json:"externally_managed,omitempty" yaml:"externally_managed,omitempty"`
 }
 
 // ListGroupResponse returns the group tuples currently residing within OpenFGA.
@@ -331,6 +507,64 @@ type ListGroupResponse struct {
 	Groups []Group `json:"name" yaml:"name"`
 }
 
+// AddRoleRequest holds a request to add a role.
+type AddRoleRequest struct {
+	// Name holds the name of the role.
+	Name string `json:"name"`
+}
+
+// AddRoleResponse holds the details of the added role.
+type AddRoleResponse struct {
+	Role
+}
+
+// GetRoleRequest holds a request to get a role by UUID or name.
+type GetRoleRequest struct {
+	// UUID holds the UUID of the role to be retrieved.
+	UUID string `json:"uuid"`
+	// Name holds the name of the role to be retrieved.
+	Name string `json:"name"`
+}
+
+// GetRoleResponse holds the details of the role.
+type GetRoleResponse struct {
+	Role
+}
+
+// RenameRoleRequest holds a request to rename a role.
+type RenameRoleRequest struct {
+	// Name holds the name of the role.
+	Name string `json:"name"`
+
+	// NewName holds the new name of the role.
+	NewName string `json:"new-name"`
+}
+
+// RemoveRoleRequest holds a request to remove a role.
+type RemoveRoleRequest struct {
+	// Name holds the name of the role.
+	Name string `json:"name"`
+}
+
+// ListRolesRequest holds a request to list a page of roles.
+type ListRolesRequest struct {
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+// Role holds the details of a role currently residing in JIMM.
+type Role struct {
+	UUID      string `json:"uuid" yaml:"uuid"`
+	Name      string `json:"name" yaml:"name"`
+	CreatedAt string `json:"created_at" yaml:"created_at"`
+	UpdatedAt string `json:"updated_at" yaml:"updated_at"`
+}
+
+// ListRolesResponse holds the roles currently residing in JIMM.
+type ListRolesResponse struct {
+	Roles []Role `json:"roles" yaml:"roles"`
+}
+
 // RelationshipTuple represents a OpenFGA Tuple.
 type RelationshipTuple struct {
 	// Object represents an OFGA object that we wish to apply a relational tuple to.
@@ -381,18 +615,54 @@ type ListRelationshipTuplesResponse struct {
 
 // CrossModelQueryRequest holds the parameters to perform a cross model query against
 // JSON model statuses for every model this user has access to.
+//
+// If Limit is greater than zero only that many models are queried,
+// starting at Offset in the list of models ordered by UUID.
+//
+// Only models matching the Selector are queried.
 type CrossModelQueryRequest struct {
-	Type  string `json:"type"`
-	Query string `json:"query"`
+	Type     string        `json:"type"`
+	Query    string        `json:"query"`
+	Limit    int           `json:"limit,omitempty"`
+	Offset   int           `json:"offset,omitempty"`
+	Selector ModelSelector `json:"selector"`
+}
+
+// A ModelSelector selects a subset of models. A model must match every
+// non-empty field to be selected.
+type ModelSelector struct {
+	// Controller is the name of the controller hosting the model.
+	Controller string `json:"controller,omitempty"`
+
+	// Cloud is the name of the cloud hosting the model.
+	Cloud string `json:"cloud,omitempty"`
+
+	// Region is the name of the cloud region hosting the model.
+	Region string `json:"region,omitempty"`
+
+	// Owner is the name of the model's owner.
+	Owner string `json:"owner,omitempty"`
+
+	// Name is a glob pattern, using the syntax of path.Match, that
+	// the model name must match.
+	Name string `json:"name,omitempty"`
+
+	// Group is the name of a JIMM group that must have administrator
+	// access to the model.
+	Group string `json:"group,omitempty"`
 }
 
 // CrossModelJqQueryResponse holds results for a cross-model query that has been filtered utilising JQ.
 // It has two fields:
 //   - Results - A map of each iterated JQ output result. The key for this map is the model UUID.
 //   - Errors - A map of each iterated JQ *or* Status call error. The key for this map is the model UUID.
+//
+// When the request is paginated Total holds the total number of models
+// that can be queried.
 type CrossModelQueryResponse struct {
 	Results map[string][]any    `json:"results" yaml:"results"`
 	Errors  map[string][]string `json:"errors" yaml:"errors"`
+	Total   int                 `json:"total,omitempty" yaml:"total,omitempty"`
 }
 
 // PurgeLogsRequest is the request used to purge logs.
@@ -423,6 +693,150 @@ type MigrateModelRequest struct {
 	Specs []MigrateModelInfo `json:"specs"`
 }
 
+// MigrationInfo holds the details of a model migration between two
+// controllers within JIMM.
+type MigrationInfo struct {
+	// ID is JIMM's ID for the migration.
+	ID uint `json:"id" yaml:"id"`
+
+	// MigrationID is the ID of the migration on the source controller.
+	MigrationID string `json:"migration-id,omitempty" yaml:"migration-id,omitempty"`
+
+	// ModelTag is the tag of the migrating model.
+	ModelTag string `json:"model-tag" yaml:"model-tag"`
+
+	// ModelName is the name of the migrating model.
+	ModelName string `json:"model-name" yaml:"model-name"`
+
+	// SourceController is the name of the controller the model is
+	// migrating from.
+	SourceController string `json:"source-controller" yaml:"source-controller"`
+
+	// TargetController is the name of the controller the model is
+	// migrating to.
+	TargetController string `json:"target-controller" yaml:"target-controller"`
+
+	// InitiatedBy is the name of the identity that initiated the
+	// migration.
+	InitiatedBy string `json:"initiated-by,omitempty" yaml:"initiated-by,omitempty"`
+
+	// Phase is the phase of the migration, one of "running", "done",
+	// "aborted" or "failed".
+	Phase string `json:"phase" yaml:"phase"`
+
+	// Status is the latest status message reported by the source
+	// controller.
+	Status string `json:"status,omitempty" yaml:"status,omitempty"`
+
+	// StartedAt is the time the migration was initiated.
+	StartedAt time.Time `json:"started-at" yaml:"started-at"`
+
+	// FinishedAt is the time the migration finished.
+	FinishedAt *time.Time `json:"finished-at,omitempty" yaml:"finished-at,omitempty"`
+
+	// Error holds the reason the migration did not complete.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ListMigrationsRequest holds the parameters used to list model
+// migrations.
+type ListMigrationsRequest struct {
+	// ModelTag, if specified, limits the migrations to those of the
+	// given model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Active, if true, limits the migrations to those that have not
+	// finished.
+	Active bool `json:"active,omitempty"`
+}
+
+// ListMigrationsResponse holds a list of model migrations.
+type ListMigrationsResponse struct {
+	Migrations []MigrationInfo `json:"migrations" yaml:"migrations"`
+}
+
+// MigrationStatusRequest holds the parameters used to get the status of
+// the most recent migration of a model.
+type MigrationStatusRequest struct {
+	// ModelTag is the tag of the model.
+	ModelTag string `json:"model-tag"`
+}
+
+// DrainControllerRequest holds the parameters used to drain all models
+// off a controller.
+type DrainControllerRequest struct {
+	// Controller is the name of the controller to drain.
+	Controller string `json:"controller"`
+
+	// Concurrency is the maximum number of models that are migrated at
+	// the same time. If this is less than 1 models are migrated one at a
+	// time.
+	Concurrency int `json:"concurrency,omitempty"`
+
+	// DryRun, if true, only reports the controller each model would be
+	// migrated to.
+	DryRun bool `json:"dry-run,omitempty"`
+}
+
+// ControllerDrainStatusRequest holds the parameters used to get the
+// progress of a controller drain.
+type ControllerDrainStatusRequest struct {
+	// Controller is the name of the controller being drained.
+	Controller string `json:"controller"`
+}
+
+// SetControllerDrainStateRequest holds the parameters used to pause,
+// resume or abort a controller drain.
+type SetControllerDrainStateRequest struct {
+	// Controller is the name of the controller being drained.
+	Controller string `json:"controller"`
+
+	// State is the new state of the drain, one of "paused", "running"
+	// or "aborted".
+	State string `json:"state"`
+}
+
+// ControllerDrain holds the progress of draining a controller.
+type ControllerDrain struct {
+	// Controller is the name of the controller being drained.
+	Controller string `json:"controller" yaml:"controller"`
+
+	// State is the state of the drain, one of "planned", "running",
+	// "paused", "aborted" or "done".
+	State string `json:"state" yaml:"state"`
+
+	// Models holds the progress of each model on the controller.
+	Models []DrainModel `json:"models" yaml:"models"`
+}
+
+// DrainModel holds the progress of migrating a single model while
+// draining a controller.
+type DrainModel struct {
+	// ModelTag is the tag of the model.
+	ModelTag string `json:"model-tag" yaml:"model-tag"`
+
+	// ModelName is the name of the model.
+	ModelName string `json:"model-name" yaml:"model-name"`
+
+	// TargetController is the name of the controller the model is
+	// migrated to.
+	TargetController string `json:"target-controller,omitempty" yaml:"target-controller,omitempty"`
+
+	// Status is the status of the model, one of "pending", "migrating",
+	// "done", "failed" or "skipped".
+	Status string `json:"status" yaml:"status"`
+
+	// Error holds the reason the model was skipped or failed.
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// LoginDeviceRequest holds a request to start a LoginDevice flow.
+type LoginDeviceRequest struct {
+	// Provider is the name of the identity provider to log in with. If
+	// empty the default identity provider is used.
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
+}
+
 // LoginDeviceResponse holds the details to complete a LoginDevice flow.
 type LoginDeviceResponse struct {
 	// VerificationURI holds the URI that the user must navigate to
@@ -505,10 +919,44 @@ type GrantServiceAccountAccess struct {
 	ClientID string `json:"client-id"`
 }
 
+// ServiceAccount holds the details of a service account.
+type ServiceAccount struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id" yaml:"client-id"`
+}
+
+// ListServiceAccountsResponse holds the service accounts administered
+// by the authenticated user.
+type ListServiceAccountsResponse struct {
+	ServiceAccounts []ServiceAccount `json:"service-accounts" yaml:"service-accounts"`
+}
+
+// RemoveServiceAccountRequest holds a request to remove a service account.
+type RemoveServiceAccountRequest struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+}
+
+// ListServiceAccountAdministratorsRequest holds a request to list
+// the administrators of a service account.
+type ListServiceAccountAdministratorsRequest struct {
+	// ClientID holds the client id of the service account.
+	ClientID string `json:"client-id"`
+}
+
+// ListServiceAccountAdministratorsResponse holds the identities and
+// groups that administer a service account.
+type ListServiceAccountAdministratorsResponse struct {
+	Administrators []string `json:"administrators" yaml:"administrators"`
+}
+
 // WhoamiResponse holds the response for a /auth/whoami call.
 type WhoamiResponse struct {
 	DisplayName string `json:"display-name" yaml:"display-name"`
 	Email       string `json:"email" yaml:"email"`
+	// Provider is the name of the identity provider the user logged in
+	// with.
+	Provider string `json:"provider,omitempty" yaml:"provider,omitempty"`
 }
 
 // VersionResponse holds the response for a version call.
@@ -516,3 +964,192 @@ type VersionResponse struct {
 	Version string `json:"version" yaml:"version"`
 	Commit  string `json:"commit" yaml:"commit"`
 }
+
+// FindApplicationsRequest holds the filters used to find applications
+// in JIMM's inventory. Applications must match every non-empty filter.
+type FindApplicationsRequest struct {
+	// ModelTag restricts the search to the model with the given tag.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Name is the name of the application.
+	Name string `json:"name,omitempty"`
+
+	// CharmName is the name of the application's charm.
+	CharmName string `json:"charm-name,omitempty"`
+
+	// CharmRevisionBelow, if non-zero, matches only applications with a
+	// charm revision less than this value.
+	CharmRevisionBelow int `json:"charm-revision-below,omitempty"`
+
+	// Status is the current status of the application.
+	Status string `json:"status,omitempty"`
+
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// ApplicationInfo holds the details of an application in JIMM's
+// inventory.
+type ApplicationInfo struct {
+	ModelTag        string    `json:"model-tag" yaml:"model-tag"`
+	ModelName       string    `json:"model-name" yaml:"model-name"`
+	Name            string    `json:"name" yaml:"name"`
+	CharmURL        string    `json:"charm-url" yaml:"charm-url"`
+	CharmName       string    `json:"charm-name" yaml:"charm-name"`
+	CharmRevision   int       `json:"charm-revision" yaml:"charm-revision"`
+	Life            string    `json:"life,omitempty" yaml:"life,omitempty"`
+	Status          string    `json:"status,omitempty" yaml:"status,omitempty"`
+	WorkloadVersion string    `json:"workload-version,omitempty" yaml:"workload-version,omitempty"`
+	Exposed         bool      `json:"exposed" yaml:"exposed"`
+	Subordinate     bool      `json:"subordinate" yaml:"subordinate"`
+	UpdatedAt       time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// FindApplicationsResponse holds the applications found by
+// FindApplications.
+type FindApplicationsResponse struct {
+	Applications []ApplicationInfo `json:"applications" yaml:"applications"`
+}
+
+// FindUnitsRequest holds the filters used to find units in JIMM's
+// inventory. Units must match every non-empty filter.
+type FindUnitsRequest struct {
+	// ModelTag restricts the search to the model with the given tag.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Application is the name of the unit's application.
+	Application string `json:"application,omitempty"`
+
+	// MachineID is the ID of the machine hosting the unit.
+	MachineID string `json:"machine-id,omitempty"`
+
+	// WorkloadStatus is the current workload status of the unit.
+	WorkloadStatus string `json:"workload-status,omitempty"`
+
+	// AgentStatus is the current status of the unit's agent.
+	AgentStatus string `json:"agent-status,omitempty"`
+
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// UnitInfo holds the details of a unit in JIMM's inventory.
+type UnitInfo struct {
+	ModelTag        string    `json:"model-tag" yaml:"model-tag"`
+	ModelName       string    `json:"model-name" yaml:"model-name"`
+	Name            string    `json:"name" yaml:"name"`
+	Application     string    `json:"application" yaml:"application"`
+	CharmURL        string    `json:"charm-url" yaml:"charm-url"`
+	MachineID       string    `json:"machine-id,omitempty" yaml:"machine-id,omitempty"`
+	Principal       string    `json:"principal,omitempty" yaml:"principal,omitempty"`
+	Life            string    `json:"life,omitempty" yaml:"life,omitempty"`
+	WorkloadStatus  string    `json:"workload-status,omitempty" yaml:"workload-status,omitempty"`
+	WorkloadMessage string    `json:"workload-message,omitempty" yaml:"workload-message,omitempty"`
+	AgentStatus     string    `json:"agent-status,omitempty" yaml:"agent-status,omitempty"`
+	UpdatedAt       time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// FindUnitsResponse holds the units found by FindUnits.
+type FindUnitsResponse struct {
+	Units []UnitInfo `json:"units" yaml:"units"`
+}
+
+// FindMachinesRequest holds the filters used to find machines in JIMM's
+// inventory. Machines must match every non-empty filter.
+type FindMachinesRequest struct {
+	// ModelTag restricts the search to the model with the given tag.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Base is the OS base of the machine, for example "ubuntu@22.04".
+	Base string `json:"base,omitempty"`
+
+	// InstanceID is the provider's ID for the machine instance.
+	InstanceID string `json:"instance-id,omitempty"`
+
+	// AgentStatus is the current status of the machine's agent.
+	AgentStatus string `json:"agent-status,omitempty"`
+
+	Limit  int `json:"limit,omitempty"`
+	Offset int `json:"offset,omitempty"`
+}
+
+// MachineInfo holds the details of a machine in JIMM's inventory.
+type MachineInfo struct {
+	ModelTag       string    `json:"model-tag" yaml:"model-tag"`
+	ModelName      string    `json:"model-name" yaml:"model-name"`
+	ID             string    `json:"id" yaml:"id"`
+	Base           string    `json:"base,omitempty" yaml:"base,omitempty"`
+	InstanceID     string    `json:"instance-id,omitempty" yaml:"instance-id,omitempty"`
+	Hostname       string    `json:"hostname,omitempty" yaml:"hostname,omitempty"`
+	Cores          int64     `json:"cores,omitempty" yaml:"cores,omitempty"`
+	Life           string    `json:"life,omitempty" yaml:"life,omitempty"`
+	AgentStatus    string    `json:"agent-status,omitempty" yaml:"agent-status,omitempty"`
+	InstanceStatus string    `json:"instance-status,omitempty" yaml:"instance-status,omitempty"`
+	UpdatedAt      time.Time `json:"updated-at" yaml:"updated-at"`
+}
+
+// FindMachinesResponse holds the machines found by FindMachines.
+type FindMachinesResponse struct {
+	Machines []MachineInfo `json:"machines" yaml:"machines"`
+}
+
+// CharmReportRequest holds the parameters used to produce a report of the
+// charms deployed across all controllers.
+type CharmReportRequest struct {
+	// Charm, if not empty, restricts the report to the charm with the
+	// given name.
+	Charm string `json:"charm,omitempty"`
+
+	// DeprecatedChannels holds channels, or channel tracks, that
+	// applications should no longer be deployed from. Applications
+	// deployed from any of these channels are flagged in the report.
+	DeprecatedChannels []string `json:"deprecated-channels,omitempty"`
+}
+
+// CharmReport holds a report of the charms deployed across all
+// controllers.
+type CharmReport struct {
+	// Charms holds the deployed charms, ordered by name.
+	Charms []CharmReportCharm `json:"charms" yaml:"charms"`
+
+	// Errors maps the tag of each model whose status could not be
+	// retrieved to the error encountered.
+	Errors map[string]string `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// CharmReportCharm holds the applications running a charm.
+type CharmReportCharm struct {
+	// Name is the name of the charm.
+	Name string `json:"name" yaml:"name"`
+
+	// Applications holds every application running the charm.
+	Applications []CharmReportApplication `json:"applications" yaml:"applications"`
+}
+
+// CharmReportApplication holds the details of an application in a
+// CharmReport.
+type CharmReportApplication struct {
+	Controller   string `json:"controller" yaml:"controller"`
+	ModelTag     string `json:"model-tag" yaml:"model-tag"`
+	ModelName    string `json:"model-name" yaml:"model-name"`
+	Application  string `json:"application" yaml:"application"`
+	Origin       string `json:"origin,omitempty" yaml:"origin,omitempty"`
+	Channel      string `json:"channel,omitempty" yaml:"channel,omitempty"`
+	Revision     int    `json:"revision" yaml:"revision"`
+	Base         string `json:"base,omitempty" yaml:"base,omitempty"`
+	Architecture string `json:"architecture,omitempty" yaml:"architecture,omitempty"`
+
+	// LatestRevision is the newest revision of the charm deployed from
+	// charmhub anywhere in the fleet from the same channel, for the same
+	// base and architecture, as this application. Charmhub revisions
+	// are not comparable between channels, bases or architectures.
+	LatestRevision int `json:"latest-revision,omitempty" yaml:"latest-revision,omitempty"`
+
+	// Outdated is set if the application's charm revision is older
+	// than its LatestRevision.
+	Outdated bool `json:"outdated,omitempty" yaml:"outdated,omitempty"`
+
+	// DeprecatedChannel is set if the application is deployed from a
+	// deprecated channel.
+	DeprecatedChannel bool `json:"deprecated-channel,omitempty" yaml:"deprecated-channel,omitempty"`
+}
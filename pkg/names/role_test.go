@@ -0,0 +1,64 @@
+// Copyright 2024 Canonical.
+
+package names_test
+
+import (
+	"fmt"
+	"testing"
+
+	qt "github.com/frankban/quicktest"
+	"github.com/google/uuid"
+
+	"github.com/canonical/jimm/v3/pkg/names"
+)
+
+func TestParseRoleTag(t *testing.T) {
+	c := qt.New(t)
+	uuid := uuid.NewString()
+
+	tests := []struct {
+		tag           string
+		expectedError string
+		expectedTag   string
+		expectedId    string
+	}{{
+		tag:         fmt.Sprintf("role-%s", uuid),
+		expectedId:  uuid,
+		expectedTag: fmt.Sprintf("role-%s", uuid),
+	}, {
+		tag:         fmt.Sprintf("role-%s#assignee", uuid),
+		expectedId:  fmt.Sprintf("%s#assignee", uuid),
+		expectedTag: fmt.Sprintf("role-%s#assignee", uuid),
+	}, {
+		tag:           "role-db-operators",
+		expectedError: "\"role-db-operators\" is not a valid role tag",
+	}, {
+		tag:           fmt.Sprintf("group-%s", uuid),
+		expectedError: fmt.Sprintf("\"group-%s\" is not a valid role tag", uuid),
+	}}
+
+	for i, test := range tests {
+		test := test
+		c.Run(fmt.Sprintf("test case %d", i), func(c *qt.C) {
+			rt, err := names.ParseRoleTag(test.tag)
+			if test.expectedError == "" {
+				c.Assert(err, qt.IsNil)
+				c.Assert(rt.Id(), qt.Equals, test.expectedId)
+				c.Assert(rt.Kind(), qt.Equals, "role")
+				c.Assert(rt.String(), qt.Equals, test.expectedTag)
+			} else {
+				c.Assert(err, qt.ErrorMatches, test.expectedError)
+			}
+		})
+	}
+}
+
+func TestIsValidRoleName(t *testing.T) {
+	c := qt.New(t)
+
+	c.Check(names.IsValidRoleName("db-operators"), qt.IsTrue)
+	c.Check(names.IsValidRoleName("Role.1"), qt.IsTrue)
+	c.Check(names.IsValidRoleName("1role"), qt.IsFalse)
+	c.Check(names.IsValidRoleName("role#assignee"), qt.IsFalse)
+	c.Check(names.IsValidRoleName(""), qt.IsFalse)
+}